@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AlertaController struct {
+	alertaService *services.AlertaService
+}
+
+func NewAlertaController(alertaService *services.AlertaService) *AlertaController {
+	return &AlertaController{alertaService: alertaService}
+}
+
+// GET /api/alertas
+func (c *AlertaController) GetConfiguracoes(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	configuracoes, err := c.alertaService.GetConfiguracoes(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(configuracoes)
+}
+
+// PUT /api/alertas
+func (c *AlertaController) SalvarConfiguracao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.ConfiguracaoAlertaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	configuracao, err := c.alertaService.SalvarConfiguracao(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Alertas atualizados com sucesso",
+		"data":    configuracao,
+	})
+}
+
+// DELETE /api/alertas/:id
+func (c *AlertaController) DeleteConfiguracao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	configuracaoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.alertaService.DeleteConfiguracao(userID, uint(configuracaoID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Configuração de alerta excluída com sucesso"})
+}
@@ -3,8 +3,9 @@ package controllers
 import (
 	"net/mail"
 	"strings"
-	"time"
 
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
 	"github.com/Vicente/Password-Mobile-App/backend/app/services"
 	"github.com/Vicente/Password-Mobile-App/backend/app/types"
 	"github.com/gofiber/fiber/v2"
@@ -12,40 +13,42 @@ import (
 
 type AuthController struct {
 	AuthService *services.AuthService
+	relogio     relogio.Relogio
 }
 
-func NewAuthController(authService *services.AuthService) *AuthController {
+func NewAuthController(authService *services.AuthService, relogio relogio.Relogio) *AuthController {
 	return &AuthController{
 		AuthService: authService,
+		relogio:     relogio,
 	}
 }
 
 func (c *AuthController) validateEmail(email string) error {
 	email = strings.TrimSpace(strings.ToLower(email))
 	if email == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "email é obrigatório")
+		return i18n.NovoErro(i18n.EmailObrigatorio)
 	}
 
 	if _, err := mail.ParseAddress(email); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "formato de email inválido")
+		return i18n.NovoErro(i18n.EmailFormatoInvalido)
 	}
 
 	if !strings.Contains(email, "@") || !strings.Contains(email, ".") {
-		return fiber.NewError(fiber.StatusBadRequest, "email deve conter @ e domínio válido")
+		return i18n.NovoErro(i18n.EmailDominioInvalido)
 	}
 
 	if strings.HasPrefix(email, ".") || strings.HasSuffix(email, ".") ||
 		strings.HasPrefix(email, "@") || strings.HasSuffix(email, "@") {
-		return fiber.NewError(fiber.StatusBadRequest, "formato de email inválido")
+		return i18n.NovoErro(i18n.EmailFormatoInvalido)
 	}
 
 	return nil
 }
 
 func (c *AuthController) validateBirthDate(birthDate types.Date) error {
-	today := time.Now()
+	today := c.relogio.Agora()
 	if birthDate.Time.After(today) {
-		return fiber.NewError(fiber.StatusBadRequest, "data de nascimento deve ser anterior à data atual")
+		return i18n.NovoErro(i18n.DataNascimentoFutura)
 	}
 	return nil
 }
@@ -54,28 +57,20 @@ func (c *AuthController) Signup(ctx *fiber.Ctx) error {
 	var req types.SignupRequest
 
 	if err := ctx.BodyParser(&req); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Dados inválidos: " + err.Error(),
-		})
+		return ctx.Status(fiber.StatusBadRequest).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidosDetalhe, err.Error()))
 	}
 
 	if err := c.validateEmail(req.Email); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return ctx.Status(fiber.StatusBadRequest).JSON(i18n.Resposta(ctx, err))
 	}
 
 	if err := c.validateBirthDate(req.DataNascimento); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return ctx.Status(fiber.StatusBadRequest).JSON(i18n.Resposta(ctx, err))
 	}
 
 	_, err := c.AuthService.Signup(&req)
 	if err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return ctx.Status(fiber.StatusBadRequest).JSON(i18n.Resposta(ctx, err))
 	}
 
 	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
@@ -87,22 +82,16 @@ func (c *AuthController) Login(ctx *fiber.Ctx) error {
 	var req types.LoginRequest
 
 	if err := ctx.BodyParser(&req); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Dados inválidos: " + err.Error(),
-		})
+		return ctx.Status(fiber.StatusBadRequest).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidosDetalhe, err.Error()))
 	}
 
 	if err := c.validateEmail(req.Email); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return ctx.Status(fiber.StatusBadRequest).JSON(i18n.Resposta(ctx, err))
 	}
 
 	response, err := c.AuthService.Login(&req)
 	if err != nil {
-		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return ctx.Status(fiber.StatusUnauthorized).JSON(i18n.Resposta(ctx, err))
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -115,9 +104,7 @@ func (c *AuthController) GetUserProfile(ctx *fiber.Ctx) error {
 	
 	profile, err := c.AuthService.GetUserProfile(userID)
 	if err != nil {
-		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return ctx.Status(fiber.StatusNotFound).JSON(i18n.Resposta(ctx, err))
 	}
 	
 	return ctx.Status(fiber.StatusOK).JSON(profile)
@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"errors"
 	"strconv"
 
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
 	"github.com/Vicente/Password-Mobile-App/backend/app/services"
 	"github.com/Vicente/Password-Mobile-App/backend/app/types"
 	"github.com/gofiber/fiber/v2"
@@ -22,24 +24,30 @@ func (c *DespesaController) CreateDespesa(ctx *fiber.Ctx) error {
 
 	var req types.CreateDespesaRequest
 	if err := ctx.BodyParser(&req); err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": "Dados inválidos"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
 	}
 
 	if req.Descricao == "" {
-		return ctx.Status(400).JSON(fiber.Map{"error": "Descrição é obrigatória"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DescricaoObrigatoria))
 	}
 
 	if req.Valor <= 0 {
-		return ctx.Status(400).JSON(fiber.Map{"error": "O valor deve ser maior que zero"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.ValorNaoPositivo))
 	}
 
-	if req.MesReferencia == "" {
-		return ctx.Status(400).JSON(fiber.Map{"error": "Mês de referência é obrigatório"})
+	if req.MesReferencia == "" && req.Data == "" {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.MesOuDataObrigatorio))
 	}
 
 	despesa, err := c.despesaService.CreateDespesa(userID, &req)
 	if err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": err.Error()})
+		var duplicataErr *services.DuplicataError
+		if errors.As(err, &duplicataErr) {
+			resposta := i18n.Resposta(ctx, err)
+			resposta["duplicatas"] = duplicataErr.Candidatos
+			return ctx.Status(409).JSON(resposta)
+		}
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
 	}
 
 	return ctx.Status(201).JSON(despesa)
@@ -51,12 +59,12 @@ func (c *DespesaController) GetDespesasByMonth(ctx *fiber.Ctx) error {
 	mesReferencia := ctx.Params("mesReferencia")
 
 	if mesReferencia == "" {
-		return ctx.Status(400).JSON(fiber.Map{"error": "Mês de referência é obrigatório"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.MesReferenciaObrigatorio))
 	}
 
 	despesas, err := c.despesaService.GetDespesasByMonth(userID, mesReferencia)
 	if err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
 	}
 
 	if len(despesas) == 0 {
@@ -72,7 +80,7 @@ func (c *DespesaController) GetDespesasByUser(ctx *fiber.Ctx) error {
 
 	despesas, err := c.despesaService.GetDespesasByUser(userID)
 	if err != nil {
-		return ctx.Status(500).JSON(fiber.Map{"error": "Erro interno do servidor"})
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
 	}
 
 	if len(despesas) == 0 {
@@ -89,25 +97,25 @@ func (c *DespesaController) UpdateDespesa(ctx *fiber.Ctx) error {
 	idParam := ctx.Params("id")
 	despesaID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": "ID inválido"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
 	}
 
 	var req types.UpdateDespesaRequest
 	if err := ctx.BodyParser(&req); err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": "Dados inválidos"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
 	}
 
 	if req.Descricao == "" {
-		return ctx.Status(400).JSON(fiber.Map{"error": "Descrição é obrigatória"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DescricaoObrigatoria))
 	}
 
 	if req.Valor <= 0 {
-		return ctx.Status(400).JSON(fiber.Map{"error": "O valor deve ser maior que zero"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.ValorNaoPositivo))
 	}
 
 	despesa, err := c.despesaService.UpdateDespesa(userID, uint(despesaID), &req)
 	if err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
 	}
 
 	return ctx.Status(200).JSON(fiber.Map{
@@ -123,12 +131,69 @@ func (c *DespesaController) DeleteDespesa(ctx *fiber.Ctx) error {
 	idParam := ctx.Params("id")
 	despesaID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": "ID inválido"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
 	}
 
 	if err := c.despesaService.DeleteDespesa(userID, uint(despesaID)); err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
 	}
 
 	return ctx.Status(200).JSON(fiber.Map{"message": "Despesa excluída com sucesso"})
-} 
\ No newline at end of file
+}
+
+// POST /api/despesas/batch
+func (c *DespesaController) BatchDespesas(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.BatchDespesaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	resultado, err := c.despesaService.BatchDespesas(userID, i18n.IdiomaDaRequisicao(ctx), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	if !resultado.Sucesso && resultado.Aplicadas == 0 {
+		return ctx.Status(422).JSON(resultado)
+	}
+
+	return ctx.Status(200).JSON(resultado)
+}
+
+// GET /api/despesas/duplicatas
+func (c *DespesaController) GetDuplicatas(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	grupos, err := c.despesaService.GetDuplicatas(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(grupos) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhuma possível duplicata encontrada"})
+	}
+
+	return ctx.JSON(grupos)
+}
+
+// POST /api/despesas/duplicatas/mesclar
+func (c *DespesaController) MesclarDuplicatas(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.MesclarDuplicatasRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	despesa, err := c.despesaService.MesclarDuplicatas(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Despesas mescladas com sucesso",
+		"data":    despesa,
+	})
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type DispositivoController struct {
+	dispositivoService *services.DispositivoService
+}
+
+func NewDispositivoController(dispositivoService *services.DispositivoService) *DispositivoController {
+	return &DispositivoController{dispositivoService: dispositivoService}
+}
+
+// POST /api/dispositivos
+func (c *DispositivoController) RegistrarDispositivo(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.RegistrarDispositivoRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	dispositivo, err := c.dispositivoService.RegistrarDispositivo(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(dispositivo)
+}
+
+// GET /api/dispositivos
+func (c *DispositivoController) GetDispositivosByUser(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	dispositivos, err := c.dispositivoService.GetDispositivosByUser(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(dispositivos) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhum dispositivo encontrado"})
+	}
+
+	return ctx.JSON(dispositivos)
+}
+
+// DELETE /api/dispositivos/:id
+func (c *DispositivoController) DeleteDispositivo(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	dispositivoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.dispositivoService.DeleteDispositivo(userID, uint(dispositivoID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Dispositivo removido com sucesso"})
+}
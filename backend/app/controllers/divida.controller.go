@@ -0,0 +1,230 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type DividaController struct {
+	dividaService *services.DividaService
+}
+
+func NewDividaController(dividaService *services.DividaService) *DividaController {
+	return &DividaController{dividaService: dividaService}
+}
+
+// POST /api/dividas
+func (c *DividaController) CreateDivida(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.DividaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	divida, err := c.dividaService.CreateDivida(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(divida)
+}
+
+// GET /api/dividas
+func (c *DividaController) GetDividasByUser(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	dividas, err := c.dividaService.GetDividasByUser(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(dividas) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhuma dívida encontrada"})
+	}
+
+	return ctx.JSON(dividas)
+}
+
+// GET /api/dividas/:id
+func (c *DividaController) GetDividaByID(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	dividaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	divida, err := c.dividaService.GetDividaByID(userID, uint(dividaID))
+	if err != nil {
+		if i18n.TemCodigo(err, i18n.DividaNaoEncontrada) {
+			return ctx.Status(404).JSON(i18n.Resposta(ctx, err))
+		}
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(divida)
+}
+
+// PUT /api/dividas/:id
+func (c *DividaController) UpdateDivida(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	dividaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.DividaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	divida, err := c.dividaService.UpdateDivida(userID, uint(dividaID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Dívida atualizada com sucesso",
+		"data":    divida,
+	})
+}
+
+// DELETE /api/dividas/:id
+func (c *DividaController) DeleteDivida(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	dividaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.dividaService.DeleteDivida(userID, uint(dividaID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Dívida excluída com sucesso"})
+}
+
+// GET /api/dividas/:id/cronograma
+func (c *DividaController) GetCronograma(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	dividaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	parcelas, err := c.dividaService.GetCronograma(userID, uint(dividaID))
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(parcelas)
+}
+
+// GET /api/dividas/:id/saldo
+func (c *DividaController) GetEvolucaoSaldo(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	dividaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	evolucao, err := c.dividaService.GetEvolucaoSaldo(userID, uint(dividaID))
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	if len(evolucao) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "A dívida ainda não começou"})
+	}
+
+	return ctx.JSON(evolucao)
+}
+
+// GET /api/dividas/:id/projecao?valorMensal=500
+func (c *DividaController) GetProjecaoQuitacao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	dividaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	valorMensal := 0.0
+	if param := ctx.Query("valorMensal"); param != "" {
+		if valorMensal, err = strconv.ParseFloat(param, 64); err != nil {
+			return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.ValorMensalInvalido))
+		}
+	}
+
+	projecao, err := c.dividaService.GetProjecaoQuitacao(userID, uint(dividaID), valorMensal)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(projecao)
+}
+
+// POST /api/dividas/:id/pagamentos
+func (c *DividaController) RegistrarPagamento(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	dividaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.PagamentoDividaRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+		}
+	}
+
+	divida, err := c.dividaService.RegistrarPagamento(userID, uint(dividaID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(divida)
+}
+
+// DELETE /api/dividas/:id/pagamentos/:pagamentoId
+func (c *DividaController) DeletePagamento(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	dividaID, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	pagamentoID, err := strconv.ParseUint(ctx.Params("pagamentoId"), 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	divida, err := c.dividaService.DeletePagamento(userID, uint(dividaID), uint(pagamentoID))
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Pagamento excluído com sucesso",
+		"data":    divida,
+	})
+}
@@ -0,0 +1,324 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type DivisaoController struct {
+	divisaoService *services.DivisaoService
+}
+
+func NewDivisaoController(divisaoService *services.DivisaoService) *DivisaoController {
+	return &DivisaoController{divisaoService: divisaoService}
+}
+
+// POST /api/grupos
+func (c *DivisaoController) CreateGrupo(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.GrupoDivisaoRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	grupo, err := c.divisaoService.CreateGrupo(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(grupo)
+}
+
+// GET /api/grupos
+func (c *DivisaoController) GetGruposByUser(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	grupos, err := c.divisaoService.GetGruposByUser(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(grupos) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhum grupo encontrado"})
+	}
+
+	return ctx.JSON(grupos)
+}
+
+// GET /api/grupos/:id
+func (c *DivisaoController) GetGrupoByID(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	grupo, err := c.divisaoService.GetGrupoByID(userID, uint(grupoID))
+	if err != nil {
+		if i18n.TemCodigo(err, i18n.GrupoNaoEncontrado) {
+			return ctx.Status(404).JSON(i18n.Resposta(ctx, err))
+		}
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(grupo)
+}
+
+// PUT /api/grupos/:id
+func (c *DivisaoController) UpdateGrupo(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.GrupoDivisaoRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	grupo, err := c.divisaoService.UpdateGrupo(userID, uint(grupoID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Grupo atualizado com sucesso",
+		"data":    grupo,
+	})
+}
+
+// DELETE /api/grupos/:id
+func (c *DivisaoController) DeleteGrupo(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.divisaoService.DeleteGrupo(userID, uint(grupoID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Grupo excluído com sucesso"})
+}
+
+// POST /api/grupos/:id/membros
+func (c *DivisaoController) AddMembro(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.MembroGrupoDivisaoRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	grupo, err := c.divisaoService.AddMembro(userID, uint(grupoID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(grupo)
+}
+
+// DELETE /api/grupos/:id/membros/:userId
+func (c *DivisaoController) RemoverMembro(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	membroParam := ctx.Params("userId")
+	membroID, err := strconv.ParseUint(membroParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.divisaoService.RemoverMembro(userID, uint(grupoID), uint(membroID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Membro removido com sucesso"})
+}
+
+// POST /api/grupos/:id/divisoes
+func (c *DivisaoController) CreateDivisao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.DivisaoRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	divisao, err := c.divisaoService.CreateDivisao(userID, uint(grupoID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(divisao)
+}
+
+// GET /api/grupos/:id/divisoes
+func (c *DivisaoController) GetDivisoes(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	divisoes, err := c.divisaoService.GetDivisoes(userID, uint(grupoID))
+	if err != nil {
+		if i18n.TemCodigo(err, i18n.GrupoNaoEncontrado) {
+			return ctx.Status(404).JSON(i18n.Resposta(ctx, err))
+		}
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(divisoes) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhuma divisão encontrada"})
+	}
+
+	return ctx.JSON(divisoes)
+}
+
+// DELETE /api/grupos/:id/divisoes/:divisaoId
+func (c *DivisaoController) DeleteDivisao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	divisaoParam := ctx.Params("divisaoId")
+	divisaoID, err := strconv.ParseUint(divisaoParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.divisaoService.DeleteDivisao(userID, uint(grupoID), uint(divisaoID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Divisão excluída com sucesso"})
+}
+
+// GET /api/grupos/:id/saldos
+func (c *DivisaoController) GetSaldos(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	saldos, err := c.divisaoService.GetSaldos(userID, uint(grupoID))
+	if err != nil {
+		if i18n.TemCodigo(err, i18n.GrupoNaoEncontrado) {
+			return ctx.Status(404).JSON(i18n.Resposta(ctx, err))
+		}
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(saldos)
+}
+
+// POST /api/grupos/:id/acertos
+func (c *DivisaoController) RegistrarAcerto(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.AcertoDivisaoRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	acerto, err := c.divisaoService.RegistrarAcerto(userID, uint(grupoID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(acerto)
+}
+
+// GET /api/grupos/:id/acertos
+func (c *DivisaoController) GetAcertos(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	acertos, err := c.divisaoService.GetAcertos(userID, uint(grupoID))
+	if err != nil {
+		if i18n.TemCodigo(err, i18n.GrupoNaoEncontrado) {
+			return ctx.Status(404).JSON(i18n.Resposta(ctx, err))
+		}
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(acertos) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhum acerto encontrado"})
+	}
+
+	return ctx.JSON(acertos)
+}
+
+// DELETE /api/grupos/:id/acertos/:acertoId
+func (c *DivisaoController) DeleteAcerto(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	grupoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	acertoParam := ctx.Params("acertoId")
+	acertoID, err := strconv.ParseUint(acertoParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.divisaoService.DeleteAcerto(userID, uint(grupoID), uint(acertoID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Acerto excluído com sucesso"})
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type EnvelopeController struct {
+	envelopeService *services.EnvelopeService
+}
+
+func NewEnvelopeController(envelopeService *services.EnvelopeService) *EnvelopeController {
+	return &EnvelopeController{envelopeService: envelopeService}
+}
+
+// POST /api/envelopes
+func (c *EnvelopeController) CreateEnvelope(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.EnvelopeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	envelope, err := c.envelopeService.CreateEnvelope(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(envelope)
+}
+
+// GET /api/envelopes
+func (c *EnvelopeController) GetEnvelopesByUser(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	envelopes, err := c.envelopeService.GetEnvelopesByUser(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(envelopes) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhum envelope encontrado"})
+	}
+
+	return ctx.JSON(envelopes)
+}
+
+// GET /api/envelopes/:id
+func (c *EnvelopeController) GetEnvelopeByID(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	envelopeID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	envelope, err := c.envelopeService.GetEnvelopeByID(userID, uint(envelopeID))
+	if err != nil {
+		if i18n.TemCodigo(err, i18n.EnvelopeNaoEncontrado) {
+			return ctx.Status(404).JSON(i18n.Resposta(ctx, err))
+		}
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(envelope)
+}
+
+// PUT /api/envelopes/:id
+func (c *EnvelopeController) UpdateEnvelope(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	envelopeID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.EnvelopeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	envelope, err := c.envelopeService.UpdateEnvelope(userID, uint(envelopeID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Envelope atualizado com sucesso",
+		"data":    envelope,
+	})
+}
+
+// DELETE /api/envelopes/:id
+func (c *EnvelopeController) DeleteEnvelope(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	envelopeID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.envelopeService.DeleteEnvelope(userID, uint(envelopeID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Envelope excluído com sucesso"})
+}
+
+// POST /api/envelopes/:id/aportes
+func (c *EnvelopeController) RegistrarAporte(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	envelopeID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.MovimentoEnvelopeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	envelope, err := c.envelopeService.RegistrarAporte(userID, uint(envelopeID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(envelope)
+}
+
+// POST /api/envelopes/:id/gastos
+func (c *EnvelopeController) RegistrarGasto(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	envelopeID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.MovimentoEnvelopeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	envelope, err := c.envelopeService.RegistrarGasto(userID, uint(envelopeID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(envelope)
+}
+
+// DELETE /api/envelopes/:id/movimentos/:movimentoId
+func (c *EnvelopeController) DeleteMovimento(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	envelopeID, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	movimentoID, err := strconv.ParseUint(ctx.Params("movimentoId"), 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.envelopeService.DeleteMovimento(userID, uint(envelopeID), uint(movimentoID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Movimento excluído com sucesso"})
+}
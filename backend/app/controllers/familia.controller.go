@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type FamiliaController struct {
+	familiaService *services.FamiliaService
+}
+
+func NewFamiliaController(familiaService *services.FamiliaService) *FamiliaController {
+	return &FamiliaController{familiaService: familiaService}
+}
+
+// POST /api/familia
+func (c *FamiliaController) CreateFamilia(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.FamiliaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	familia, err := c.familiaService.CreateFamilia(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(familia)
+}
+
+// GET /api/familia
+func (c *FamiliaController) GetFamilia(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	familia, err := c.familiaService.GetFamilia(userID)
+	if err != nil {
+		if i18n.TemCodigo(err, i18n.FamiliaSemParticipacao) {
+			return ctx.Status(404).JSON(i18n.Resposta(ctx, err))
+		}
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(familia)
+}
+
+// PUT /api/familia
+func (c *FamiliaController) UpdateFamilia(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.FamiliaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	familia, err := c.familiaService.UpdateFamilia(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Família atualizada com sucesso",
+		"data":    familia,
+	})
+}
+
+// POST /api/familia/sair
+func (c *FamiliaController) SairDaFamilia(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	if err := c.familiaService.SairDaFamilia(userID); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Você saiu da família"})
+}
+
+// PUT /api/familia/membros/:userId
+func (c *FamiliaController) AlterarPapel(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("userId")
+	membroID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.PapelMembroRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	familia, err := c.familiaService.AlterarPapel(userID, uint(membroID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Papel do membro atualizado com sucesso",
+		"data":    familia,
+	})
+}
+
+// DELETE /api/familia/membros/:userId
+func (c *FamiliaController) RemoverMembro(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("userId")
+	membroID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.familiaService.RemoverMembro(userID, uint(membroID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Membro removido com sucesso"})
+}
+
+// POST /api/familia/convites
+func (c *FamiliaController) ConvidarMembro(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.ConviteFamiliaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	convite, err := c.familiaService.ConvidarMembro(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(convite)
+}
+
+// GET /api/familia/convites
+func (c *FamiliaController) GetConvitesDaFamilia(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	convites, err := c.familiaService.GetConvitesDaFamilia(userID)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	if len(convites) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhum convite encontrado"})
+	}
+
+	return ctx.JSON(convites)
+}
+
+// DELETE /api/familia/convites/:id
+func (c *FamiliaController) RevogarConvite(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	conviteID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.familiaService.RevogarConvite(userID, uint(conviteID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Convite revogado com sucesso"})
+}
+
+// GET /api/familia/resumo/mes/:mesReferencia
+func (c *FamiliaController) GetResumoFamilia(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	mesReferencia := ctx.Params("mesReferencia")
+
+	resumo, err := c.familiaService.GetResumoFamilia(userID, mesReferencia)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(resumo)
+}
+
+// GET /api/convites
+func (c *FamiliaController) GetMeusConvites(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	convites, err := c.familiaService.GetMeusConvites(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(convites) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhum convite encontrado"})
+	}
+
+	return ctx.JSON(convites)
+}
+
+// POST /api/convites/:id/aceitar
+func (c *FamiliaController) AceitarConvite(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	conviteID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.AceitarConviteRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+		}
+	}
+
+	familia, err := c.familiaService.AceitarConvite(userID, uint(conviteID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Convite aceito com sucesso",
+		"data":    familia,
+	})
+}
+
+// POST /api/convites/:id/recusar
+func (c *FamiliaController) RecusarConvite(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	conviteID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.familiaService.RecusarConvite(userID, uint(conviteID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Convite recusado"})
+}
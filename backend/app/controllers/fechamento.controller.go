@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type FechamentoController struct {
+	fechamentoService *services.FechamentoService
+}
+
+func NewFechamentoController(fechamentoService *services.FechamentoService) *FechamentoController {
+	return &FechamentoController{fechamentoService: fechamentoService}
+}
+
+// GET /api/fechamentos
+func (c *FechamentoController) GetFechamentos(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	fechamentos, err := c.fechamentoService.GetFechamentos(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(fechamentos) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhum fechamento encontrado"})
+	}
+
+	return ctx.JSON(fechamentos)
+}
+
+// GET /api/fechamentos/:mesReferencia
+func (c *FechamentoController) GetFechamento(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	mesReferencia := ctx.Params("mesReferencia")
+
+	fechamento, err := c.fechamentoService.GetFechamento(userID, i18n.IdiomaDaRequisicao(ctx), mesReferencia)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(fechamento)
+}
+
+// POST /api/fechamentos/:mesReferencia/fechar
+func (c *FechamentoController) FecharMes(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	mesReferencia := ctx.Params("mesReferencia")
+
+	fechamento, err := c.fechamentoService.FecharMes(userID, mesReferencia)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Mês fechado com sucesso",
+		"data":    fechamento,
+	})
+}
+
+// POST /api/fechamentos/:mesReferencia/reabrir
+func (c *FechamentoController) ReabrirMes(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	mesReferencia := ctx.Params("mesReferencia")
+
+	var req types.ReabrirMesRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	fechamento, err := c.fechamentoService.ReabrirMes(userID, mesReferencia, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Mês reaberto com sucesso",
+		"data":    fechamento,
+	})
+}
+
+// GET /api/fechamentos/configuracao
+func (c *FechamentoController) GetConfiguracao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	configuracao, err := c.fechamentoService.GetConfiguracao(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(configuracao)
+}
+
+// PUT /api/fechamentos/configuracao
+func (c *FechamentoController) SalvarConfiguracao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.ConfiguracaoFechamentoRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	configuracao, err := c.fechamentoService.SalvarConfiguracao(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Configuração de fechamento salva com sucesso",
+		"data":    configuracao,
+	})
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxTamanhoArquivoImportacao = 4 * 1024 * 1024
+
+type ImportacaoController struct {
+	importacaoService *services.ImportacaoService
+}
+
+func NewImportacaoController(importacaoService *services.ImportacaoService) *ImportacaoController {
+	return &ImportacaoController{importacaoService: importacaoService}
+}
+
+func lerArquivoImportacao(ctx *fiber.Ctx) (string, []byte, error) {
+	arquivo, err := ctx.FormFile("arquivo")
+	if err != nil {
+		return "", nil, i18n.NovoErro(i18n.ArquivoObrigatorio)
+	}
+
+	if arquivo.Size > maxTamanhoArquivoImportacao {
+		return "", nil, i18n.NovoErro(i18n.ArquivoMuitoGrande)
+	}
+
+	f, err := arquivo.Open()
+	if err != nil {
+		return "", nil, i18n.NovoErro(i18n.ArquivoIlegivel)
+	}
+	defer f.Close()
+
+	conteudo, err := io.ReadAll(f)
+	if err != nil {
+		return "", nil, i18n.NovoErro(i18n.ArquivoIlegivel)
+	}
+
+	return arquivo.Filename, conteudo, nil
+}
+
+func lerMapeamentoCSV(ctx *fiber.Ctx) (*types.MapeamentoCSV, error) {
+	var mapeamento types.MapeamentoCSV
+
+	if raw := ctx.FormValue("mapeamento"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapeamento); err != nil {
+			return nil, i18n.NovoErro(i18n.MapeamentoInvalido)
+		}
+	}
+
+	return &mapeamento, nil
+}
+
+// POST /api/importacoes/csv/preview
+func (c *ImportacaoController) PreviewCSV(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	_, conteudo, err := lerArquivoImportacao(ctx)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	mapeamento, err := lerMapeamentoCSV(ctx)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	preview, err := c.importacaoService.PreviewCSV(userID, i18n.IdiomaDaRequisicao(ctx), conteudo, mapeamento)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(preview)
+}
+
+// POST /api/importacoes/csv
+func (c *ImportacaoController) ImportarCSV(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	nomeArquivo, conteudo, err := lerArquivoImportacao(ctx)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	mapeamento, err := lerMapeamentoCSV(ctx)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	importacao, err := c.importacaoService.ImportarCSV(userID, nomeArquivo, conteudo, mapeamento)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(importacao)
+}
+
+// GET /api/importacoes
+func (c *ImportacaoController) GetImportacoesByUser(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	importacoes, err := c.importacaoService.GetImportacoesByUser(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(importacoes) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhuma importação encontrada"})
+	}
+
+	return ctx.JSON(importacoes)
+}
+
+// DELETE /api/importacoes/:id
+func (c *ImportacaoController) DesfazerImportacao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	importacaoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.importacaoService.DesfazerImportacao(userID, uint(importacaoID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Importação desfeita com sucesso"})
+}
+
+// POST /api/importacoes/ofx/preview
+func (c *ImportacaoController) PreviewOFX(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	_, conteudo, err := lerArquivoImportacao(ctx)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	preview, err := c.importacaoService.PreviewOFX(userID, conteudo, ctx.FormValue("importarDuplicatas") == "true")
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(preview)
+}
+
+// POST /api/importacoes/ofx
+func (c *ImportacaoController) ImportarOFX(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	nomeArquivo, conteudo, err := lerArquivoImportacao(ctx)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	importacao, err := c.importacaoService.ImportarOFX(userID, nomeArquivo, conteudo, ctx.FormValue("importarDuplicatas") == "true")
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(importacao)
+}
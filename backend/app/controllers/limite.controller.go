@@ -3,6 +3,7 @@ package controllers
 import (
 	"strconv"
 
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
 	"github.com/Vicente/Password-Mobile-App/backend/app/services"
 	"github.com/Vicente/Password-Mobile-App/backend/app/types"
 	"github.com/gofiber/fiber/v2"
@@ -22,20 +23,20 @@ func (c *LimiteController) CreateLimite(ctx *fiber.Ctx) error {
 
 	var req types.CreateLimiteRequest
 	if err := ctx.BodyParser(&req); err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": "Dados inválidos"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
 	}
 
 	if req.Valor <= 0 {
-		return ctx.Status(400).JSON(fiber.Map{"error": "O valor deve ser maior que zero"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.ValorNaoPositivo))
 	}
 
 	if req.MesReferencia == "" {
-		return ctx.Status(400).JSON(fiber.Map{"error": "Mês de referência é obrigatório"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.MesReferenciaObrigatorio))
 	}
 
 	limite, err := c.limiteService.CreateLimite(userID, &req)
 	if err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
 	}
 
 	return ctx.Status(201).JSON(limite)
@@ -47,15 +48,15 @@ func (c *LimiteController) GetLimiteByMonth(ctx *fiber.Ctx) error {
 	mesReferencia := ctx.Params("mesReferencia")
 
 	if mesReferencia == "" {
-		return ctx.Status(400).JSON(fiber.Map{"error": "Mês de referência é obrigatório"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.MesReferenciaObrigatorio))
 	}
 
 	limite, err := c.limiteService.GetLimiteByMonth(userID, mesReferencia)
 	if err != nil {
-		if err.Error() == "limite não encontrado para este mês" {
+		if i18n.TemCodigo(err, i18n.LimiteNaoEncontradoMes) {
 			return ctx.Status(204).JSON(fiber.Map{"message": "Nenhum limite encontrado para este mês"})
 		}
-		return ctx.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
 	}
 
 	return ctx.JSON(limite)
@@ -67,7 +68,7 @@ func (c *LimiteController) GetLimitesByUser(ctx *fiber.Ctx) error {
 
 	limites, err := c.limiteService.GetLimitesByUser(userID)
 	if err != nil {
-		return ctx.Status(500).JSON(fiber.Map{"error": "Erro interno do servidor"})
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
 	}
 
 	if len(limites) == 0 {
@@ -84,21 +85,21 @@ func (c *LimiteController) UpdateLimite(ctx *fiber.Ctx) error {
 	idParam := ctx.Params("id")
 	limiteID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": "ID inválido"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
 	}
 
 	var req types.UpdateLimiteRequest
 	if err := ctx.BodyParser(&req); err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": "Dados inválidos"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
 	}
 
 	if req.Valor <= 0 {
-		return ctx.Status(400).JSON(fiber.Map{"error": "O valor deve ser maior que zero"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.ValorNaoPositivo))
 	}
 
 	limite, err := c.limiteService.UpdateLimite(userID, uint(limiteID), &req)
 	if err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
 	}
 
 	return ctx.Status(200).JSON(fiber.Map{
@@ -114,12 +115,65 @@ func (c *LimiteController) DeleteLimite(ctx *fiber.Ctx) error {
 	idParam := ctx.Params("id")
 	limiteID, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": "ID inválido"})
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
 	}
 
 	if err := c.limiteService.DeleteLimite(userID, uint(limiteID)); err != nil {
-		return ctx.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
 	}
 
 	return ctx.Status(200).JSON(fiber.Map{"message": "Limite excluído com sucesso"})
-} 
\ No newline at end of file
+}
+
+// GET /api/limites/mes/:mesReferencia
+func (c *LimiteController) GetLimitesDoMes(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+	mesReferencia := ctx.Params("mesReferencia")
+
+	if mesReferencia == "" {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.MesReferenciaObrigatorio))
+	}
+
+	limites, err := c.limiteService.GetLimitesDoMes(userID, mesReferencia)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	if limites.Geral == nil && len(limites.Categorias) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhum limite encontrado para este mês"})
+	}
+
+	return ctx.JSON(limites)
+}
+
+// GET /api/limite/configuracao
+func (c *LimiteController) GetConfiguracao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	configuracao, err := c.limiteService.GetConfiguracao(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(configuracao)
+}
+
+// PUT /api/limite/configuracao
+func (c *LimiteController) SalvarConfiguracao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.ConfiguracaoLimiteRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	configuracao, err := c.limiteService.SalvarConfiguracao(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Configuração de limite atualizada com sucesso",
+		"data":    configuracao,
+	})
+}
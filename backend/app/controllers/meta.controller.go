@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MetaController struct {
+	metaService *services.MetaService
+}
+
+func NewMetaController(metaService *services.MetaService) *MetaController {
+	return &MetaController{metaService: metaService}
+}
+
+// POST /api/metas
+func (c *MetaController) CreateMeta(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.MetaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	meta, err := c.metaService.CreateMeta(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(meta)
+}
+
+// GET /api/metas
+func (c *MetaController) GetMetasByUser(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	metas, err := c.metaService.GetMetasByUser(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(metas) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhuma meta encontrada"})
+	}
+
+	return ctx.JSON(metas)
+}
+
+// GET /api/metas/:id
+func (c *MetaController) GetMetaByID(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	metaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	meta, err := c.metaService.GetMetaByID(userID, uint(metaID))
+	if err != nil {
+		if i18n.TemCodigo(err, i18n.MetaNaoEncontrada) {
+			return ctx.Status(404).JSON(i18n.Resposta(ctx, err))
+		}
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(meta)
+}
+
+// PUT /api/metas/:id
+func (c *MetaController) UpdateMeta(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	metaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.MetaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	meta, err := c.metaService.UpdateMeta(userID, uint(metaID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Meta atualizada com sucesso",
+		"data":    meta,
+	})
+}
+
+// DELETE /api/metas/:id
+func (c *MetaController) DeleteMeta(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	metaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.metaService.DeleteMeta(userID, uint(metaID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Meta excluída com sucesso"})
+}
+
+// POST /api/metas/:id/aportes
+func (c *MetaController) RegistrarAporte(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	metaID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.AporteMetaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	meta, err := c.metaService.RegistrarAporte(userID, uint(metaID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(meta)
+}
+
+// DELETE /api/metas/:id/aportes/:aporteId
+func (c *MetaController) DeleteAporte(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	metaID, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	aporteID, err := strconv.ParseUint(ctx.Params("aporteId"), 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	meta, err := c.metaService.DeleteAporte(userID, uint(metaID), uint(aporteID))
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Aporte excluído com sucesso",
+		"data":    meta,
+	})
+}
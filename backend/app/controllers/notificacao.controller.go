@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificacaoController struct {
+	notificacaoService *services.NotificacaoService
+}
+
+func NewNotificacaoController(notificacaoService *services.NotificacaoService) *NotificacaoController {
+	return &NotificacaoController{notificacaoService: notificacaoService}
+}
+
+// GET /api/notificacoes?naoLidas=true
+func (c *NotificacaoController) GetNotificacoes(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	notificacoes, err := c.notificacaoService.GetNotificacoes(userID, ctx.QueryBool("naoLidas"))
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(notificacoes)
+}
+
+func (c *NotificacaoController) marcar(ctx *fiber.Ctx, lida bool) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	notificacaoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	notificacao, err := c.notificacaoService.MarcarLida(userID, uint(notificacaoID), lida)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(notificacao)
+}
+
+// PUT /api/notificacoes/:id/lida
+func (c *NotificacaoController) MarcarLida(ctx *fiber.Ctx) error {
+	return c.marcar(ctx, true)
+}
+
+// PUT /api/notificacoes/:id/nao-lida
+func (c *NotificacaoController) MarcarNaoLida(ctx *fiber.Ctx) error {
+	return c.marcar(ctx, false)
+}
+
+// PUT /api/notificacoes/lidas
+func (c *NotificacaoController) MarcarTodasComoLidas(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	atualizadas, err := c.notificacaoService.MarcarTodasComoLidas(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message":     "Notificações marcadas como lidas",
+		"atualizadas": atualizadas,
+	})
+}
+
+// DELETE /api/notificacoes/:id
+func (c *NotificacaoController) DeleteNotificacao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	notificacaoID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.notificacaoService.DeleteNotificacao(userID, uint(notificacaoID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Notificação excluída com sucesso"})
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PeriodoController struct {
+	periodoService *services.PeriodoService
+}
+
+func NewPeriodoController(periodoService *services.PeriodoService) *PeriodoController {
+	return &PeriodoController{periodoService: periodoService}
+}
+
+// GET /api/periodos/configuracao
+func (c *PeriodoController) GetConfiguracao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	configuracao, err := c.periodoService.GetConfiguracao(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(configuracao)
+}
+
+// PUT /api/periodos/configuracao
+func (c *PeriodoController) SalvarConfiguracao(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.ConfiguracaoPeriodoRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	configuracao, err := c.periodoService.SalvarConfiguracao(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Configuração de período salva com sucesso",
+		"data":    configuracao,
+	})
+}
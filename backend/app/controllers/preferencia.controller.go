@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PreferenciaController struct {
+	preferenciaService *services.PreferenciaService
+}
+
+func NewPreferenciaController(preferenciaService *services.PreferenciaService) *PreferenciaController {
+	return &PreferenciaController{preferenciaService: preferenciaService}
+}
+
+// GET /api/preferencias
+func (c *PreferenciaController) GetPreferencias(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	preferencias, err := c.preferenciaService.GetPreferencias(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	return ctx.JSON(preferencias)
+}
+
+// PATCH /api/preferencias
+func (c *PreferenciaController) AtualizarPreferencias(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.PreferenciaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	preferencias, err := c.preferenciaService.AtualizarPreferencias(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Preferências atualizadas com sucesso",
+		"data":    preferencias,
+	})
+}
@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PrevisaoController struct {
+	previsaoService *services.PrevisaoService
+}
+
+func NewPrevisaoController(previsaoService *services.PrevisaoService) *PrevisaoController {
+	return &PrevisaoController{previsaoService: previsaoService}
+}
+
+// GET /api/previsao/mes/:mesReferencia
+func (c *PrevisaoController) GetPrevisaoDoMes(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+	mesReferencia := ctx.Params("mesReferencia")
+
+	if mesReferencia == "" {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.MesReferenciaObrigatorio))
+	}
+
+	previsao, err := c.previsaoService.GetPrevisaoDoMes(userID, mesReferencia)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(previsao)
+}
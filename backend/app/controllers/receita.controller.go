@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReceitaController struct {
+	receitaService *services.ReceitaService
+}
+
+func NewReceitaController(receitaService *services.ReceitaService) *ReceitaController {
+	return &ReceitaController{receitaService: receitaService}
+}
+
+// GET /api/receita/mes/:mesReferencia
+func (c *ReceitaController) GetReceitasByMonth(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+	mesReferencia := ctx.Params("mesReferencia")
+
+	if mesReferencia == "" {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.MesReferenciaObrigatorio))
+	}
+
+	receitas, err := c.receitaService.GetReceitasByMonth(userID, mesReferencia)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	if len(receitas) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhuma receita encontrada para este mês"})
+	}
+
+	return ctx.JSON(receitas)
+}
+
+// GET /api/receitas
+func (c *ReceitaController) GetReceitasByUser(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	receitas, err := c.receitaService.GetReceitasByUser(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(receitas) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhuma receita encontrada"})
+	}
+
+	return ctx.JSON(receitas)
+}
@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type RelatorioController struct {
+	relatorioService *services.RelatorioService
+}
+
+func NewRelatorioController(relatorioService *services.RelatorioService) *RelatorioController {
+	return &RelatorioController{relatorioService: relatorioService}
+}
+
+// GET /api/relatorios/ano/:ano
+func (c *RelatorioController) GetRelatorioAnual(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	relatorio, err := c.relatorioService.GetRelatorioAnual(userID, ctx.Params("ano"), ctx.QueryInt("topCategorias"))
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(relatorio)
+}
+
+// GET /api/relatorios/periodo?inicio=YYYY-MM&fim=YYYY-MM
+func (c *RelatorioController) GetRelatorioPeriodo(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	relatorio, err := c.relatorioService.GetRelatorioPeriodo(userID, ctx.Query("inicio"), ctx.Query("fim"), ctx.QueryInt("topCategorias"))
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(relatorio)
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ResumoController struct {
+	resumoService *services.ResumoService
+}
+
+func NewResumoController(resumoService *services.ResumoService) *ResumoController {
+	return &ResumoController{resumoService: resumoService}
+}
+
+// GET /api/resumo/mes/:mesReferencia
+func (c *ResumoController) GetResumoDoMes(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+	mesReferencia := ctx.Params("mesReferencia")
+
+	if mesReferencia == "" {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.MesReferenciaObrigatorio))
+	}
+
+	resumo, err := c.resumoService.GetResumoDoMes(userID, mesReferencia)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(resumo)
+}
+
+// GET /api/resumo/periodo?data=YYYY-MM-DD
+func (c *ResumoController) GetResumoPeriodo(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	resumo, err := c.resumoService.GetResumoPeriodo(userID, ctx.Query("data"))
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(resumo)
+}
+
+// GET /api/resumo/periodos?quantidade=6
+func (c *ResumoController) GetHistoricoPeriodos(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	quantidade := 6
+	if param := ctx.Query("quantidade"); param != "" {
+		valor, err := strconv.Atoi(param)
+		if err != nil {
+			return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.QuantidadeInvalida))
+		}
+		quantidade = valor
+	}
+
+	historico, err := c.resumoService.GetHistoricoPeriodos(userID, quantidade)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(historico)
+}
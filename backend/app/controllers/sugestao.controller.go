@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SugestaoController struct {
+	sugestaoService *services.SugestaoService
+}
+
+func NewSugestaoController(sugestaoService *services.SugestaoService) *SugestaoController {
+	return &SugestaoController{sugestaoService: sugestaoService}
+}
+
+// GET /api/limites/sugestao/:mesReferencia?meses=6
+func (c *SugestaoController) GetSugestoes(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+	mesReferencia := ctx.Params("mesReferencia")
+
+	if mesReferencia == "" {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.MesReferenciaObrigatorio))
+	}
+
+	sugestoes, err := c.sugestaoService.GetSugestoes(userID, mesReferencia, ctx.QueryInt("meses"))
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(sugestoes)
+}
+
+// POST /api/limites/sugestao/:mesReferencia/aplicar
+func (c *SugestaoController) AplicarSugestoes(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+	mesReferencia := ctx.Params("mesReferencia")
+
+	if mesReferencia == "" {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.MesReferenciaObrigatorio))
+	}
+
+	var req types.AplicarSugestaoRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&req); err != nil {
+			return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+		}
+	}
+
+	resultado, err := c.sugestaoService.AplicarSugestoes(userID, mesReferencia, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.JSON(resultado)
+}
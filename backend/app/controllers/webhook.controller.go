@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookController struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookController(webhookService *services.WebhookService) *WebhookController {
+	return &WebhookController{webhookService: webhookService}
+}
+
+// POST /api/webhooks
+func (c *WebhookController) CreateWebhook(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	var req types.WebhookRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	webhook, err := c.webhookService.CreateWebhook(userID, &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(201).JSON(webhook)
+}
+
+// GET /api/webhooks
+func (c *WebhookController) GetWebhooksByUser(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	webhooks, err := c.webhookService.GetWebhooksByUser(userID)
+	if err != nil {
+		return ctx.Status(500).JSON(i18n.RespostaCodigo(ctx, i18n.ErroInterno))
+	}
+
+	if len(webhooks) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhum webhook encontrado"})
+	}
+
+	return ctx.JSON(webhooks)
+}
+
+// PUT /api/webhooks/:id
+func (c *WebhookController) UpdateWebhook(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	webhookID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	var req types.WebhookRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.DadosInvalidos))
+	}
+
+	webhook, err := c.webhookService.UpdateWebhook(userID, uint(webhookID), &req)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{
+		"message": "Webhook atualizado com sucesso",
+		"data":    webhook,
+	})
+}
+
+// DELETE /api/webhooks/:id
+func (c *WebhookController) DeleteWebhook(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	webhookID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	if err := c.webhookService.DeleteWebhook(userID, uint(webhookID)); err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(200).JSON(fiber.Map{"message": "Webhook excluído com sucesso"})
+}
+
+// POST /api/webhooks/:id/teste
+func (c *WebhookController) EnviarTeste(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	webhookID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	entrega, err := c.webhookService.EnviarTeste(userID, uint(webhookID))
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	return ctx.Status(202).JSON(entrega)
+}
+
+// GET /api/webhooks/:id/entregas
+func (c *WebhookController) GetEntregas(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uint)
+
+	idParam := ctx.Params("id")
+	webhookID, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.RespostaCodigo(ctx, i18n.IDInvalido))
+	}
+
+	entregas, err := c.webhookService.GetEntregas(userID, uint(webhookID))
+	if err != nil {
+		return ctx.Status(400).JSON(i18n.Resposta(ctx, err))
+	}
+
+	if len(entregas) == 0 {
+		return ctx.Status(204).JSON(fiber.Map{"message": "Nenhuma entrega encontrada"})
+	}
+
+	return ctx.JSON(entregas)
+}
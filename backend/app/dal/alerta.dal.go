@@ -0,0 +1,49 @@
+package dal
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type AlertaDAL struct {
+	db *gorm.DB
+}
+
+func NewAlertaDAL(db *gorm.DB) *AlertaDAL {
+	return &AlertaDAL{db: db}
+}
+
+func (a *AlertaDAL) GetConfiguracoesByUser(userID uint) ([]types.ConfiguracaoAlerta, error) {
+	var configuracoes []types.ConfiguracaoAlerta
+	err := a.db.Where("user_id = ?", userID).Order("categoria").Find(&configuracoes).Error
+	return configuracoes, err
+}
+
+func (a *AlertaDAL) GetConfiguracaoByCategoria(userID uint, categoria string) (*types.ConfiguracaoAlerta, error) {
+	var configuracao types.ConfiguracaoAlerta
+	err := a.db.Unscoped().Where("user_id = ? AND LOWER(categoria) = LOWER(?)", userID, categoria).First(&configuracao).Error
+	if err != nil {
+		return nil, err
+	}
+	return &configuracao, nil
+}
+
+// SaveConfiguracao também restaura configurações excluídas, já que a
+// categoria faz parte de um índice único.
+func (a *AlertaDAL) SaveConfiguracao(configuracao *types.ConfiguracaoAlerta) error {
+	configuracao.DeletedAt = gorm.DeletedAt{}
+	return a.db.Unscoped().Save(configuracao).Error
+}
+
+func (a *AlertaDAL) GetConfiguracaoByID(id uint, userID uint) (*types.ConfiguracaoAlerta, error) {
+	var configuracao types.ConfiguracaoAlerta
+	err := a.db.Where("id = ? AND user_id = ?", id, userID).First(&configuracao).Error
+	if err != nil {
+		return nil, err
+	}
+	return &configuracao, nil
+}
+
+func (a *AlertaDAL) DeleteConfiguracao(id uint, userID uint) error {
+	return a.db.Where("id = ? AND user_id = ?", id, userID).Delete(&types.ConfiguracaoAlerta{}).Error
+}
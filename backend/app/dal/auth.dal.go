@@ -3,6 +3,7 @@ package dal
 import (
 	"errors"
 
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
 	"github.com/Vicente/Password-Mobile-App/backend/app/types"
 	"gorm.io/gorm"
 )
@@ -21,7 +22,7 @@ func (d *AuthDAL) CreateUser(user *types.User) error {
 	var existingUser types.User
 	result := d.DB.Where("email = ?", user.Email).First(&existingUser)
 	if result.Error == nil {
-		return errors.New("email já cadastrado")
+		return i18n.NovoErro(i18n.EmailJaCadastrado)
 	} else if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return result.Error
 	}
@@ -15,7 +15,21 @@ func NewDespesaDAL(db *gorm.DB) *DespesaDAL {
 	return &DespesaDAL{db: db}
 }
 
+func (d *DespesaDAL) Transaction(fn func(txDAL *DespesaDAL) error) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&DespesaDAL{db: tx})
+	})
+}
+
+// CreateDespesa atribui a despesa à família de quem a lançou, se houver.
 func (d *DespesaDAL) CreateDespesa(despesa *types.Despesa) error {
+	if despesa.FamiliaID == nil {
+		familiaID, err := familiaDoUsuario(d.db, despesa.UserID)
+		if err != nil {
+			return err
+		}
+		despesa.FamiliaID = familiaID
+	}
 	return d.db.Create(despesa).Error
 }
 
@@ -25,19 +39,19 @@ func (d *DespesaDAL) GetDespesasByUserAndMonth(userID uint, mesReferencia time.T
 	firstDay := time.Date(mesReferencia.Year(), mesReferencia.Month(), 1, 0, 0, 0, 0, time.UTC)
 	lastDay := firstDay.AddDate(0, 1, -1)
 	
-	err := d.db.Where("user_id = ? AND mes_referencia >= ? AND mes_referencia <= ?", userID, firstDay, lastDay).Find(&despesas).Error
+	err := d.db.Scopes(escopoUsuario(userID)).Where("mes_referencia >= ? AND mes_referencia <= ?", firstDay, lastDay).Find(&despesas).Error
 	return despesas, err
 }
 
 func (d *DespesaDAL) GetDespesasByUser(userID uint) ([]types.Despesa, error) {
 	var despesas []types.Despesa
-	err := d.db.Where("user_id = ?", userID).Order("mes_referencia DESC").Find(&despesas).Error
+	err := d.db.Scopes(escopoUsuario(userID)).Order("mes_referencia DESC").Find(&despesas).Error
 	return despesas, err
 }
 
 func (d *DespesaDAL) GetDespesaByID(id uint, userID uint) (*types.Despesa, error) {
 	var despesa types.Despesa
-	err := d.db.Where("id = ? AND user_id = ?", id, userID).First(&despesa).Error
+	err := d.db.Scopes(escopoUsuario(userID)).Where("id = ?", id).First(&despesa).Error
 	if err != nil {
 		return nil, err
 	}
@@ -49,5 +63,115 @@ func (d *DespesaDAL) UpdateDespesa(despesa *types.Despesa) error {
 }
 
 func (d *DespesaDAL) DeleteDespesa(id uint, userID uint) error {
-	return d.db.Where("id = ? AND user_id = ?", id, userID).Delete(&types.Despesa{}).Error
-} 
\ No newline at end of file
+	return d.db.Scopes(escopoUsuario(userID)).Where("id = ?", id).Delete(&types.Despesa{}).Error
+}
+
+func (d *DespesaDAL) GetDespesasByUserBetweenMonths(userID uint, inicio time.Time, fim time.Time) ([]types.Despesa, error) {
+	var despesas []types.Despesa
+	err := d.db.Scopes(escopoUsuario(userID)).Where("mes_referencia >= ? AND mes_referencia <= ?", inicio, fim).Order("mes_referencia, id").Find(&despesas).Error
+	return despesas, err
+}
+
+func (d *DespesaDAL) GetDespesasByValorBetweenMonths(userID uint, valor float64, inicio time.Time, fim time.Time) ([]types.Despesa, error) {
+	var despesas []types.Despesa
+	err := d.db.Scopes(escopoUsuario(userID)).Where("valor BETWEEN ? AND ? AND mes_referencia >= ? AND mes_referencia <= ?", valor-0.005, valor+0.005, inicio, fim).Order("mes_referencia, id").Find(&despesas).Error
+	return despesas, err
+}
+
+func (d *DespesaDAL) GetDespesasComValorRepetido(userID uint) ([]types.Despesa, error) {
+	var despesas []types.Despesa
+
+	repetidos := d.db.Model(&types.Despesa{}).Select("valor").Scopes(escopoUsuario(userID)).Group("valor").Having("COUNT(*) > 1")
+
+	err := d.db.Scopes(escopoUsuario(userID)).Where("valor IN (?)", repetidos).Order("valor, mes_referencia, id").Find(&despesas).Error
+	return despesas, err
+}
+
+// PossuiPagamentoDivida indica se a despesa foi gerada pelo pagamento de uma
+// dívida.
+func (d *DespesaDAL) PossuiPagamentoDivida(despesaID uint) (bool, error) {
+	var count int64
+	err := d.db.Model(&types.PagamentoDivida{}).Where("despesa_id = ?", despesaID).Count(&count).Error
+	return count > 0, err
+}
+
+// PossuiGastoEnvelope indica se a despesa foi paga com um envelope.
+func (d *DespesaDAL) PossuiGastoEnvelope(despesaID uint) (bool, error) {
+	var count int64
+	err := d.db.Model(&types.MovimentoEnvelope{}).Where("despesa_id = ?", despesaID).Count(&count).Error
+	return count > 0, err
+}
+
+func (d *DespesaDAL) GetDespesasByIDs(ids []uint, userID uint) ([]types.Despesa, error) {
+	var despesas []types.Despesa
+	err := d.db.Scopes(escopoUsuario(userID)).Where("id IN ?", ids).Find(&despesas).Error
+	return despesas, err
+}
+
+func (d *DespesaDAL) GetTotaisPorCategoria(userID uint, mesReferencia time.Time) ([]types.TotalCategoria, error) {
+	var totais []types.TotalCategoria
+
+	firstDay := time.Date(mesReferencia.Year(), mesReferencia.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, -1)
+
+	err := d.db.Model(&types.Despesa{}).
+		Select("COALESCE(categoria, '') AS categoria, COALESCE(SUM(valor), 0) AS total, COUNT(*) AS quantidade").
+		Scopes(escopoUsuario(userID)).
+		Where("mes_referencia >= ? AND mes_referencia <= ?", firstDay, lastDay).
+		Group("COALESCE(categoria, '')").
+		Order("total DESC").
+		Scan(&totais).Error
+	return totais, err
+}
+
+func (d *DespesaDAL) GetTotalByUserAndMonth(userID uint, mesReferencia time.Time, categoria string) (float64, error) {
+	var total float64
+
+	firstDay := time.Date(mesReferencia.Year(), mesReferencia.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, -1)
+
+	query := d.db.Model(&types.Despesa{}).
+		Select("COALESCE(SUM(valor), 0)").
+		Scopes(escopoUsuario(userID)).
+		Where("mes_referencia >= ? AND mes_referencia <= ?", firstDay, lastDay)
+	if categoria != "" {
+		query = query.Where("LOWER(COALESCE(categoria, '')) = LOWER(?)", categoria)
+	}
+
+	err := query.Scan(&total).Error
+	return total, err
+}
+
+// GetTotalEntreDatas soma as despesas pela data do gasto. Despesas sem data
+// contam no dia em que foram lançadas.
+func (d *DespesaDAL) GetTotalEntreDatas(userID uint, inicio time.Time, fim time.Time) (float64, int64, error) {
+	var resultado struct {
+		Total      float64
+		Quantidade int64
+	}
+
+	err := d.db.Model(&types.Despesa{}).
+		Select("COALESCE(SUM(valor), 0) AS total, COUNT(*) AS quantidade").
+		Scopes(escopoUsuario(userID)).
+		Where("COALESCE(data, created_at::date) >= ? AND COALESCE(data, created_at::date) <= ?", inicio, fim).
+		Scan(&resultado).Error
+	return resultado.Total, resultado.Quantidade, err
+}
+
+// GetTotaisPorMembro soma as despesas do mês por quem as lançou.
+func (d *DespesaDAL) GetTotaisPorMembro(userID uint, mesReferencia time.Time) ([]types.TotalMembro, error) {
+	var totais []types.TotalMembro
+
+	firstDay := time.Date(mesReferencia.Year(), mesReferencia.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, -1)
+
+	err := d.db.Model(&types.Despesa{}).
+		Select("despesas.user_id, users.nome, COALESCE(SUM(despesas.valor), 0) AS total, COUNT(*) AS quantidade").
+		Joins("JOIN users ON users.id = despesas.user_id").
+		Scopes(escopoUsuario(userID)).
+		Where("despesas.mes_referencia >= ? AND despesas.mes_referencia <= ?", firstDay, lastDay).
+		Group("despesas.user_id, users.nome").
+		Order("total DESC").
+		Scan(&totais).Error
+	return totais, err
+}
@@ -0,0 +1,49 @@
+package dal
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type DispositivoDAL struct {
+	db *gorm.DB
+}
+
+func NewDispositivoDAL(db *gorm.DB) *DispositivoDAL {
+	return &DispositivoDAL{db: db}
+}
+
+// GetDispositivoByToken busca também dispositivos excluídos, já que o token
+// é único na tabela.
+func (d *DispositivoDAL) GetDispositivoByToken(token string) (*types.DispositivoPush, error) {
+	var dispositivo types.DispositivoPush
+	err := d.db.Unscoped().Where("token = ?", token).First(&dispositivo).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dispositivo, nil
+}
+
+func (d *DispositivoDAL) SaveDispositivo(dispositivo *types.DispositivoPush) error {
+	dispositivo.DeletedAt = gorm.DeletedAt{}
+	return d.db.Unscoped().Save(dispositivo).Error
+}
+
+func (d *DispositivoDAL) GetDispositivosByUser(userID uint) ([]types.DispositivoPush, error) {
+	var dispositivos []types.DispositivoPush
+	err := d.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&dispositivos).Error
+	return dispositivos, err
+}
+
+func (d *DispositivoDAL) GetDispositivoByID(id uint, userID uint) (*types.DispositivoPush, error) {
+	var dispositivo types.DispositivoPush
+	err := d.db.Where("id = ? AND user_id = ?", id, userID).First(&dispositivo).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dispositivo, nil
+}
+
+func (d *DispositivoDAL) DeleteDispositivo(id uint, userID uint) error {
+	return d.db.Where("id = ? AND user_id = ?", id, userID).Delete(&types.DispositivoPush{}).Error
+}
@@ -0,0 +1,108 @@
+package dal
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type DividaDAL struct {
+	db *gorm.DB
+}
+
+func NewDividaDAL(db *gorm.DB) *DividaDAL {
+	return &DividaDAL{db: db}
+}
+
+// NaTransacao retorna um DividaDAL que usa a transação em andamento de
+// txDAL, para gravar o pagamento junto com a despesa que ele gera.
+func (v *DividaDAL) NaTransacao(txDAL *DespesaDAL) *DividaDAL {
+	return &DividaDAL{db: txDAL.db}
+}
+
+func (v *DividaDAL) CreateDivida(divida *types.Divida) error {
+	return v.db.Create(divida).Error
+}
+
+func (v *DividaDAL) GetDividasByUser(userID uint) ([]types.Divida, error) {
+	var dividas []types.Divida
+	err := v.db.Where("user_id = ?", userID).Order("data_inicio DESC, id DESC").Find(&dividas).Error
+	return dividas, err
+}
+
+func (v *DividaDAL) GetDividaByID(id uint, userID uint) (*types.Divida, error) {
+	var divida types.Divida
+	err := v.db.Where("id = ? AND user_id = ?", id, userID).First(&divida).Error
+	if err != nil {
+		return nil, err
+	}
+	return &divida, nil
+}
+
+func (v *DividaDAL) UpdateDivida(divida *types.Divida) error {
+	return v.db.Save(divida).Error
+}
+
+// DeleteDivida exclui a dívida e seus pagamentos. As despesas e receitas
+// geradas pelos pagamentos são mantidas.
+func (v *DividaDAL) DeleteDivida(id uint, userID uint) error {
+	return v.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("divida_id = ? AND user_id = ?", id, userID).Delete(&types.PagamentoDivida{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ? AND user_id = ?", id, userID).Delete(&types.Divida{}).Error
+	})
+}
+
+func (v *DividaDAL) CreatePagamento(pagamento *types.PagamentoDivida) error {
+	return v.db.Create(pagamento).Error
+}
+
+// CreatePagamentoComReceita grava o recebimento e a receita correspondente
+// na mesma transação. A receita é atribuída à família de quem recebeu.
+func (v *DividaDAL) CreatePagamentoComReceita(pagamento *types.PagamentoDivida, receita *types.Receita) error {
+	return v.db.Transaction(func(tx *gorm.DB) error {
+		familiaID, err := familiaDoUsuario(tx, receita.UserID)
+		if err != nil {
+			return err
+		}
+		receita.FamiliaID = familiaID
+		if err := tx.Create(receita).Error; err != nil {
+			return err
+		}
+		pagamento.ReceitaID = &receita.ID
+		return tx.Create(pagamento).Error
+	})
+}
+
+func (v *DividaDAL) GetPagamentosByDivida(dividaID uint, userID uint) ([]types.PagamentoDivida, error) {
+	var pagamentos []types.PagamentoDivida
+	err := v.db.Where("divida_id = ? AND user_id = ?", dividaID, userID).Order("data, id").Find(&pagamentos).Error
+	return pagamentos, err
+}
+
+func (v *DividaDAL) GetPagamentosByUser(userID uint) ([]types.PagamentoDivida, error) {
+	var pagamentos []types.PagamentoDivida
+	err := v.db.Where("user_id = ?", userID).Order("data, id").Find(&pagamentos).Error
+	return pagamentos, err
+}
+
+func (v *DividaDAL) GetPagamentoByID(id uint, dividaID uint, userID uint) (*types.PagamentoDivida, error) {
+	var pagamento types.PagamentoDivida
+	err := v.db.Where("id = ? AND divida_id = ? AND user_id = ?", id, dividaID, userID).First(&pagamento).Error
+	if err != nil {
+		return nil, err
+	}
+	return &pagamento, nil
+}
+
+// DeletePagamento exclui o pagamento e, se houver, a receita gerada por ele.
+func (v *DividaDAL) DeletePagamento(pagamento *types.PagamentoDivida) error {
+	return v.db.Transaction(func(tx *gorm.DB) error {
+		if pagamento.ReceitaID != nil {
+			if err := tx.Where("id = ? AND user_id = ?", *pagamento.ReceitaID, pagamento.UserID).Delete(&types.Receita{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("id = ? AND user_id = ?", pagamento.ID, pagamento.UserID).Delete(&types.PagamentoDivida{}).Error
+	})
+}
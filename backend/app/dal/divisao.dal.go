@@ -0,0 +1,144 @@
+package dal
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DivisaoDAL struct {
+	db *gorm.DB
+}
+
+func NewDivisaoDAL(db *gorm.DB) *DivisaoDAL {
+	return &DivisaoDAL{db: db}
+}
+
+// CreateGrupo cria o grupo e inclui os membros informados na mesma transação.
+func (d *DivisaoDAL) CreateGrupo(grupo *types.GrupoDivisao, userIDs []uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(grupo).Error; err != nil {
+			return err
+		}
+		for _, userID := range userIDs {
+			membro := types.MembroGrupoDivisao{GrupoID: grupo.ID, UserID: userID}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&membro).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (d *DivisaoDAL) GetGruposByUser(userID uint) ([]types.GrupoDivisao, error) {
+	var grupos []types.GrupoDivisao
+	err := d.db.
+		Where("id IN (?)", d.db.Model(&types.MembroGrupoDivisao{}).Select("grupo_id").Where("user_id = ?", userID)).
+		Order("created_at DESC").
+		Find(&grupos).Error
+	return grupos, err
+}
+
+// GetGrupoByID só encontra grupos dos quais o usuário é membro.
+func (d *DivisaoDAL) GetGrupoByID(id uint, userID uint) (*types.GrupoDivisao, error) {
+	var grupo types.GrupoDivisao
+	err := d.db.
+		Where("id = ? AND id IN (?)", id, d.db.Model(&types.MembroGrupoDivisao{}).Select("grupo_id").Where("user_id = ?", userID)).
+		First(&grupo).Error
+	if err != nil {
+		return nil, err
+	}
+	return &grupo, nil
+}
+
+func (d *DivisaoDAL) UpdateGrupo(grupo *types.GrupoDivisao) error {
+	return d.db.Save(grupo).Error
+}
+
+// DeleteGrupo exclui o grupo com todas as divisões, acertos e membros.
+func (d *DivisaoDAL) DeleteGrupo(id uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		divisoes := tx.Model(&types.Divisao{}).Select("id").Where("grupo_id = ?", id)
+		if err := tx.Where("divisao_id IN (?)", divisoes).Delete(&types.ParticipanteDivisao{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("grupo_id = ?", id).Delete(&types.Divisao{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("grupo_id = ?", id).Delete(&types.AcertoDivisao{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("grupo_id = ?", id).Delete(&types.MembroGrupoDivisao{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&types.GrupoDivisao{}, id).Error
+	})
+}
+
+func (d *DivisaoDAL) GetMembros(grupoID uint) ([]types.MembroGrupoDivisao, error) {
+	var membros []types.MembroGrupoDivisao
+	err := d.db.Preload("User").Where("grupo_id = ?", grupoID).Order("created_at, id").Find(&membros).Error
+	return membros, err
+}
+
+func (d *DivisaoDAL) AddMembro(membro *types.MembroGrupoDivisao) error {
+	return d.db.Clauses(clause.OnConflict{DoNothing: true}).Create(membro).Error
+}
+
+// DeleteMembro remove o vínculo de vez, para que o usuário possa ser
+// incluído de novo. As divisões e acertos dele continuam no grupo.
+func (d *DivisaoDAL) DeleteMembro(grupoID uint, userID uint) error {
+	return d.db.Unscoped().Where("grupo_id = ? AND user_id = ?", grupoID, userID).Delete(&types.MembroGrupoDivisao{}).Error
+}
+
+// CreateDivisao grava a divisão junto com as partes dos participantes.
+func (d *DivisaoDAL) CreateDivisao(divisao *types.Divisao) error {
+	return d.db.Create(divisao).Error
+}
+
+func (d *DivisaoDAL) GetDivisoesByGrupo(grupoID uint) ([]types.Divisao, error) {
+	var divisoes []types.Divisao
+	err := d.db.Preload("Participantes").Where("grupo_id = ?", grupoID).Order("data DESC, id DESC").Find(&divisoes).Error
+	return divisoes, err
+}
+
+func (d *DivisaoDAL) GetDivisaoByID(id uint, grupoID uint) (*types.Divisao, error) {
+	var divisao types.Divisao
+	err := d.db.Preload("Participantes").Where("id = ? AND grupo_id = ?", id, grupoID).First(&divisao).Error
+	if err != nil {
+		return nil, err
+	}
+	return &divisao, nil
+}
+
+func (d *DivisaoDAL) DeleteDivisao(id uint, grupoID uint) error {
+	return d.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("divisao_id = ?", id).Delete(&types.ParticipanteDivisao{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ? AND grupo_id = ?", id, grupoID).Delete(&types.Divisao{}).Error
+	})
+}
+
+func (d *DivisaoDAL) CreateAcerto(acerto *types.AcertoDivisao) error {
+	return d.db.Create(acerto).Error
+}
+
+func (d *DivisaoDAL) GetAcertosByGrupo(grupoID uint) ([]types.AcertoDivisao, error) {
+	var acertos []types.AcertoDivisao
+	err := d.db.Where("grupo_id = ?", grupoID).Order("data DESC, id DESC").Find(&acertos).Error
+	return acertos, err
+}
+
+func (d *DivisaoDAL) GetAcertoByID(id uint, grupoID uint) (*types.AcertoDivisao, error) {
+	var acerto types.AcertoDivisao
+	err := d.db.Where("id = ? AND grupo_id = ?", id, grupoID).First(&acerto).Error
+	if err != nil {
+		return nil, err
+	}
+	return &acerto, nil
+}
+
+func (d *DivisaoDAL) DeleteAcerto(id uint, grupoID uint) error {
+	return d.db.Where("id = ? AND grupo_id = ?", id, grupoID).Delete(&types.AcertoDivisao{}).Error
+}
@@ -0,0 +1,86 @@
+package dal
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type EnvelopeDAL struct {
+	db *gorm.DB
+}
+
+func NewEnvelopeDAL(db *gorm.DB) *EnvelopeDAL {
+	return &EnvelopeDAL{db: db}
+}
+
+func (e *EnvelopeDAL) CreateEnvelope(envelope *types.Envelope) error {
+	return e.db.Create(envelope).Error
+}
+
+func (e *EnvelopeDAL) GetEnvelopesByUser(userID uint) ([]types.Envelope, error) {
+	var envelopes []types.Envelope
+	err := e.db.Where("user_id = ?", userID).Order("mes_vencimento, nome").Find(&envelopes).Error
+	return envelopes, err
+}
+
+func (e *EnvelopeDAL) GetEnvelopeByID(id uint, userID uint) (*types.Envelope, error) {
+	var envelope types.Envelope
+	err := e.db.Where("id = ? AND user_id = ?", id, userID).First(&envelope).Error
+	if err != nil {
+		return nil, err
+	}
+	return &envelope, nil
+}
+
+func (e *EnvelopeDAL) UpdateEnvelope(envelope *types.Envelope) error {
+	return e.db.Save(envelope).Error
+}
+
+// DeleteEnvelope exclui o envelope junto com seus movimentos.
+func (e *EnvelopeDAL) DeleteEnvelope(id uint, userID uint) error {
+	return e.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("envelope_id = ? AND user_id = ?", id, userID).Delete(&types.MovimentoEnvelope{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ? AND user_id = ?", id, userID).Delete(&types.Envelope{}).Error
+	})
+}
+
+func (e *EnvelopeDAL) CreateMovimento(movimento *types.MovimentoEnvelope) error {
+	return e.db.Create(movimento).Error
+}
+
+func (e *EnvelopeDAL) GetMovimentosByEnvelope(envelopeID uint, userID uint) ([]types.MovimentoEnvelope, error) {
+	var movimentos []types.MovimentoEnvelope
+	err := e.db.Where("envelope_id = ? AND user_id = ?", envelopeID, userID).
+		Order("mes_referencia DESC, created_at DESC").
+		Find(&movimentos).Error
+	return movimentos, err
+}
+
+func (e *EnvelopeDAL) GetMovimentosByUser(userID uint) ([]types.MovimentoEnvelope, error) {
+	var movimentos []types.MovimentoEnvelope
+	err := e.db.Where("user_id = ?", userID).Find(&movimentos).Error
+	return movimentos, err
+}
+
+func (e *EnvelopeDAL) GetMovimentoByID(id uint, envelopeID uint, userID uint) (*types.MovimentoEnvelope, error) {
+	var movimento types.MovimentoEnvelope
+	err := e.db.Where("id = ? AND envelope_id = ? AND user_id = ?", id, envelopeID, userID).First(&movimento).Error
+	if err != nil {
+		return nil, err
+	}
+	return &movimento, nil
+}
+
+func (e *EnvelopeDAL) ExistsGastoDaDespesa(despesaID uint, userID uint) (bool, error) {
+	var count int64
+	err := e.db.Model(&types.MovimentoEnvelope{}).
+		Where("despesa_id = ? AND user_id = ?", despesaID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (e *EnvelopeDAL) DeleteMovimento(id uint, envelopeID uint, userID uint) error {
+	return e.db.Where("id = ? AND envelope_id = ? AND user_id = ?", id, envelopeID, userID).Delete(&types.MovimentoEnvelope{}).Error
+}
@@ -0,0 +1,256 @@
+package dal
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+// escopoUsuario substitui o filtro `user_id = ?` nas consultas de despesas e
+// limites: um membro de família enxerga os registros da família; fora de uma
+// família, o usuário enxerga apenas os próprios registros pessoais. Os
+// registros pessoais de um membro ficam ocultos, mas intactos, até ele sair.
+func escopoUsuario(userID uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(
+			"(familia_id = (SELECT familia_id FROM membro_familias WHERE user_id = ?) OR "+
+				"(familia_id IS NULL AND user_id = ? AND NOT EXISTS (SELECT 1 FROM membro_familias WHERE user_id = ?)))",
+			userID, userID, userID,
+		)
+	}
+}
+
+// familiaDoUsuario retorna a família do usuário, ou nil se ele não participa
+// de nenhuma. Usado para atribuir novos registros à família.
+func familiaDoUsuario(db *gorm.DB, userID uint) (*uint, error) {
+	var membro types.MembroFamilia
+	err := db.Where("user_id = ?", userID).First(&membro).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &membro.FamiliaID, nil
+}
+
+// usuariosDoEscopo retorna os usuários que enxergam os registros do usuário:
+// os membros da família dele ou, fora de uma família, apenas ele.
+func usuariosDoEscopo(db *gorm.DB, userID uint) ([]uint, error) {
+	var usuarios []uint
+	err := db.Model(&types.MembroFamilia{}).
+		Where("familia_id = (SELECT familia_id FROM membro_familias WHERE user_id = ?)", userID).
+		Order("user_id").Pluck("user_id", &usuarios).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(usuarios) == 0 {
+		return []uint{userID}, nil
+	}
+	return usuarios, nil
+}
+
+type FamiliaDAL struct {
+	db *gorm.DB
+}
+
+func NewFamiliaDAL(db *gorm.DB) *FamiliaDAL {
+	return &FamiliaDAL{db: db}
+}
+
+// CreateFamilia cria a família com o usuário como primeiro membro.
+func (f *FamiliaDAL) CreateFamilia(familia *types.Familia, membro *types.MembroFamilia) error {
+	return f.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(familia).Error; err != nil {
+			return err
+		}
+		membro.FamiliaID = familia.ID
+		return tx.Create(membro).Error
+	})
+}
+
+func (f *FamiliaDAL) GetFamiliaByID(id uint) (*types.Familia, error) {
+	var familia types.Familia
+	err := f.db.First(&familia, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &familia, nil
+}
+
+func (f *FamiliaDAL) UpdateFamilia(familia *types.Familia) error {
+	return f.db.Save(familia).Error
+}
+
+func (f *FamiliaDAL) GetMembroByUser(userID uint) (*types.MembroFamilia, error) {
+	var membro types.MembroFamilia
+	err := f.db.Where("user_id = ?", userID).First(&membro).Error
+	if err != nil {
+		return nil, err
+	}
+	return &membro, nil
+}
+
+func (f *FamiliaDAL) GetMembros(familiaID uint) ([]types.MembroFamilia, error) {
+	var membros []types.MembroFamilia
+	err := f.db.Preload("User").Where("familia_id = ?", familiaID).Order("created_at").Find(&membros).Error
+	return membros, err
+}
+
+func (f *FamiliaDAL) UpdateMembro(membro *types.MembroFamilia) error {
+	return f.db.Omit("User").Save(membro).Error
+}
+
+// DeleteMembro remove o vínculo de vez, para que o usuário possa entrar em
+// outra família. As despesas, receitas, importações e limites que ele criou
+// na família voltam a ser pessoais dele na mesma transação; os fechamentos
+// ficam com a família.
+func (f *FamiliaDAL) DeleteMembro(familiaID uint, userID uint) error {
+	return f.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&types.Despesa{}).Where("familia_id = ? AND user_id = ?", familiaID, userID).Update("familia_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&types.Receita{}).Where("familia_id = ? AND user_id = ?", familiaID, userID).Update("familia_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&types.Importacao{}).Where("familia_id = ? AND user_id = ?", familiaID, userID).Update("familia_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&types.Limite{}).Where("familia_id = ? AND user_id = ?", familiaID, userID).Update("familia_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("familia_id = ? AND user_id = ?", familiaID, userID).Delete(&types.MembroFamilia{}).Error
+	})
+}
+
+// DeleteFamilia exclui uma família sem membros. Despesas, receitas,
+// importações, limites e fechamentos dela voltam a ser pessoais de quem os
+// criou.
+func (f *FamiliaDAL) DeleteFamilia(familiaID uint) error {
+	return f.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&types.Despesa{}).Where("familia_id = ?", familiaID).Update("familia_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&types.Receita{}).Where("familia_id = ?", familiaID).Update("familia_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&types.Importacao{}).Where("familia_id = ?", familiaID).Update("familia_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&types.Limite{}).Where("familia_id = ?", familiaID).Update("familia_id", nil).Error; err != nil {
+			return err
+		}
+		if err := reverterFechamentos(tx, familiaID); err != nil {
+			return err
+		}
+		if err := tx.Where("familia_id = ? AND status = ?", familiaID, "pendente").Delete(&types.ConviteFamilia{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&types.Familia{}, familiaID).Error
+	})
+}
+
+// reverterFechamentos devolve os fechamentos da família a quem os criou. Se
+// o criador já tinha fechado o mesmo mês antes de entrar na família, vale o
+// fechamento pessoal e o da família é excluído.
+func reverterFechamentos(tx *gorm.DB, familiaID uint) error {
+	var fechamentos []types.FechamentoMes
+	if err := tx.Where("familia_id = ?", familiaID).Find(&fechamentos).Error; err != nil {
+		return err
+	}
+
+	for _, fechamento := range fechamentos {
+		var pessoais int64
+		err := tx.Model(&types.FechamentoMes{}).
+			Where("familia_id IS NULL AND user_id = ? AND mes_referencia = ?", fechamento.UserID, fechamento.MesReferencia).
+			Count(&pessoais).Error
+		if err != nil {
+			return err
+		}
+
+		if pessoais > 0 {
+			err = tx.Delete(&types.FechamentoMes{}, fechamento.ID).Error
+		} else {
+			err = tx.Model(&types.FechamentoMes{}).Where("id = ?", fechamento.ID).Update("familia_id", nil).Error
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EntrarNaFamilia aceita o convite e cria o vínculo na mesma transação. Com
+// importarDespesas, as despesas, receitas e importações pessoais do usuário
+// passam para a família.
+// Limites pessoais nunca migram, porque os limites da família são definidos
+// pelos administradores; eles ficam ocultos enquanto o usuário for membro.
+func (f *FamiliaDAL) EntrarNaFamilia(convite *types.ConviteFamilia, membro *types.MembroFamilia, importarDespesas bool) error {
+	return f.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(convite).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(membro).Error; err != nil {
+			return err
+		}
+		if !importarDespesas {
+			return nil
+		}
+		if err := tx.Model(&types.Despesa{}).Where("user_id = ? AND familia_id IS NULL", membro.UserID).Update("familia_id", membro.FamiliaID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&types.Receita{}).Where("user_id = ? AND familia_id IS NULL", membro.UserID).Update("familia_id", membro.FamiliaID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&types.Importacao{}).
+			Where("user_id = ? AND familia_id IS NULL", membro.UserID).
+			Update("familia_id", membro.FamiliaID).Error
+	})
+}
+
+func (f *FamiliaDAL) CreateConvite(convite *types.ConviteFamilia) error {
+	return f.db.Create(convite).Error
+}
+
+func (f *FamiliaDAL) SaveConvite(convite *types.ConviteFamilia) error {
+	return f.db.Save(convite).Error
+}
+
+func (f *FamiliaDAL) GetConviteByID(id uint) (*types.ConviteFamilia, error) {
+	var convite types.ConviteFamilia
+	err := f.db.First(&convite, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &convite, nil
+}
+
+func (f *FamiliaDAL) GetConvitesPendentesByFamilia(familiaID uint, agora time.Time) ([]types.ConviteFamilia, error) {
+	var convites []types.ConviteFamilia
+	err := f.db.Where("familia_id = ? AND status = ? AND expira_em > ?", familiaID, "pendente", agora).
+		Order("created_at DESC").
+		Find(&convites).Error
+	return convites, err
+}
+
+func (f *FamiliaDAL) GetConvitesPendentesByEmail(email string, agora time.Time) ([]types.ConviteFamilia, error) {
+	var convites []types.ConviteFamilia
+	err := f.db.Where("LOWER(email) = LOWER(?) AND status = ? AND expira_em > ?", email, "pendente", agora).
+		Order("created_at DESC").
+		Find(&convites).Error
+	return convites, err
+}
+
+func (f *FamiliaDAL) ExistsConvitePendente(familiaID uint, email string, agora time.Time) (bool, error) {
+	var count int64
+	err := f.db.Model(&types.ConviteFamilia{}).
+		Where("familia_id = ? AND LOWER(email) = LOWER(?) AND status = ? AND expira_em > ?", familiaID, email, "pendente", agora).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (f *FamiliaDAL) DeleteConvite(id uint, familiaID uint) error {
+	return f.db.Where("id = ? AND familia_id = ?", id, familiaID).Delete(&types.ConviteFamilia{}).Error
+}
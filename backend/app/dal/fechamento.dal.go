@@ -0,0 +1,93 @@
+package dal
+
+import (
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FechamentoDAL struct {
+	db *gorm.DB
+}
+
+func NewFechamentoDAL(db *gorm.DB) *FechamentoDAL {
+	return &FechamentoDAL{db: db}
+}
+
+func (f *FechamentoDAL) GetFechamento(userID uint, mesReferencia time.Time) (*types.FechamentoMes, error) {
+	var fechamento types.FechamentoMes
+	err := f.db.Scopes(escopoUsuario(userID)).Where("mes_referencia = ?", mesReferencia).First(&fechamento).Error
+	if err != nil {
+		return nil, err
+	}
+	return &fechamento, nil
+}
+
+func (f *FechamentoDAL) GetFechamentosByUser(userID uint) ([]types.FechamentoMes, error) {
+	var fechamentos []types.FechamentoMes
+	err := f.db.Scopes(escopoUsuario(userID)).Order("mes_referencia DESC").Find(&fechamentos).Error
+	return fechamentos, err
+}
+
+// SaveFechamento grava o fechamento e a entrada de auditoria correspondente
+// na mesma transação. Um fechamento novo pertence à família do usuário e só
+// é criado se o mês ainda não tiver fechamento; um existente só é alterado
+// se ainda estiver com statusAnterior. Retorna false, sem gravar nada, se
+// outra requisição alterou o mês antes.
+func (f *FechamentoDAL) SaveFechamento(fechamento *types.FechamentoMes, statusAnterior string, auditoria *types.AuditoriaFechamento) (bool, error) {
+	salvo := false
+
+	err := f.db.Transaction(func(tx *gorm.DB) error {
+		var result *gorm.DB
+		if fechamento.ID == 0 {
+			if fechamento.FamiliaID == nil {
+				familiaID, err := familiaDoUsuario(tx, fechamento.UserID)
+				if err != nil {
+					return err
+				}
+				fechamento.FamiliaID = familiaID
+			}
+			result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(fechamento)
+		} else {
+			result = tx.Model(fechamento).Where("status = ?", statusAnterior).
+				Select("status", "fechado_em", "automatico", "total_despesas", "quantidade_despesas", "limite_geral", "categorias").
+				Updates(fechamento)
+		}
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		salvo = true
+
+		auditoria.FechamentoID = fechamento.ID
+		return tx.Create(auditoria).Error
+	})
+
+	return salvo, err
+}
+
+func (f *FechamentoDAL) GetAuditorias(fechamentoID uint) ([]types.AuditoriaFechamento, error) {
+	var auditorias []types.AuditoriaFechamento
+	err := f.db.Where("fechamento_id = ?", fechamentoID).Order("created_at DESC, id DESC").Find(&auditorias).Error
+	return auditorias, err
+}
+
+func (f *FechamentoDAL) GetConfiguracao(userID uint) (*types.ConfiguracaoFechamento, error) {
+	var configuracao types.ConfiguracaoFechamento
+	err := f.db.Where("user_id = ?", userID).First(&configuracao).Error
+	if err != nil {
+		return nil, err
+	}
+	return &configuracao, nil
+}
+
+func (f *FechamentoDAL) SaveConfiguracao(configuracao *types.ConfiguracaoFechamento) error {
+	return f.db.Save(configuracao).Error
+}
+
+func (f *FechamentoDAL) GetConfiguracoesAutomaticas() ([]types.ConfiguracaoFechamento, error) {
+	var configuracoes []types.ConfiguracaoFechamento
+	err := f.db.Where("automatico = ?", true).Find(&configuracoes).Error
+	return configuracoes, err
+}
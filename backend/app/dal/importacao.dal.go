@@ -0,0 +1,162 @@
+package dal
+
+import (
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type ImportacaoDAL struct {
+	db *gorm.DB
+}
+
+func NewImportacaoDAL(db *gorm.DB) *ImportacaoDAL {
+	return &ImportacaoDAL{db: db}
+}
+
+// CreateImportacao atribui a importação, as despesas e as receitas à família
+// de quem importou, se houver.
+func (i *ImportacaoDAL) CreateImportacao(importacao *types.Importacao, despesas []types.Despesa, receitas []types.Receita) error {
+	return i.db.Transaction(func(tx *gorm.DB) error {
+		familiaID, err := familiaDoUsuario(tx, importacao.UserID)
+		if err != nil {
+			return err
+		}
+		importacao.FamiliaID = familiaID
+		if err := tx.Create(importacao).Error; err != nil {
+			return err
+		}
+
+		if len(despesas) > 0 {
+			for idx := range despesas {
+				despesas[idx].ImportacaoID = &importacao.ID
+				despesas[idx].FamiliaID = familiaID
+			}
+			if err := tx.CreateInBatches(despesas, 200).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(receitas) > 0 {
+			for idx := range receitas {
+				receitas[idx].ImportacaoID = &importacao.ID
+				receitas[idx].FamiliaID = familiaID
+			}
+			if err := tx.CreateInBatches(receitas, 200).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetFITIDsExistentes procura os FITIDs já importados da conta: o FITID só é
+// único dentro da conta que o emitiu. Registros sem conta vêm de importações
+// anteriores ao registro da conta e valem para qualquer uma.
+func (i *ImportacaoDAL) GetFITIDsExistentes(userID uint, conta string, fitids []string) (map[string]bool, error) {
+	existentes := make(map[string]bool)
+	if len(fitids) == 0 {
+		return existentes, nil
+	}
+
+	var encontrados []string
+	err := i.db.Model(&types.Despesa{}).Scopes(escopoUsuario(userID)).
+		Where("(conta = ? OR COALESCE(conta, '') = '') AND fit_id IN ?", conta, fitids).
+		Pluck("fit_id", &encontrados).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, fitid := range encontrados {
+		existentes[fitid] = true
+	}
+
+	encontrados = nil
+	err = i.db.Model(&types.Receita{}).Scopes(escopoUsuario(userID)).
+		Where("(conta = ? OR COALESCE(conta, '') = '') AND fit_id IN ?", conta, fitids).
+		Pluck("fit_id", &encontrados).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, fitid := range encontrados {
+		existentes[fitid] = true
+	}
+
+	return existentes, nil
+}
+
+func (i *ImportacaoDAL) GetImportacoesByUser(userID uint) ([]types.Importacao, error) {
+	var importacoes []types.Importacao
+	err := i.db.Scopes(escopoUsuario(userID)).Order("created_at DESC").Find(&importacoes).Error
+	return importacoes, err
+}
+
+func (i *ImportacaoDAL) GetImportacaoByID(id uint, userID uint) (*types.Importacao, error) {
+	var importacao types.Importacao
+	err := i.db.Scopes(escopoUsuario(userID)).Where("id = ?", id).First(&importacao).Error
+	if err != nil {
+		return nil, err
+	}
+	return &importacao, nil
+}
+
+// GetMesesImportacao retorna os meses de referência das despesas e receitas
+// que continuam ligadas à importação.
+func (i *ImportacaoDAL) GetMesesImportacao(importacao *types.Importacao) ([]time.Time, error) {
+	var meses, mesesReceitas []time.Time
+	err := i.db.Model(&types.Despesa{}).Where("importacao_id = ? AND user_id = ?", importacao.ID, importacao.UserID).
+		Distinct().Pluck("mes_referencia", &meses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = i.db.Model(&types.Receita{}).Where("importacao_id = ? AND user_id = ?", importacao.ID, importacao.UserID).
+		Distinct().Pluck("mes_referencia", &mesesReceitas).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return append(meses, mesesReceitas...), nil
+}
+
+// PossuiDespesasVinculadas indica se alguma despesa da importação foi paga
+// com um envelope ou gerada pelo pagamento de uma dívida.
+func (i *ImportacaoDAL) PossuiDespesasVinculadas(importacao *types.Importacao) (bool, error) {
+	despesas := i.db.Model(&types.Despesa{}).Select("id").Where("importacao_id = ? AND user_id = ?", importacao.ID, importacao.UserID)
+
+	var count int64
+	err := i.db.Model(&types.MovimentoEnvelope{}).Where("despesa_id IN (?)", despesas).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	err = i.db.Model(&types.PagamentoDivida{}).Where("despesa_id IN (?)", despesas).Count(&count).Error
+	return count > 0, err
+}
+
+// DesfazerImportacao retorna as despesas excluídas, para que o serviço
+// publique os eventos depois da transação.
+func (i *ImportacaoDAL) DesfazerImportacao(importacao *types.Importacao, status string) ([]types.Despesa, error) {
+	var despesas []types.Despesa
+	err := i.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("importacao_id = ? AND user_id = ?", importacao.ID, importacao.UserID).Find(&despesas).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("importacao_id = ? AND user_id = ?", importacao.ID, importacao.UserID).Delete(&types.Despesa{}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("importacao_id = ? AND user_id = ?", importacao.ID, importacao.UserID).Delete(&types.Receita{}).Error
+		if err != nil {
+			return err
+		}
+
+		importacao.Status = status
+		return tx.Save(importacao).Error
+	})
+	return despesas, err
+}
@@ -1,6 +1,7 @@
 package dal
 
 import (
+	"errors"
 	"time"
 
 	"github.com/Vicente/Password-Mobile-App/backend/app/types"
@@ -15,7 +16,21 @@ func NewLimiteDAL(db *gorm.DB) *LimiteDAL {
 	return &LimiteDAL{db: db}
 }
 
+func (l *LimiteDAL) Transaction(fn func(txDAL *LimiteDAL) error) error {
+	return l.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&LimiteDAL{db: tx})
+	})
+}
+
+// CreateLimite atribui o limite à família de quem o criou, se houver.
 func (l *LimiteDAL) CreateLimite(limite *types.Limite) error {
+	if limite.FamiliaID == nil {
+		familiaID, err := familiaDoUsuario(l.db, limite.UserID)
+		if err != nil {
+			return err
+		}
+		limite.FamiliaID = familiaID
+	}
 	return l.db.Create(limite).Error
 }
 
@@ -24,22 +39,31 @@ func (l *LimiteDAL) GetLimiteByUserAndMonth(userID uint, mesReferencia time.Time
 	
 	firstDay := time.Date(mesReferencia.Year(), mesReferencia.Month(), 1, 0, 0, 0, 0, time.UTC)
 	
-	err := l.db.Where("user_id = ? AND mes_referencia = ?", userID, firstDay).First(&limite).Error
+	err := l.db.Scopes(escopoUsuario(userID)).Where("mes_referencia = ? AND COALESCE(categoria, '') = ''", firstDay).First(&limite).Error
 	if err != nil {
 		return nil, err
 	}
 	return &limite, nil
 }
 
+func (l *LimiteDAL) GetLimitesByUserAndMonth(userID uint, mesReferencia time.Time) ([]types.Limite, error) {
+	var limites []types.Limite
+
+	firstDay := time.Date(mesReferencia.Year(), mesReferencia.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	err := l.db.Scopes(escopoUsuario(userID)).Where("mes_referencia = ?", firstDay).Order("categoria").Find(&limites).Error
+	return limites, err
+}
+
 func (l *LimiteDAL) GetLimitesByUser(userID uint) ([]types.Limite, error) {
 	var limites []types.Limite
-	err := l.db.Where("user_id = ?", userID).Order("mes_referencia DESC").Find(&limites).Error
+	err := l.db.Scopes(escopoUsuario(userID)).Order("mes_referencia DESC").Find(&limites).Error
 	return limites, err
 }
 
 func (l *LimiteDAL) GetLimiteByID(id uint, userID uint) (*types.Limite, error) {
 	var limite types.Limite
-	err := l.db.Where("id = ? AND user_id = ?", id, userID).First(&limite).Error
+	err := l.db.Scopes(escopoUsuario(userID)).Where("id = ?", id).First(&limite).Error
 	if err != nil {
 		return nil, err
 	}
@@ -51,14 +75,48 @@ func (l *LimiteDAL) UpdateLimite(limite *types.Limite) error {
 }
 
 func (l *LimiteDAL) DeleteLimite(id uint, userID uint) error {
-	return l.db.Where("id = ? AND user_id = ?", id, userID).Delete(&types.Limite{}).Error
+	return l.db.Scopes(escopoUsuario(userID)).Where("id = ?", id).Delete(&types.Limite{}).Error
 }
 
-func (l *LimiteDAL) ExistsLimiteForMonth(userID uint, mesReferencia time.Time) (bool, error) {
+func (l *LimiteDAL) ExistsLimiteForMonth(userID uint, mesReferencia time.Time, categoria string) (bool, error) {
 	var count int64
 	
 	firstDay := time.Date(mesReferencia.Year(), mesReferencia.Month(), 1, 0, 0, 0, 0, time.UTC)
 	
-	err := l.db.Model(&types.Limite{}).Where("user_id = ? AND mes_referencia = ?", userID, firstDay).Count(&count).Error
+	err := l.db.Model(&types.Limite{}).
+		Scopes(escopoUsuario(userID)).
+		Where("mes_referencia = ? AND LOWER(COALESCE(categoria, '')) = LOWER(?)", firstDay, categoria).
+		Count(&count).Error
 	return count > 0, err
-} 
\ No newline at end of file
+}
+
+func (l *LimiteDAL) GetUltimoMesComLimite(userID uint, antesDe time.Time) (*time.Time, error) {
+	var limite types.Limite
+	err := l.db.Scopes(escopoUsuario(userID)).Where("mes_referencia < ?", antesDe).Order("mes_referencia DESC").First(&limite).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &limite.MesReferencia, nil
+}
+
+func (l *LimiteDAL) GetConfiguracao(userID uint) (*types.ConfiguracaoLimite, error) {
+	var configuracao types.ConfiguracaoLimite
+	err := l.db.Where("user_id = ?", userID).First(&configuracao).Error
+	if err != nil {
+		return nil, err
+	}
+	return &configuracao, nil
+}
+
+func (l *LimiteDAL) SaveConfiguracao(configuracao *types.ConfiguracaoLimite) error {
+	return l.db.Save(configuracao).Error
+}
+
+func (l *LimiteDAL) GetConfiguracoesAtivas(modoDesativado string) ([]types.ConfiguracaoLimite, error) {
+	var configuracoes []types.ConfiguracaoLimite
+	err := l.db.Where("modo <> ?", modoDesativado).Find(&configuracoes).Error
+	return configuracoes, err
+}
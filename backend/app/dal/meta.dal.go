@@ -0,0 +1,119 @@
+package dal
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MetaDAL struct {
+	db *gorm.DB
+}
+
+func NewMetaDAL(db *gorm.DB) *MetaDAL {
+	return &MetaDAL{db: db}
+}
+
+func (m *MetaDAL) CreateMeta(meta *types.Meta) error {
+	return m.db.Create(meta).Error
+}
+
+func (m *MetaDAL) GetMetasByUser(userID uint) ([]types.Meta, error) {
+	var metas []types.Meta
+	err := m.db.Where("user_id = ?", userID).Order("data_alvo, nome").Find(&metas).Error
+	return metas, err
+}
+
+func (m *MetaDAL) GetMetaByID(id uint, userID uint) (*types.Meta, error) {
+	var meta types.Meta
+	err := m.db.Where("id = ? AND user_id = ?", id, userID).First(&meta).Error
+	if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func (m *MetaDAL) UpdateMeta(meta *types.Meta) error {
+	return m.db.Save(meta).Error
+}
+
+// DeleteMeta exclui a meta junto com seus aportes.
+func (m *MetaDAL) DeleteMeta(id uint, userID uint) error {
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("meta_id = ? AND user_id = ?", id, userID).Delete(&types.AporteMeta{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ? AND user_id = ?", id, userID).Delete(&types.Meta{}).Error
+	})
+}
+
+// GetMetasComContribuicaoAutomatica retorna as metas ainda não concluídas de
+// todos os usuários que têm contribuição automática.
+func (m *MetaDAL) GetMetasComContribuicaoAutomatica() ([]types.Meta, error) {
+	var metas []types.Meta
+	err := m.db.Where("contribuicao_automatica > 0 AND concluida_em IS NULL").Find(&metas).Error
+	return metas, err
+}
+
+func (m *MetaDAL) CreateAporte(aporte *types.AporteMeta) error {
+	return m.db.Create(aporte).Error
+}
+
+// CreateAporteUnico grava o aporte apenas se a meta ainda não tiver outro com
+// a mesma chave, mesmo que já tenha sido excluído. Retorna se foi criado.
+func (m *MetaDAL) CreateAporteUnico(aporte *types.AporteMeta) (bool, error) {
+	result := m.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "meta_id"}, {Name: "chave"}},
+		DoNothing: true,
+	}).Create(aporte)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (m *MetaDAL) GetAportesByMeta(metaID uint, userID uint) ([]types.AporteMeta, error) {
+	var aportes []types.AporteMeta
+	err := m.db.Where("meta_id = ? AND user_id = ?", metaID, userID).Order("data DESC, id DESC").Find(&aportes).Error
+	return aportes, err
+}
+
+func (m *MetaDAL) GetTotaisAportados(userID uint) (map[uint]float64, error) {
+	var linhas []struct {
+		MetaID uint
+		Total  float64
+	}
+	err := m.db.Model(&types.AporteMeta{}).
+		Select("meta_id, COALESCE(SUM(valor), 0) AS total").
+		Where("user_id = ?", userID).
+		Group("meta_id").
+		Scan(&linhas).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totais := make(map[uint]float64, len(linhas))
+	for _, linha := range linhas {
+		totais[linha.MetaID] = linha.Total
+	}
+	return totais, nil
+}
+
+func (m *MetaDAL) GetTotalAportado(metaID uint, userID uint) (float64, error) {
+	var total float64
+	err := m.db.Model(&types.AporteMeta{}).
+		Select("COALESCE(SUM(valor), 0)").
+		Where("meta_id = ? AND user_id = ?", metaID, userID).
+		Scan(&total).Error
+	return total, err
+}
+
+func (m *MetaDAL) GetAporteByID(id uint, metaID uint, userID uint) (*types.AporteMeta, error) {
+	var aporte types.AporteMeta
+	err := m.db.Where("id = ? AND meta_id = ? AND user_id = ?", id, metaID, userID).First(&aporte).Error
+	if err != nil {
+		return nil, err
+	}
+	return &aporte, nil
+}
+
+func (m *MetaDAL) DeleteAporte(id uint, metaID uint, userID uint) error {
+	return m.db.Where("id = ? AND meta_id = ? AND user_id = ?", id, metaID, userID).Delete(&types.AporteMeta{}).Error
+}
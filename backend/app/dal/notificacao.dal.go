@@ -0,0 +1,90 @@
+package dal
+
+import (
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificacaoDAL struct {
+	db *gorm.DB
+}
+
+func NewNotificacaoDAL(db *gorm.DB) *NotificacaoDAL {
+	return &NotificacaoDAL{db: db}
+}
+
+// CreateNotificacaoUnica grava a notificação apenas se ainda não existir outra
+// com a mesma chave para o usuário, mesmo que já tenha sido excluída. Quando
+// informada, a mensagem de saída (outbox) é gravada na mesma transação.
+// Retorna se a notificação foi criada.
+func (n *NotificacaoDAL) CreateNotificacaoUnica(notificacao *types.Notificacao, mensagem *types.MensagemOutbox) (bool, error) {
+	criada := false
+
+	err := n.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "chave"}},
+			DoNothing: true,
+		}).Create(notificacao)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		criada = true
+
+		if mensagem == nil {
+			return nil
+		}
+		mensagem.NotificacaoID = &notificacao.ID
+		return tx.Create(mensagem).Error
+	})
+
+	return criada, err
+}
+
+// GetDestinatarios retorna quem deve ser avisado sobre os registros do
+// usuário: todos os membros da família, ou só ele fora de uma família.
+func (n *NotificacaoDAL) GetDestinatarios(userID uint) ([]uint, error) {
+	return usuariosDoEscopo(n.db, userID)
+}
+
+func (n *NotificacaoDAL) GetNotificacoesByUser(userID uint, apenasNaoLidas bool) ([]types.Notificacao, error) {
+	var notificacoes []types.Notificacao
+	query := n.db.Where("user_id = ?", userID)
+	if apenasNaoLidas {
+		query = query.Where("lida = ?", false)
+	}
+	err := query.Order("created_at DESC, id DESC").Find(&notificacoes).Error
+	return notificacoes, err
+}
+
+func (n *NotificacaoDAL) CountNaoLidas(userID uint) (int64, error) {
+	var total int64
+	err := n.db.Model(&types.Notificacao{}).Where("user_id = ? AND lida = ?", userID, false).Count(&total).Error
+	return total, err
+}
+
+func (n *NotificacaoDAL) GetNotificacaoByID(id uint, userID uint) (*types.Notificacao, error) {
+	var notificacao types.Notificacao
+	err := n.db.Where("id = ? AND user_id = ?", id, userID).First(&notificacao).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notificacao, nil
+}
+
+func (n *NotificacaoDAL) UpdateNotificacao(notificacao *types.Notificacao) error {
+	return n.db.Save(notificacao).Error
+}
+
+func (n *NotificacaoDAL) MarcarTodasComoLidas(userID uint, lidaEm time.Time) (int64, error) {
+	result := n.db.Model(&types.Notificacao{}).
+		Where("user_id = ? AND lida = ?", userID, false).
+		Updates(map[string]interface{}{"lida": true, "lida_em": lidaEm})
+	return result.RowsAffected, result.Error
+}
+
+func (n *NotificacaoDAL) DeleteNotificacao(id uint, userID uint) error {
+	return n.db.Where("id = ? AND user_id = ?", id, userID).Delete(&types.Notificacao{}).Error
+}
@@ -0,0 +1,69 @@
+package dal
+
+import (
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxDAL struct {
+	db *gorm.DB
+}
+
+func NewOutboxDAL(db *gorm.DB) *OutboxDAL {
+	return &OutboxDAL{db: db}
+}
+
+// ReservarPendentes bloqueia até `quantidade` mensagens prontas para envio e
+// adia a próxima tentativa delas por `reserva`. Assim, mais de uma instância
+// pode rodar o despachante sem entregar a mesma mensagem duas vezes, e uma
+// mensagem reservada por uma instância que caiu volta a ficar disponível.
+func (o *OutboxDAL) ReservarPendentes(agora time.Time, quantidade int, reserva time.Duration, status string) ([]types.MensagemOutbox, error) {
+	var mensagens []types.MensagemOutbox
+
+	err := o.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND proxima_tentativa <= ?", status, agora).
+			Order("proxima_tentativa, id").
+			Limit(quantidade).
+			Find(&mensagens).Error
+		if err != nil || len(mensagens) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(mensagens))
+		for i := range mensagens {
+			ids[i] = mensagens[i].ID
+		}
+
+		return tx.Model(&types.MensagemOutbox{}).Where("id IN ?", ids).Update("proxima_tentativa", agora.Add(reserva)).Error
+	})
+
+	return mensagens, err
+}
+
+func (o *OutboxDAL) UpdateMensagem(mensagem *types.MensagemOutbox) error {
+	return o.db.Save(mensagem).Error
+}
+
+func (o *OutboxDAL) GetDispositivosAtivos(userID uint) ([]types.DispositivoPush, error) {
+	var dispositivos []types.DispositivoPush
+	err := o.db.Where("user_id = ? AND ativo = ?", userID, true).Find(&dispositivos).Error
+	return dispositivos, err
+}
+
+func (o *OutboxDAL) DesativarDispositivos(tokens []string) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return o.db.Model(&types.DispositivoPush{}).Where("token IN ?", tokens).Update("ativo", false).Error
+}
+
+func (o *OutboxDAL) RegistrarUso(tokens []string, usoEm time.Time) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return o.db.Model(&types.DispositivoPush{}).Where("token IN ?", tokens).Update("ultimo_uso", usoEm).Error
+}
@@ -0,0 +1,27 @@
+package dal
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type PeriodoDAL struct {
+	db *gorm.DB
+}
+
+func NewPeriodoDAL(db *gorm.DB) *PeriodoDAL {
+	return &PeriodoDAL{db: db}
+}
+
+func (p *PeriodoDAL) GetConfiguracao(userID uint) (*types.ConfiguracaoPeriodo, error) {
+	var configuracao types.ConfiguracaoPeriodo
+	err := p.db.Where("user_id = ?", userID).First(&configuracao).Error
+	if err != nil {
+		return nil, err
+	}
+	return &configuracao, nil
+}
+
+func (p *PeriodoDAL) SaveConfiguracao(configuracao *types.ConfiguracaoPeriodo) error {
+	return p.db.Save(configuracao).Error
+}
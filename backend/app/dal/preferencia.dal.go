@@ -0,0 +1,27 @@
+package dal
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type PreferenciaDAL struct {
+	db *gorm.DB
+}
+
+func NewPreferenciaDAL(db *gorm.DB) *PreferenciaDAL {
+	return &PreferenciaDAL{db: db}
+}
+
+func (p *PreferenciaDAL) GetPreferencia(userID uint) (*types.Preferencia, error) {
+	var preferencia types.Preferencia
+	err := p.db.Where("user_id = ?", userID).First(&preferencia).Error
+	if err != nil {
+		return nil, err
+	}
+	return &preferencia, nil
+}
+
+func (p *PreferenciaDAL) SavePreferencia(preferencia *types.Preferencia) error {
+	return p.db.Save(preferencia).Error
+}
@@ -0,0 +1,32 @@
+package dal
+
+import (
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type ReceitaDAL struct {
+	db *gorm.DB
+}
+
+func NewReceitaDAL(db *gorm.DB) *ReceitaDAL {
+	return &ReceitaDAL{db: db}
+}
+
+func (r *ReceitaDAL) GetReceitasByUserAndMonth(userID uint, mesReferencia time.Time) ([]types.Receita, error) {
+	var receitas []types.Receita
+
+	firstDay := time.Date(mesReferencia.Year(), mesReferencia.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstDay.AddDate(0, 1, -1)
+
+	err := r.db.Scopes(escopoUsuario(userID)).Where("mes_referencia >= ? AND mes_referencia <= ?", firstDay, lastDay).Find(&receitas).Error
+	return receitas, err
+}
+
+func (r *ReceitaDAL) GetReceitasByUser(userID uint) ([]types.Receita, error) {
+	var receitas []types.Receita
+	err := r.db.Scopes(escopoUsuario(userID)).Order("mes_referencia DESC").Find(&receitas).Error
+	return receitas, err
+}
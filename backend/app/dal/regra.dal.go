@@ -0,0 +1,68 @@
+package dal
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type RegraDAL struct {
+	db *gorm.DB
+}
+
+func NewRegraDAL(db *gorm.DB) *RegraDAL {
+	return &RegraDAL{db: db}
+}
+
+func (r *RegraDAL) CreateRegra(regra *types.RegraCategorizacao) error {
+	return r.db.Create(regra).Error
+}
+
+func (r *RegraDAL) GetRegrasByUser(userID uint) ([]types.RegraCategorizacao, error) {
+	var regras []types.RegraCategorizacao
+	err := r.db.Where("user_id = ?", userID).Order("ordem, id").Find(&regras).Error
+	return regras, err
+}
+
+func (r *RegraDAL) GetRegrasAtivasByUser(userID uint) ([]types.RegraCategorizacao, error) {
+	var regras []types.RegraCategorizacao
+	err := r.db.Where("user_id = ? AND ativa = ?", userID, true).Order("ordem, id").Find(&regras).Error
+	return regras, err
+}
+
+func (r *RegraDAL) GetRegraByID(id uint, userID uint) (*types.RegraCategorizacao, error) {
+	var regra types.RegraCategorizacao
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&regra).Error
+	if err != nil {
+		return nil, err
+	}
+	return &regra, nil
+}
+
+func (r *RegraDAL) GetProximaOrdem(userID uint) (int, error) {
+	var ordem *int
+	err := r.db.Model(&types.RegraCategorizacao{}).Where("user_id = ?", userID).Select("MAX(ordem)").Scan(&ordem).Error
+	if err != nil || ordem == nil {
+		return 1, err
+	}
+	return *ordem + 1, nil
+}
+
+func (r *RegraDAL) UpdateRegra(regra *types.RegraCategorizacao) error {
+	return r.db.Save(regra).Error
+}
+
+func (r *RegraDAL) UpdateOrdem(userID uint, ids []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			err := tx.Model(&types.RegraCategorizacao{}).Where("id = ? AND user_id = ?", id, userID).Update("ordem", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *RegraDAL) DeleteRegra(id uint, userID uint) error {
+	return r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&types.RegraCategorizacao{}).Error
+}
@@ -0,0 +1,85 @@
+package dal
+
+import (
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+// RelatorioDAL concentra as consultas agregadas usadas nos relatórios. Todas
+// recebem o primeiro dia do mês inicial e final do período (inclusive).
+type RelatorioDAL struct {
+	db *gorm.DB
+}
+
+func NewRelatorioDAL(db *gorm.DB) *RelatorioDAL {
+	return &RelatorioDAL{db: db}
+}
+
+func ultimoDiaDoMes(mesReferencia time.Time) time.Time {
+	return time.Date(mesReferencia.Year(), mesReferencia.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, -1)
+}
+
+func (r *RelatorioDAL) GetTotaisPorMes(userID uint, inicio time.Time, fim time.Time) ([]types.TotalMes, error) {
+	var totais []types.TotalMes
+
+	err := r.db.Model(&types.Despesa{}).
+		Select("DATE_TRUNC('month', mes_referencia)::date AS mes_referencia, COALESCE(SUM(valor), 0) AS total, COUNT(*) AS quantidade").
+		Scopes(escopoUsuario(userID)).
+		Where("mes_referencia >= ? AND mes_referencia <= ?", inicio, ultimoDiaDoMes(fim)).
+		Group("DATE_TRUNC('month', mes_referencia)").
+		Order("mes_referencia").
+		Scan(&totais).Error
+	return totais, err
+}
+
+func (r *RelatorioDAL) GetLimitesGeraisBetweenMonths(userID uint, inicio time.Time, fim time.Time) ([]types.Limite, error) {
+	var limites []types.Limite
+
+	err := r.db.Scopes(escopoUsuario(userID)).Where("mes_referencia >= ? AND mes_referencia <= ? AND COALESCE(categoria, '') = ''", inicio, ultimoDiaDoMes(fim)).
+		Order("mes_referencia").
+		Find(&limites).Error
+	return limites, err
+}
+
+// GetTopCategorias agrupa as categorias sem diferenciar maiúsculas de minúsculas.
+func (r *RelatorioDAL) GetTopCategorias(userID uint, inicio time.Time, fim time.Time, quantidade int) ([]types.TotalCategoria, error) {
+	var totais []types.TotalCategoria
+
+	err := r.db.Model(&types.Despesa{}).
+		Select("MIN(COALESCE(categoria, '')) AS categoria, COALESCE(SUM(valor), 0) AS total, COUNT(*) AS quantidade").
+		Scopes(escopoUsuario(userID)).
+		Where("mes_referencia >= ? AND mes_referencia <= ?", inicio, ultimoDiaDoMes(fim)).
+		Group("LOWER(COALESCE(categoria, ''))").
+		Order("total DESC").
+		Limit(quantidade).
+		Scan(&totais).Error
+	return totais, err
+}
+
+// GetTotaisPorDescricao agrupa as despesas de cada mês pela descrição, sem
+// diferenciar maiúsculas de minúsculas.
+func (r *RelatorioDAL) GetTotaisPorDescricao(userID uint, inicio time.Time, fim time.Time) ([]types.TotalDescricaoMes, error) {
+	var totais []types.TotalDescricaoMes
+
+	err := r.db.Model(&types.Despesa{}).
+		Select("MIN(TRIM(descricao)) AS descricao, DATE_TRUNC('month', mes_referencia)::date AS mes_referencia, COALESCE(SUM(valor), 0) AS total, COUNT(*) AS quantidade").
+		Scopes(escopoUsuario(userID)).
+		Where("mes_referencia >= ? AND mes_referencia <= ?", inicio, ultimoDiaDoMes(fim)).
+		Group("LOWER(TRIM(descricao)), DATE_TRUNC('month', mes_referencia)").
+		Scan(&totais).Error
+	return totais, err
+}
+
+func (r *RelatorioDAL) GetTotaisPorCategoriaMes(userID uint, inicio time.Time, fim time.Time) ([]types.TotalCategoriaMes, error) {
+	var totais []types.TotalCategoriaMes
+
+	err := r.db.Model(&types.Despesa{}).
+		Select("MIN(COALESCE(categoria, '')) AS categoria, DATE_TRUNC('month', mes_referencia)::date AS mes_referencia, COALESCE(SUM(valor), 0) AS total").
+		Scopes(escopoUsuario(userID)).
+		Where("mes_referencia >= ? AND mes_referencia <= ?", inicio, ultimoDiaDoMes(fim)).
+		Group("LOWER(COALESCE(categoria, '')), DATE_TRUNC('month', mes_referencia)").
+		Scan(&totais).Error
+	return totais, err
+}
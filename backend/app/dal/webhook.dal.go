@@ -0,0 +1,110 @@
+package dal
+
+import (
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookDAL struct {
+	db *gorm.DB
+}
+
+func NewWebhookDAL(db *gorm.DB) *WebhookDAL {
+	return &WebhookDAL{db: db}
+}
+
+func (w *WebhookDAL) CreateWebhook(webhook *types.Webhook) error {
+	return w.db.Create(webhook).Error
+}
+
+func (w *WebhookDAL) GetWebhooksByUser(userID uint) ([]types.Webhook, error) {
+	var webhooks []types.Webhook
+	err := w.db.Where("user_id = ?", userID).Order("id").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (w *WebhookDAL) GetWebhooksAtivosByUser(userID uint) ([]types.Webhook, error) {
+	var webhooks []types.Webhook
+	err := w.db.Where("user_id = ? AND ativo = ?", userID, true).Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (w *WebhookDAL) GetWebhookByID(id uint, userID uint) (*types.Webhook, error) {
+	var webhook types.Webhook
+	err := w.db.Where("id = ? AND user_id = ?", id, userID).First(&webhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (w *WebhookDAL) UpdateWebhook(webhook *types.Webhook) error {
+	return w.db.Save(webhook).Error
+}
+
+func (w *WebhookDAL) DeleteWebhook(id uint, userID uint) error {
+	return w.db.Where("id = ? AND user_id = ?", id, userID).Delete(&types.Webhook{}).Error
+}
+
+// CreateEntregas ignora entregas de eventos que o webhook já recebeu.
+func (w *WebhookDAL) CreateEntregas(entregas []types.EntregaWebhook) error {
+	if len(entregas) == 0 {
+		return nil
+	}
+	return w.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "webhook_id"}, {Name: "evento_id"}},
+		DoNothing: true,
+	}).Create(&entregas).Error
+}
+
+func (w *WebhookDAL) GetEntregasByWebhook(webhookID uint, userID uint, quantidade int) ([]types.EntregaWebhook, error) {
+	var entregas []types.EntregaWebhook
+	err := w.db.Where("webhook_id = ? AND user_id = ?", webhookID, userID).
+		Order("created_at DESC, id DESC").
+		Limit(quantidade).
+		Find(&entregas).Error
+	return entregas, err
+}
+
+// ReservarPendentes segue a mesma estratégia de OutboxDAL.ReservarPendentes.
+func (w *WebhookDAL) ReservarPendentes(agora time.Time, quantidade int, reserva time.Duration, status string) ([]types.EntregaWebhook, error) {
+	var entregas []types.EntregaWebhook
+
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND proxima_tentativa <= ?", status, agora).
+			Order("proxima_tentativa, id").
+			Limit(quantidade).
+			Find(&entregas).Error
+		if err != nil || len(entregas) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(entregas))
+		for i := range entregas {
+			ids[i] = entregas[i].ID
+		}
+
+		return tx.Model(&types.EntregaWebhook{}).Where("id IN ?", ids).Update("proxima_tentativa", agora.Add(reserva)).Error
+	})
+
+	return entregas, err
+}
+
+// GetWebhookParaEntrega busca o webhook mesmo que tenha sido excluído, para
+// que a entrega pendente possa ser descartada.
+func (w *WebhookDAL) GetWebhookParaEntrega(id uint) (*types.Webhook, error) {
+	var webhook types.Webhook
+	err := w.db.Unscoped().Where("id = ?", id).First(&webhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (w *WebhookDAL) UpdateEntrega(entrega *types.EntregaWebhook) error {
+	return w.db.Save(entrega).Error
+}
@@ -0,0 +1,228 @@
+package i18n
+
+// Códigos estáveis dos erros da API. O cliente deve decidir pelo código,
+// nunca pelo texto da mensagem, que muda conforme o idioma.
+const (
+	DadosInvalidos                 Codigo = "dados_invalidos"
+	DadosInvalidosDetalhe          Codigo = "dados_invalidos_detalhe"
+	IDInvalido                     Codigo = "id_invalido"
+	ErroInterno                    Codigo = "erro_interno"
+	TokenAcessoObrigatorio         Codigo = "token_acesso_obrigatorio"
+	TokenAcessoFormatoInvalido     Codigo = "token_acesso_formato_invalido"
+	TokenAcessoInvalido            Codigo = "token_acesso_invalido"
+	TokenAcessoExpirado            Codigo = "token_acesso_expirado"
+	TokenAcessoClaimsInvalidos     Codigo = "token_acesso_claims_invalidos"
+	TokenAcessoSemUsuario          Codigo = "token_acesso_sem_usuario"
+	TokenAcessoSemEmail            Codigo = "token_acesso_sem_email"
+	NomeObrigatorio                Codigo = "nome_obrigatorio"
+	DescricaoObrigatoria           Codigo = "descricao_obrigatoria"
+	EmailObrigatorio               Codigo = "email_obrigatorio"
+	EmailFormatoInvalido           Codigo = "email_formato_invalido"
+	EmailDominioInvalido           Codigo = "email_dominio_invalido"
+	EmailJaCadastrado              Codigo = "email_ja_cadastrado"
+	EmailOuSenhaInvalidos          Codigo = "email_ou_senha_invalidos"
+	EmailInvalido                  Codigo = "email_invalido"
+	EmailInvalidoDetalhe           Codigo = "email_invalido_detalhe"
+	EmailNaoCadastrado             Codigo = "email_nao_cadastrado"
+	SenhaCurta                     Codigo = "senha_curta"
+	SenhasDiferentes               Codigo = "senhas_diferentes"
+	SenhaErroProcessamento         Codigo = "senha_erro_processamento"
+	TokenErroGeracao               Codigo = "token_erro_geracao"
+	DataNascimentoFutura           Codigo = "data_nascimento_futura"
+	UsuarioNaoEncontrado           Codigo = "usuario_nao_encontrado"
+	ValorNaoPositivo               Codigo = "valor_nao_positivo"
+	ValorMensalInvalido            Codigo = "valor_mensal_invalido"
+	QuantidadeInvalida             Codigo = "quantidade_invalida"
+	DataInvalida                   Codigo = "data_invalida"
+	DataInvalidaDetalhe            Codigo = "data_invalida_detalhe"
+	ValorInvalidoDetalhe           Codigo = "valor_invalido_detalhe"
+	AnoInvalido                    Codigo = "ano_invalido"
+	MesInvalido                    Codigo = "mes_invalido"
+	MesForaDoIntervalo             Codigo = "mes_fora_do_intervalo"
+	MesFormatoInvalido             Codigo = "mes_formato_invalido"
+	MesReferenciaObrigatorio       Codigo = "mes_referencia_obrigatorio"
+	MesOuDataObrigatorio           Codigo = "mes_ou_data_obrigatorio"
+	PeriodoMesesObrigatorio        Codigo = "periodo_meses_obrigatorio"
+	PeriodoMesesInvertido          Codigo = "periodo_meses_invertido"
+	PeriodoRelatorioLongo          Codigo = "periodo_relatorio_longo"
+	FusoHorarioInvalido            Codigo = "fuso_horario_invalido"
+	IdiomaInvalido                 Codigo = "idioma_invalido"
+	MoedaInvalida                  Codigo = "moeda_invalida"
+	DiaInicioMesInvalido           Codigo = "dia_inicio_mes_invalido"
+	DespesaNaoEncontrada           Codigo = "despesa_nao_encontrada"
+	DespesaVinculadaDivida         Codigo = "despesa_vinculada_divida"
+	DespesaVinculadaEnvelope       Codigo = "despesa_vinculada_envelope"
+	DespesaDuplicada               Codigo = "despesa_duplicada"
+	DespesaPagaComEnvelope         Codigo = "despesa_paga_com_envelope"
+	DespesaMantidaObrigatoria      Codigo = "despesa_mantida_obrigatoria"
+	DespesasRemoverObrigatorias    Codigo = "despesas_remover_obrigatorias"
+	DespesaMantidaRemovida         Codigo = "despesa_mantida_removida"
+	DespesasMesclarExcesso         Codigo = "despesas_mesclar_excesso"
+	DespesaNaoDuplicata            Codigo = "despesa_nao_duplicata"
+	LoteVazio                      Codigo = "lote_vazio"
+	LoteExcesso                    Codigo = "lote_excesso"
+	LoteOperacaoInvalida           Codigo = "lote_operacao_invalida"
+	LoteModoInvalido               Codigo = "lote_modo_invalido"
+	LoteRevertido                  Codigo = "lote_revertido"
+	LoteIDAtualizarObrigatorio     Codigo = "lote_id_atualizar_obrigatorio"
+	LoteIDExcluirObrigatorio       Codigo = "lote_id_excluir_obrigatorio"
+	LimiteNaoEncontrado            Codigo = "limite_nao_encontrado"
+	LimiteNaoEncontradoMes         Codigo = "limite_nao_encontrado_mes"
+	LimiteMesDuplicado             Codigo = "limite_mes_duplicado"
+	LimiteCategoriaDuplicado       Codigo = "limite_categoria_duplicado"
+	LimiteSemPermissao             Codigo = "limite_sem_permissao"
+	LimiteModoInvalido             Codigo = "limite_modo_invalido"
+	ValorPadraoNaoPositivo         Codigo = "valor_padrao_nao_positivo"
+	ValorPadraoNegativo            Codigo = "valor_padrao_negativo"
+	TetoRolloverNegativo           Codigo = "teto_rollover_negativo"
+	SugestaoMesesInvalidos         Codigo = "sugestao_meses_invalidos"
+	SugestaoSemDespesas            Codigo = "sugestao_sem_despesas"
+	MesFechadoCriarDespesa         Codigo = "mes_fechado_criar_despesa"
+	MesFechadoEditarDespesa        Codigo = "mes_fechado_editar_despesa"
+	MesFechadoExcluirDespesa       Codigo = "mes_fechado_excluir_despesa"
+	MesFechadoCriarLimite          Codigo = "mes_fechado_criar_limite"
+	MesFechadoEditarLimite         Codigo = "mes_fechado_editar_limite"
+	MesFechadoExcluirLimite        Codigo = "mes_fechado_excluir_limite"
+	MesFechadoAplicarSugestoes     Codigo = "mes_fechado_aplicar_sugestoes"
+	MesNaoTerminou                 Codigo = "mes_nao_terminou"
+	MesJaFechado                   Codigo = "mes_ja_fechado"
+	MesNaoFechado                  Codigo = "mes_nao_fechado"
+	MotivoReaberturaObrigatorio    Codigo = "motivo_reabertura_obrigatorio"
+	FechamentoSemPermissao         Codigo = "fechamento_sem_permissao"
+	DiasCarenciaInvalidos          Codigo = "dias_carencia_invalidos"
+	PeriodoTipoInvalido            Codigo = "periodo_tipo_invalido"
+	PeriodoDiaInicioInvalido       Codigo = "periodo_dia_inicio_invalido"
+	PeriodoDiaSemanaInvalido       Codigo = "periodo_dia_semana_invalido"
+	PeriodoDataInicioObrigatoria   Codigo = "periodo_data_inicio_obrigatoria"
+	PeriodoDataInicioInvalida      Codigo = "periodo_data_inicio_invalida"
+	QuantidadePeriodosInvalida     Codigo = "quantidade_periodos_invalida"
+	RegraNaoEncontrada             Codigo = "regra_nao_encontrada"
+	RegraSemCondicao               Codigo = "regra_sem_condicao"
+	RegraSemAcao                   Codigo = "regra_sem_acao"
+	RegraRegexInvalida             Codigo = "regra_regex_invalida"
+	RegraValoresInvertidos         Codigo = "regra_valores_invertidos"
+	RegrasOrdemIncompleta          Codigo = "regras_ordem_incompleta"
+	ImportacaoNaoEncontrada        Codigo = "importacao_nao_encontrada"
+	ImportacaoJaDesfeita           Codigo = "importacao_ja_desfeita"
+	ImportacaoMesFechado           Codigo = "importacao_mes_fechado"
+	ImportacaoDespesaVinculada     Codigo = "importacao_despesa_vinculada"
+	ArquivoObrigatorio             Codigo = "arquivo_obrigatorio"
+	ArquivoMuitoGrande             Codigo = "arquivo_muito_grande"
+	ArquivoIlegivel                Codigo = "arquivo_ilegivel"
+	ArquivoVazio                   Codigo = "arquivo_vazio"
+	ArquivoSemLinhas               Codigo = "arquivo_sem_linhas"
+	ArquivoSemTransacoes           Codigo = "arquivo_sem_transacoes"
+	ArquivoNaoUTF8                 Codigo = "arquivo_nao_utf8"
+	ArquivoExcessoLinhas           Codigo = "arquivo_excesso_linhas"
+	ArquivoExcessoTransacoes       Codigo = "arquivo_excesso_transacoes"
+	ArquivoLinhasComErro           Codigo = "arquivo_linhas_com_erro"
+	MapeamentoInvalido             Codigo = "mapeamento_invalido"
+	EncodingNaoSuportado           Codigo = "encoding_nao_suportado"
+	DelimitadorInvalido            Codigo = "delimitador_invalido"
+	SeparadorDecimalInvalido       Codigo = "separador_decimal_invalido"
+	CSVIlegivel                    Codigo = "csv_ilegivel"
+	ColunaIndiceInexistente        Codigo = "coluna_indice_inexistente"
+	ColunaNomeInexistente          Codigo = "coluna_nome_inexistente"
+	ColunaNaoIdentificada          Codigo = "coluna_nao_identificada"
+	ImportacaoSemLinhasValidas     Codigo = "importacao_sem_linhas_validas"
+	ImportacaoValorZero            Codigo = "importacao_valor_zero"
+	ImportacaoLinhaMesFechado      Codigo = "importacao_linha_mes_fechado"
+	ImportacaoSemTransacoesNovas   Codigo = "importacao_sem_transacoes_novas"
+	OFXInvalido                    Codigo = "ofx_invalido"
+	OFXSemFitid                    Codigo = "ofx_sem_fitid"
+	AlertaNaoEncontrado            Codigo = "alerta_nao_encontrado"
+	AlertaPercentuaisInvalidos     Codigo = "alerta_percentuais_invalidos"
+	AlertaPercentuaisExcesso       Codigo = "alerta_percentuais_excesso"
+	NotificacaoNaoEncontrada       Codigo = "notificacao_nao_encontrada"
+	DispositivoNaoEncontrado       Codigo = "dispositivo_nao_encontrado"
+	DispositivoTokenObrigatorio    Codigo = "dispositivo_token_obrigatorio"
+	DispositivoTokenInvalido       Codigo = "dispositivo_token_invalido"
+	DispositivoPlataformaInvalida  Codigo = "dispositivo_plataforma_invalida"
+	WebhookNaoEncontrado           Codigo = "webhook_nao_encontrado"
+	WebhookURLInvalida             Codigo = "webhook_url_invalida"
+	WebhookURLLocal                Codigo = "webhook_url_local"
+	WebhookEventosObrigatorios     Codigo = "webhook_eventos_obrigatorios"
+	WebhookEventoInvalido          Codigo = "webhook_evento_invalido"
+	WebhookExcesso                 Codigo = "webhook_excesso"
+	EnvelopeNaoEncontrado          Codigo = "envelope_nao_encontrado"
+	EnvelopeVencimentoInvalido     Codigo = "envelope_vencimento_invalido"
+	EnvelopeVencimentoPassado      Codigo = "envelope_vencimento_passado"
+	MovimentoNaoEncontrado         Codigo = "movimento_nao_encontrado"
+	MetaNaoEncontrada              Codigo = "meta_nao_encontrada"
+	MetaValorAlvoNaoPositivo       Codigo = "meta_valor_alvo_nao_positivo"
+	MetaDataAlvoInvalida           Codigo = "meta_data_alvo_invalida"
+	MetaDataAlvoPassada            Codigo = "meta_data_alvo_passada"
+	MetaContribuicaoNegativa       Codigo = "meta_contribuicao_negativa"
+	AporteNaoEncontrado            Codigo = "aporte_nao_encontrado"
+	DividaNaoEncontrada            Codigo = "divida_nao_encontrada"
+	DividaPapelInvalido            Codigo = "divida_papel_invalido"
+	DividaPrincipalNaoPositivo     Codigo = "divida_principal_nao_positivo"
+	DividaTaxaInvalida             Codigo = "divida_taxa_invalida"
+	DividaParcelasInvalidas        Codigo = "divida_parcelas_invalidas"
+	DividaDataInicioInvalida       Codigo = "divida_data_inicio_invalida"
+	DividaSistemaInvalido          Codigo = "divida_sistema_invalido"
+	DividaComPagamentos            Codigo = "divida_com_pagamentos"
+	DividaQuitada                  Codigo = "divida_quitada"
+	DividaValorExcedeSaldo         Codigo = "divida_valor_excede_saldo"
+	DividaValorMensalInsuficiente  Codigo = "divida_valor_mensal_insuficiente"
+	PagamentoNaoEncontrado         Codigo = "pagamento_nao_encontrado"
+	PagamentoDataFutura            Codigo = "pagamento_data_futura"
+	FamiliaSemParticipacao         Codigo = "familia_sem_participacao"
+	FamiliaJaParticipa             Codigo = "familia_ja_participa"
+	FamiliaSemPermissao            Codigo = "familia_sem_permissao"
+	FamiliaPapelInvalido           Codigo = "familia_papel_invalido"
+	FamiliaSemAdministrador        Codigo = "familia_sem_administrador"
+	FamiliaPromovaAdministrador    Codigo = "familia_promova_administrador"
+	FamiliaUseSair                 Codigo = "familia_use_sair"
+	FamiliaMembroExistente         Codigo = "familia_membro_existente"
+	FamiliaMembroDeOutra           Codigo = "familia_membro_de_outra"
+	FamiliaSaiaAntes               Codigo = "familia_saia_antes"
+	MembroNaoEncontrado            Codigo = "membro_nao_encontrado"
+	ConviteNaoEncontrado           Codigo = "convite_nao_encontrado"
+	ConviteExpirado                Codigo = "convite_expirado"
+	ConvitePendenteExistente       Codigo = "convite_pendente_existente"
+	GrupoNaoEncontrado             Codigo = "grupo_nao_encontrado"
+	GrupoUsuarioNaoMembro          Codigo = "grupo_usuario_nao_membro"
+	GrupoPagadorNaoMembro          Codigo = "grupo_pagador_nao_membro"
+	GrupoRecebedorNaoMembro        Codigo = "grupo_recebedor_nao_membro"
+	GrupoExcluirSemPermissao       Codigo = "grupo_excluir_sem_permissao"
+	GrupoRemoverMembroSemPermissao Codigo = "grupo_remover_membro_sem_permissao"
+	GrupoSaldosPendentes           Codigo = "grupo_saldos_pendentes"
+	GrupoSaldoMembroPendente       Codigo = "grupo_saldo_membro_pendente"
+	DivisaoNaoEncontrada           Codigo = "divisao_nao_encontrada"
+	DivisaoTipoInvalido            Codigo = "divisao_tipo_invalido"
+	DivisaoSemParticipantes        Codigo = "divisao_sem_participantes"
+	DivisaoParticipanteRepetido    Codigo = "divisao_participante_repetido"
+	DivisaoPercentualNaoPositivo   Codigo = "divisao_percentual_nao_positivo"
+	DivisaoValorNaoPositivo        Codigo = "divisao_valor_nao_positivo"
+	DivisaoSomaPercentuais         Codigo = "divisao_soma_percentuais"
+	DivisaoSomaValores             Codigo = "divisao_soma_valores"
+	DivisaoExcluirSemPermissao     Codigo = "divisao_excluir_sem_permissao"
+	AcertoNaoEncontrado            Codigo = "acerto_nao_encontrado"
+	AcertoSemDestinatario          Codigo = "acerto_sem_destinatario"
+	AcertoMesmaPessoa              Codigo = "acerto_mesma_pessoa"
+	AcertoSemParticipacao          Codigo = "acerto_sem_participacao"
+	AcertoSemValorPendente         Codigo = "acerto_sem_valor_pendente"
+	AcertoExcluirSemPermissao      Codigo = "acerto_excluir_sem_permissao"
+)
+
+// Textos das notificações. São traduzidos no idioma do destinatário quando a
+// notificação é criada.
+const (
+	NotificacaoNomeLimiteGeral     Codigo = "notificacao_nome_limite_geral"
+	NotificacaoNomeLimiteCategoria Codigo = "notificacao_nome_limite_categoria"
+	NotificacaoLimiteAtingido      Codigo = "notificacao_limite_atingido"
+	NotificacaoLimiteEstourado     Codigo = "notificacao_limite_estourado"
+	NotificacaoLimiteUltrapassado  Codigo = "notificacao_limite_ultrapassado"
+	NotificacaoLimiteMensagem      Codigo = "notificacao_limite_mensagem"
+	NotificacaoPrevisaoTitulo      Codigo = "notificacao_previsao_titulo"
+	NotificacaoPrevisaoMensagem    Codigo = "notificacao_previsao_mensagem"
+	NotificacaoConviteTitulo       Codigo = "notificacao_convite_titulo"
+	NotificacaoConviteMensagem     Codigo = "notificacao_convite_mensagem"
+)
+
+// Textos gravados pelo sistema. O registro guarda o código, traduzido no
+// idioma da requisição quando é lido.
+const (
+	MotivoFechamentoAutomatico Codigo = "motivo_fechamento_automatico"
+)
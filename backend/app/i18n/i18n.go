@@ -0,0 +1,146 @@
+// Package i18n guarda o catálogo de mensagens de erro da API em português
+// (pt-BR) e inglês (en-US). Os serviços retornam um *Erro com o código da
+// mensagem e os controllers o traduzem para o idioma da requisição.
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	PortuguesBrasil = "pt-BR"
+	InglesEUA       = "en-US"
+
+	IdiomaPadrao = PortuguesBrasil
+
+	// ErroNaoCatalogado acompanha erros que não vieram do catálogo, como
+	// falhas de banco repassadas pelos serviços.
+	ErroNaoCatalogado Codigo = "erro_nao_catalogado"
+)
+
+type Codigo string
+
+var catalogos = map[string]map[Codigo]string{
+	PortuguesBrasil: mensagensPortugues,
+	InglesEUA:       mensagensIngles,
+}
+
+// Erro é um erro da API identificado por código. Error() devolve a mensagem
+// em português, então logs e comparações antigas continuam funcionando.
+type Erro struct {
+	Codigo Codigo
+	Args   []interface{}
+}
+
+func NovoErro(codigo Codigo, args ...interface{}) *Erro {
+	return &Erro{Codigo: codigo, Args: args}
+}
+
+func (e *Erro) Error() string {
+	return Traduzir(IdiomaPadrao, e.Codigo, e.Args...)
+}
+
+// Is permite comparar com errors.Is(err, i18n.NovoErro(codigo)): dois erros
+// são iguais quando têm o mesmo código, independentemente dos argumentos.
+func (e *Erro) Is(alvo error) bool {
+	outro, ok := alvo.(*Erro)
+	return ok && outro.Codigo == e.Codigo
+}
+
+// TemCodigo informa se err (ou algum erro embrulhado nele) tem o código.
+func TemCodigo(err error, codigo Codigo) bool {
+	var erro *Erro
+	return errors.As(err, &erro) && erro.Codigo == codigo
+}
+
+// Mes é um argumento de mensagem que vira o nome do mês no idioma da
+// tradução: "janeiro de 2025" em pt-BR e "January 2025" em en-US.
+type Mes time.Time
+
+func (m Mes) nome(idioma string) string {
+	data := time.Time(m)
+	if idioma == InglesEUA {
+		return fmt.Sprintf("%s %d", mesesIngles[data.Month()-1], data.Year())
+	}
+	return fmt.Sprintf("%s de %d", mesesPortugues[data.Month()-1], data.Year())
+}
+
+// Traduzir monta a mensagem do código no idioma pedido. Sem tradução, usa o
+// português; sem mensagem alguma, devolve o próprio código.
+func Traduzir(idioma string, codigo Codigo, args ...interface{}) string {
+	mensagem, ok := catalogos[idioma][codigo]
+	if !ok {
+		if mensagem, ok = mensagensPortugues[codigo]; !ok {
+			return string(codigo)
+		}
+		idioma = IdiomaPadrao
+	}
+
+	traduzidos := make([]interface{}, len(args))
+	for i, arg := range args {
+		if mes, ok := arg.(Mes); ok {
+			traduzidos[i] = mes.nome(idioma)
+		} else {
+			traduzidos[i] = arg
+		}
+	}
+	return fmt.Sprintf(mensagem, traduzidos...)
+}
+
+// Mensagem retorna o código e o texto de err no idioma pedido. Erros fora do
+// catálogo mantêm o texto original.
+func Mensagem(idioma string, err error) (Codigo, string) {
+	var erro *Erro
+	if errors.As(err, &erro) {
+		return erro.Codigo, Traduzir(idioma, erro.Codigo, erro.Args...)
+	}
+	return ErroNaoCatalogado, err.Error()
+}
+
+// NormalizarIdioma aceita variações de caixa, o separador "_" (pt_br) e o
+// idioma sem região (pt, en).
+func NormalizarIdioma(idioma string) (string, bool) {
+	idioma = strings.ReplaceAll(strings.TrimSpace(idioma), "_", "-")
+	for suportado := range catalogos {
+		if strings.EqualFold(idioma, suportado) {
+			return suportado, true
+		}
+	}
+
+	base := strings.ToLower(strings.SplitN(idioma, "-", 2)[0])
+	switch base {
+	case "pt":
+		return PortuguesBrasil, true
+	case "en":
+		return InglesEUA, true
+	}
+	return "", false
+}
+
+// IdiomaDoCabecalho escolhe o idioma suportado de maior peso em um cabeçalho
+// Accept-Language, como "en-US,en;q=0.9,pt;q=0.8".
+func IdiomaDoCabecalho(cabecalho string) (string, bool) {
+	escolhido, maiorPeso := "", 0.0
+	for _, parte := range strings.Split(cabecalho, ",") {
+		campos := strings.Split(strings.TrimSpace(parte), ";")
+		peso := 1.0
+		for _, parametro := range campos[1:] {
+			parametro = strings.TrimSpace(parametro)
+			if strings.HasPrefix(parametro, "q=") {
+				if valor, err := strconv.ParseFloat(strings.TrimPrefix(parametro, "q="), 64); err == nil {
+					peso = valor
+				}
+			}
+		}
+
+		idioma, ok := NormalizarIdioma(campos[0])
+		if ok && peso > maiorPeso {
+			escolhido, maiorPeso = idioma, peso
+		}
+	}
+	return escolhido, escolhido != ""
+}
@@ -0,0 +1,35 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTraduzirMes(t *testing.T) {
+	mes := Mes(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+
+	casos := []struct {
+		idioma   string
+		codigo   Codigo
+		mensagem string
+	}{
+		{idioma: PortuguesBrasil, codigo: ImportacaoLinhaMesFechado, mensagem: "o mês de março de 2025 está fechado"},
+		{idioma: InglesEUA, codigo: ImportacaoLinhaMesFechado, mensagem: "March 2025 is closed"},
+		{idioma: "es-ES", codigo: ImportacaoLinhaMesFechado, mensagem: "o mês de março de 2025 está fechado"},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.idioma, func(t *testing.T) {
+			if mensagem := Traduzir(caso.idioma, caso.codigo, mes); mensagem != caso.mensagem {
+				t.Errorf("mensagem %q, esperava %q", mensagem, caso.mensagem)
+			}
+		})
+	}
+}
+
+func TestNovoErroItem(t *testing.T) {
+	item := NovoErroItem(InglesEUA, NovoErro(ValorInvalidoDetalhe, "abc"))
+	if item.Codigo != ValorInvalidoDetalhe || item.Mensagem != "invalid amount: abc" {
+		t.Errorf("item %+v", item)
+	}
+}
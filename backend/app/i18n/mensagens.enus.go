@@ -0,0 +1,224 @@
+package i18n
+
+// mensagensIngles traduz o catálogo. Códigos sem tradução caem no português.
+var mensagensIngles = map[Codigo]string{
+	DadosInvalidos:                 "Invalid data",
+	DadosInvalidosDetalhe:          "Invalid data: %s",
+	IDInvalido:                     "Invalid ID",
+	ErroInterno:                    "Internal server error",
+	TokenAcessoObrigatorio:         "Access token is required",
+	TokenAcessoFormatoInvalido:     "Invalid token format",
+	TokenAcessoInvalido:            "Invalid token",
+	TokenAcessoExpirado:            "Expired or invalid token",
+	TokenAcessoClaimsInvalidos:     "Invalid token claims",
+	TokenAcessoSemUsuario:          "User ID not found in token",
+	TokenAcessoSemEmail:            "User email not found in token",
+	NomeObrigatorio:                "name is required",
+	DescricaoObrigatoria:           "description is required",
+	EmailObrigatorio:               "email is required",
+	EmailFormatoInvalido:           "invalid email format",
+	EmailDominioInvalido:           "email must contain @ and a valid domain",
+	EmailJaCadastrado:              "email already registered",
+	EmailOuSenhaInvalidos:          "invalid email or password",
+	EmailInvalido:                  "invalid email",
+	EmailInvalidoDetalhe:           "invalid email: %s",
+	EmailNaoCadastrado:             "no user registered with the email %s",
+	SenhaCurta:                     "the password must be at least 6 characters long",
+	SenhasDiferentes:               "the passwords do not match",
+	SenhaErroProcessamento:         "error processing password",
+	TokenErroGeracao:               "error generating authentication token",
+	DataNascimentoFutura:           "birth date must be before the current date",
+	UsuarioNaoEncontrado:           "user not found",
+	ValorNaoPositivo:               "the amount must be greater than zero",
+	ValorMensalInvalido:            "Invalid monthly amount",
+	QuantidadeInvalida:             "Invalid quantity",
+	DataInvalida:                   "invalid date. Use YYYY-MM-DD",
+	DataInvalidaDetalhe:            "invalid date: %s",
+	ValorInvalidoDetalhe:           "invalid amount: %s",
+	AnoInvalido:                    "invalid year",
+	MesInvalido:                    "invalid month",
+	MesForaDoIntervalo:             "month must be between 1 and 12",
+	MesFormatoInvalido:             "invalid month format. Use YYYY-MM",
+	MesReferenciaObrigatorio:       "Reference month is required",
+	MesOuDataObrigatorio:           "provide the reference month or the expense date",
+	PeriodoMesesObrigatorio:        "provide the start and end months",
+	PeriodoMesesInvertido:          "the end month must be equal to or after the start month",
+	PeriodoRelatorioLongo:          "the report period must be at most 60 months",
+	FusoHorarioInvalido:            "invalid time zone. Use a name such as America/Sao_Paulo",
+	IdiomaInvalido:                 "invalid language. Use pt-BR or en-US",
+	MoedaInvalida:                  "invalid currency. Use BRL, USD or EUR",
+	DiaInicioMesInvalido:           "invalid month start day. Use 1 to 28",
+	DespesaNaoEncontrada:           "expense not found",
+	DespesaVinculadaDivida:         "this expense was created by a debt payment. Delete the payment on the debt instead",
+	DespesaVinculadaEnvelope:       "this expense was paid with an envelope. Remove the envelope expense before changing or deleting it",
+	DespesaDuplicada:               "possible duplicate expense. Send ignorarDuplicatas to create it anyway",
+	DespesaPagaComEnvelope:         "this expense has already been paid with an envelope",
+	DespesaMantidaObrigatoria:      "provide the expense to keep",
+	DespesasRemoverObrigatorias:    "provide at least one expense to remove",
+	DespesaMantidaRemovida:         "the kept expense cannot be among the removed ones",
+	DespesasMesclarExcesso:         "too many expenses to merge at once",
+	DespesaNaoDuplicata:            "expense %d does not look like a duplicate of the kept expense",
+	LoteVazio:                      "provide at least one operation",
+	LoteExcesso:                    "the batch can contain at most %d operations",
+	LoteOperacaoInvalida:           "invalid operation. Use criar, atualizar or excluir",
+	LoteModoInvalido:               "invalid mode. Use atomico or parcial",
+	LoteRevertido:                  "operation not applied: the batch was rolled back because another operation failed",
+	LoteIDAtualizarObrigatorio:     "id is required to update",
+	LoteIDExcluirObrigatorio:       "id is required to delete",
+	LimiteNaoEncontrado:            "limit not found",
+	LimiteNaoEncontradoMes:         "no limit found for this month",
+	LimiteMesDuplicado:             "a limit already exists for this month",
+	LimiteCategoriaDuplicado:       "a limit already exists for this category in this month",
+	LimiteSemPermissao:             "only family administrators can change limits",
+	LimiteModoInvalido:             "invalid mode. Use desativado, valor_padrao or copiar_anterior",
+	ValorPadraoNaoPositivo:         "the default amount must be greater than zero",
+	ValorPadraoNegativo:            "the default amount cannot be negative",
+	TetoRolloverNegativo:           "the rollover cap cannot be negative",
+	SugestaoMesesInvalidos:         "the number of analyzed months must be between 3 and 24",
+	SugestaoSemDespesas:            "there are not enough expenses in the period to suggest limits",
+	MesFechadoCriarDespesa:         "cannot create an expense in a closed month",
+	MesFechadoEditarDespesa:        "cannot edit an expense from a closed month",
+	MesFechadoExcluirDespesa:       "cannot delete an expense from a closed month",
+	MesFechadoCriarLimite:          "cannot create a limit in a closed month",
+	MesFechadoEditarLimite:         "cannot edit a limit from a closed month",
+	MesFechadoExcluirLimite:        "cannot delete a limit from a closed month",
+	MesFechadoAplicarSugestoes:     "cannot apply suggestions to a closed month",
+	MesNaoTerminou:                 "a month can only be closed after it ends",
+	MesJaFechado:                   "the month is already closed",
+	MesNaoFechado:                  "the month is not closed",
+	MotivoReaberturaObrigatorio:    "provide the reason for reopening",
+	FechamentoSemPermissao:         "only family administrators can close or reopen months",
+	DiasCarenciaInvalidos:          "invalid grace days. Use 0 to %d",
+	PeriodoTipoInvalido:            "invalid period type. Use mensal, semanal or quinzenal",
+	PeriodoDiaInicioInvalido:       "invalid start day. Use 1 to 28 or, in business days, 1 to 10",
+	PeriodoDiaSemanaInvalido:       "invalid weekday. Use 0 (Sunday) to 6 (Saturday)",
+	PeriodoDataInicioObrigatoria:   "provide the start date of a fortnight (YYYY-MM-DD)",
+	PeriodoDataInicioInvalida:      "invalid start date. Use YYYY-MM-DD",
+	QuantidadePeriodosInvalida:     "invalid quantity. Use 1 to 24 periods",
+	RegraNaoEncontrada:             "rule not found",
+	RegraSemCondicao:               "provide at least one condition for the rule",
+	RegraSemAcao:                   "provide the category, tags or normalized description the rule should apply",
+	RegraRegexInvalida:             "invalid regular expression",
+	RegraValoresInvertidos:         "the minimum amount cannot be greater than the maximum amount",
+	RegrasOrdemIncompleta:          "provide the new position of every rule",
+	ImportacaoNaoEncontrada:        "import not found",
+	ImportacaoJaDesfeita:           "this import has already been undone",
+	ImportacaoMesFechado:           "cannot undo the import: %s is closed",
+	ImportacaoDespesaVinculada:     "cannot undo the import: some expenses were paid with an envelope or are linked to debts",
+	ArquivoObrigatorio:             "File is required",
+	ArquivoMuitoGrande:             "The file must be at most 4MB",
+	ArquivoIlegivel:                "Could not read the file",
+	ArquivoVazio:                   "the file is empty",
+	ArquivoSemLinhas:               "the file has no data rows",
+	ArquivoSemTransacoes:           "the file has no transactions",
+	ArquivoNaoUTF8:                 "the file is not UTF-8 encoded",
+	ArquivoExcessoLinhas:           "the file can contain at most %d rows",
+	ArquivoExcessoTransacoes:       "the file can contain at most %d transactions",
+	ArquivoLinhasComErro:           "the file has %d row(s) with errors. Review the preview or use ignorarErros",
+	MapeamentoInvalido:             "Invalid mapping",
+	EncodingNaoSuportado:           "unsupported encoding: %s",
+	DelimitadorInvalido:            "invalid delimiter",
+	SeparadorDecimalInvalido:       "invalid decimal separator. Use , or .",
+	CSVIlegivel:                    "error reading CSV: %v",
+	ColunaIndiceInexistente:        "column %d does not exist in the file",
+	ColunaNomeInexistente:          "column '%s' not found in the file",
+	ColunaNaoIdentificada:          "could not identify the \"%s\" column. Provide it in the mapping",
+	ImportacaoSemLinhasValidas:     "no valid rows to import",
+	ImportacaoValorZero:            "the amount must not be zero",
+	ImportacaoLinhaMesFechado:      "%s is closed",
+	ImportacaoSemTransacoesNovas:   "no new transactions to import",
+	OFXInvalido:                    "invalid OFX file: <OFX> tag not found",
+	OFXSemFitid:                    "transaction without FITID in the OFX file",
+	AlertaNaoEncontrado:            "alert configuration not found",
+	AlertaPercentuaisInvalidos:     "percentages must be between 1 and 1000",
+	AlertaPercentuaisExcesso:       "provide at most %d percentages",
+	NotificacaoNaoEncontrada:       "notification not found",
+	DispositivoNaoEncontrado:       "device not found",
+	DispositivoTokenObrigatorio:    "token is required",
+	DispositivoTokenInvalido:       "invalid token",
+	DispositivoPlataformaInvalida:  "invalid platform. Use ios, android or web",
+	WebhookNaoEncontrado:           "webhook not found",
+	WebhookURLInvalida:             "invalid url. Use an http or https address",
+	WebhookURLLocal:                "the url cannot point to a local address",
+	WebhookEventosObrigatorios:     "provide at least one event",
+	WebhookEventoInvalido:          "invalid event: %s",
+	WebhookExcesso:                 "each user can have at most %d webhooks",
+	EnvelopeNaoEncontrado:          "envelope not found",
+	EnvelopeVencimentoInvalido:     "invalid due month. Use YYYY-MM",
+	EnvelopeVencimentoPassado:      "the due month of a non-recurring envelope cannot be before the current month",
+	MovimentoNaoEncontrado:         "transaction not found",
+	MetaNaoEncontrada:              "goal not found",
+	MetaValorAlvoNaoPositivo:       "the target amount must be greater than zero",
+	MetaDataAlvoInvalida:           "invalid target date. Use YYYY-MM-DD",
+	MetaDataAlvoPassada:            "the target date cannot be in the past",
+	MetaContribuicaoNegativa:       "the automatic contribution cannot be negative",
+	AporteNaoEncontrado:            "contribution not found",
+	DividaNaoEncontrada:            "debt not found",
+	DividaPapelInvalido:            "invalid role. Use devedor or credor",
+	DividaPrincipalNaoPositivo:     "the principal must be greater than zero",
+	DividaTaxaInvalida:             "the monthly interest rate must be between 0 and 100%%",
+	DividaParcelasInvalidas:        "the number of installments must be between 1 and %d",
+	DividaDataInicioInvalida:       "invalid debt start date. Use YYYY-MM-DD",
+	DividaSistemaInvalido:          "invalid amortization system. Use price or sac",
+	DividaComPagamentos:            "cannot change the terms of a debt with recorded payments",
+	DividaQuitada:                  "the debt is already paid off",
+	DividaValorExcedeSaldo:         "the amount exceeds the outstanding balance of %s",
+	DividaValorMensalInsuficiente:  "the monthly amount does not even cover the month's interest",
+	PagamentoNaoEncontrado:         "payment not found",
+	PagamentoDataFutura:            "the payment date cannot be in the future",
+	FamiliaSemParticipacao:         "you are not part of a family",
+	FamiliaJaParticipa:             "you are already part of a family",
+	FamiliaSemPermissao:            "only family administrators can do this",
+	FamiliaPapelInvalido:           "invalid role. Use administrador or membro",
+	FamiliaSemAdministrador:        "the family needs at least one administrator",
+	FamiliaPromovaAdministrador:    "promote another member to administrator before leaving the family",
+	FamiliaUseSair:                 "to leave the family, use the leave option",
+	FamiliaMembroExistente:         "this user is already a member of the family",
+	FamiliaMembroDeOutra:           "this user is already part of another family",
+	FamiliaSaiaAntes:               "leave your current family before accepting the invitation",
+	MembroNaoEncontrado:            "member not found",
+	ConviteNaoEncontrado:           "invitation not found",
+	ConviteExpirado:                "invitation expired",
+	ConvitePendenteExistente:       "there is already a pending invitation for this email",
+	GrupoNaoEncontrado:             "group not found",
+	GrupoUsuarioNaoMembro:          "user %d is not a member of the group",
+	GrupoPagadorNaoMembro:          "the payer must be a member of the group",
+	GrupoRecebedorNaoMembro:        "the recipient must be a member of the group",
+	GrupoExcluirSemPermissao:       "only the group creator can delete it",
+	GrupoRemoverMembroSemPermissao: "only the group creator can remove other members",
+	GrupoSaldosPendentes:           "settle all balances before deleting the group",
+	GrupoSaldoMembroPendente:       "the member's balance must be zero to leave the group",
+	DivisaoNaoEncontrada:           "split not found",
+	DivisaoTipoInvalido:            "invalid split type. Use igual, percentual or valor",
+	DivisaoSemParticipantes:        "provide at least one participant",
+	DivisaoParticipanteRepetido:    "repeated participant in the split",
+	DivisaoPercentualNaoPositivo:   "each participant's percentage must be greater than zero",
+	DivisaoValorNaoPositivo:        "each participant's amount must be greater than zero",
+	DivisaoSomaPercentuais:         "the percentages must add up to 100 (provided: %.2f)",
+	DivisaoSomaValores:             "the sum of the shares (%.2f) must equal the expense amount (%.2f)",
+	DivisaoExcluirSemPermissao:     "only whoever recorded or paid can delete the split",
+	AcertoNaoEncontrado:            "settlement not found",
+	AcertoSemDestinatario:          "provide who the payment was made to",
+	AcertoMesmaPessoa:              "payer and recipient must be different people",
+	AcertoSemParticipacao:          "you can only record settlements you take part in",
+	AcertoSemValorPendente:         "there is no pending amount between these members. Provide the settlement amount",
+	AcertoExcluirSemPermissao:      "only the payer or the recipient can delete the settlement",
+
+	// Notificações
+	NotificacaoNomeLimiteGeral:     "the overall limit",
+	NotificacaoNomeLimiteCategoria: "the %s limit",
+	NotificacaoLimiteAtingido:      "You reached %d%% of %s",
+	NotificacaoLimiteEstourado:     "You went over %s",
+	NotificacaoLimiteUltrapassado:  "Your spending passed %d%% of %s",
+	NotificacaoLimiteMensagem:      "Spent %s of %s in %s (%.2f%%).",
+	NotificacaoPrevisaoTitulo:      "At the current pace, you will go over your limit",
+	NotificacaoPrevisaoMensagem:    "The forecast for %s is %s, above the %s limit.",
+	NotificacaoConviteTitulo:       "Family invitation",
+	NotificacaoConviteMensagem:     "You have been invited to join the %s family.",
+	MotivoFechamentoAutomatico:     "automatic closing after the grace period",
+}
+
+var mesesIngles = [12]string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
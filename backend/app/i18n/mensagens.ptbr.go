@@ -0,0 +1,225 @@
+package i18n
+
+// mensagensPortugues é o catálogo de referência: todo código precisa de uma
+// mensagem aqui, e Erro.Error() usa estes textos.
+var mensagensPortugues = map[Codigo]string{
+	DadosInvalidos:                 "Dados inválidos",
+	DadosInvalidosDetalhe:          "Dados inválidos: %s",
+	IDInvalido:                     "ID inválido",
+	ErroInterno:                    "Erro interno do servidor",
+	TokenAcessoObrigatorio:         "Token de acesso é obrigatório",
+	TokenAcessoFormatoInvalido:     "Formato de token inválido",
+	TokenAcessoInvalido:            "Token inválido",
+	TokenAcessoExpirado:            "Token expirado ou inválido",
+	TokenAcessoClaimsInvalidos:     "Claims do token inválidos",
+	TokenAcessoSemUsuario:          "ID do usuário não encontrado no token",
+	TokenAcessoSemEmail:            "Email do usuário não encontrado no token",
+	NomeObrigatorio:                "nome é obrigatório",
+	DescricaoObrigatoria:           "descrição é obrigatória",
+	EmailObrigatorio:               "email é obrigatório",
+	EmailFormatoInvalido:           "formato de email inválido",
+	EmailDominioInvalido:           "email deve conter @ e domínio válido",
+	EmailJaCadastrado:              "email já cadastrado",
+	EmailOuSenhaInvalidos:          "email ou senha inválidos",
+	EmailInvalido:                  "e-mail inválido",
+	EmailInvalidoDetalhe:           "e-mail inválido: %s",
+	EmailNaoCadastrado:             "nenhum usuário cadastrado com o e-mail %s",
+	SenhaCurta:                     "a senha deve ter pelo menos 6 caracteres",
+	SenhasDiferentes:               "as senhas não coincidem",
+	SenhaErroProcessamento:         "erro ao processar senha",
+	TokenErroGeracao:               "erro ao gerar token de autenticação",
+	DataNascimentoFutura:           "data de nascimento deve ser anterior à data atual",
+	UsuarioNaoEncontrado:           "usuário não encontrado",
+	ValorNaoPositivo:               "o valor deve ser maior que zero",
+	ValorMensalInvalido:            "Valor mensal inválido",
+	QuantidadeInvalida:             "Quantidade inválida",
+	DataInvalida:                   "data inválida. Use YYYY-MM-DD",
+	DataInvalidaDetalhe:            "data inválida: %s",
+	ValorInvalidoDetalhe:           "valor inválido: %s",
+	AnoInvalido:                    "ano inválido",
+	MesInvalido:                    "mês inválido",
+	MesForaDoIntervalo:             "mês deve estar entre 1 e 12",
+	MesFormatoInvalido:             "formato de mês inválido. Use YYYY-MM",
+	MesReferenciaObrigatorio:       "Mês de referência é obrigatório",
+	MesOuDataObrigatorio:           "informe o mês de referência ou a data da despesa",
+	PeriodoMesesObrigatorio:        "informe o mês inicial e o mês final",
+	PeriodoMesesInvertido:          "o mês final deve ser igual ou posterior ao mês inicial",
+	PeriodoRelatorioLongo:          "o período do relatório deve ter no máximo 60 meses",
+	FusoHorarioInvalido:            "fuso horário inválido. Use um nome como America/Sao_Paulo",
+	IdiomaInvalido:                 "idioma inválido. Use pt-BR ou en-US",
+	MoedaInvalida:                  "moeda inválida. Use BRL, USD ou EUR",
+	DiaInicioMesInvalido:           "dia de início do mês inválido. Use de 1 a 28",
+	DespesaNaoEncontrada:           "despesa não encontrada",
+	DespesaVinculadaDivida:         "esta despesa foi gerada pelo pagamento de uma dívida. Exclua o pagamento na dívida",
+	DespesaVinculadaEnvelope:       "esta despesa foi paga com um envelope. Remova o gasto do envelope antes de alterá-la ou excluí-la",
+	DespesaDuplicada:               "possível despesa duplicada. Envie ignorarDuplicatas para criar mesmo assim",
+	DespesaPagaComEnvelope:         "esta despesa já foi paga com um envelope",
+	DespesaMantidaObrigatoria:      "informe a despesa que deve ser mantida",
+	DespesasRemoverObrigatorias:    "informe ao menos uma despesa para remover",
+	DespesaMantidaRemovida:         "a despesa mantida não pode estar entre as removidas",
+	DespesasMesclarExcesso:         "muitas despesas para mesclar de uma só vez",
+	DespesaNaoDuplicata:            "a despesa %d não parece duplicata da despesa mantida",
+	LoteVazio:                      "informe ao menos uma operação",
+	LoteExcesso:                    "o lote pode conter no máximo %d operações",
+	LoteOperacaoInvalida:           "operação inválida. Use criar, atualizar ou excluir",
+	LoteModoInvalido:               "modo inválido. Use atomico ou parcial",
+	LoteRevertido:                  "operação não aplicada: o lote foi revertido por falha em outra operação",
+	LoteIDAtualizarObrigatorio:     "id é obrigatório para atualizar",
+	LoteIDExcluirObrigatorio:       "id é obrigatório para excluir",
+	LimiteNaoEncontrado:            "limite não encontrado",
+	LimiteNaoEncontradoMes:         "limite não encontrado para este mês",
+	LimiteMesDuplicado:             "já existe um limite criado para este mês",
+	LimiteCategoriaDuplicado:       "já existe um limite criado para esta categoria neste mês",
+	LimiteSemPermissao:             "apenas administradores da família podem alterar limites",
+	LimiteModoInvalido:             "modo inválido. Use desativado, valor_padrao ou copiar_anterior",
+	ValorPadraoNaoPositivo:         "o valor padrão deve ser maior que zero",
+	ValorPadraoNegativo:            "o valor padrão não pode ser negativo",
+	TetoRolloverNegativo:           "o teto de rollover não pode ser negativo",
+	SugestaoMesesInvalidos:         "o número de meses analisados deve estar entre 3 e 24",
+	SugestaoSemDespesas:            "não há despesas suficientes no período para sugerir limites",
+	MesFechadoCriarDespesa:         "não é possível criar despesa em um mês fechado",
+	MesFechadoEditarDespesa:        "não é possível editar despesa de um mês fechado",
+	MesFechadoExcluirDespesa:       "não é possível excluir despesa de um mês fechado",
+	MesFechadoCriarLimite:          "não é possível criar limite em um mês fechado",
+	MesFechadoEditarLimite:         "não é possível editar limite de um mês fechado",
+	MesFechadoExcluirLimite:        "não é possível excluir limite de um mês fechado",
+	MesFechadoAplicarSugestoes:     "não é possível aplicar sugestões em um mês fechado",
+	MesNaoTerminou:                 "só é possível fechar um mês depois que ele termina",
+	MesJaFechado:                   "o mês já está fechado",
+	MesNaoFechado:                  "o mês não está fechado",
+	MotivoReaberturaObrigatorio:    "informe o motivo da reabertura",
+	FechamentoSemPermissao:         "apenas administradores da família podem fechar ou reabrir meses",
+	DiasCarenciaInvalidos:          "dias de carência inválidos. Use de 0 a %d",
+	PeriodoTipoInvalido:            "tipo de período inválido. Use mensal, semanal ou quinzenal",
+	PeriodoDiaInicioInvalido:       "dia de início inválido. Use de 1 a 28 ou, em dias úteis, de 1 a 10",
+	PeriodoDiaSemanaInvalido:       "dia da semana inválido. Use de 0 (domingo) a 6 (sábado)",
+	PeriodoDataInicioObrigatoria:   "informe a data de início de uma quinzena (YYYY-MM-DD)",
+	PeriodoDataInicioInvalida:      "data de início inválida. Use YYYY-MM-DD",
+	QuantidadePeriodosInvalida:     "quantidade inválida. Use de 1 a 24 períodos",
+	RegraNaoEncontrada:             "regra não encontrada",
+	RegraSemCondicao:               "informe ao menos uma condição para a regra",
+	RegraSemAcao:                   "informe a categoria, as tags ou a descrição normalizada que a regra deve aplicar",
+	RegraRegexInvalida:             "expressão regular inválida",
+	RegraValoresInvertidos:         "o valor mínimo não pode ser maior que o valor máximo",
+	RegrasOrdemIncompleta:          "informe a nova posição de todas as regras",
+	ImportacaoNaoEncontrada:        "importação não encontrada",
+	ImportacaoJaDesfeita:           "esta importação já foi desfeita",
+	ImportacaoMesFechado:           "não é possível desfazer a importação: o mês de %s está fechado",
+	ImportacaoDespesaVinculada:     "não é possível desfazer a importação: há despesas pagas com envelope ou vinculadas a dívidas",
+	ArquivoObrigatorio:             "Arquivo é obrigatório",
+	ArquivoMuitoGrande:             "O arquivo deve ter no máximo 4MB",
+	ArquivoIlegivel:                "Não foi possível ler o arquivo",
+	ArquivoVazio:                   "o arquivo está vazio",
+	ArquivoSemLinhas:               "o arquivo não possui linhas de dados",
+	ArquivoSemTransacoes:           "o arquivo não possui transações",
+	ArquivoNaoUTF8:                 "o arquivo não está codificado em UTF-8",
+	ArquivoExcessoLinhas:           "o arquivo pode conter no máximo %d linhas",
+	ArquivoExcessoTransacoes:       "o arquivo pode conter no máximo %d transações",
+	ArquivoLinhasComErro:           "o arquivo possui %d linha(s) com erro. Revise a pré-visualização ou use ignorarErros",
+	MapeamentoInvalido:             "Mapeamento inválido",
+	EncodingNaoSuportado:           "encoding não suportado: %s",
+	DelimitadorInvalido:            "delimitador inválido",
+	SeparadorDecimalInvalido:       "separador decimal inválido. Use , ou .",
+	CSVIlegivel:                    "erro ao ler CSV: %v",
+	ColunaIndiceInexistente:        "coluna %d não existe no arquivo",
+	ColunaNomeInexistente:          "coluna '%s' não encontrada no arquivo",
+	ColunaNaoIdentificada:          "não foi possível identificar a coluna \"%s\". Informe-a no mapeamento",
+	ImportacaoSemLinhasValidas:     "nenhuma linha válida para importar",
+	ImportacaoValorZero:            "o valor deve ser diferente de zero",
+	ImportacaoLinhaMesFechado:      "o mês de %s está fechado",
+	ImportacaoSemTransacoesNovas:   "nenhuma transação nova para importar",
+	OFXInvalido:                    "arquivo OFX inválido: tag <OFX> não encontrada",
+	OFXSemFitid:                    "transação sem FITID no arquivo OFX",
+	AlertaNaoEncontrado:            "configuração de alerta não encontrada",
+	AlertaPercentuaisInvalidos:     "os percentuais devem estar entre 1 e 1000",
+	AlertaPercentuaisExcesso:       "informe no máximo %d percentuais",
+	NotificacaoNaoEncontrada:       "notificação não encontrada",
+	DispositivoNaoEncontrado:       "dispositivo não encontrado",
+	DispositivoTokenObrigatorio:    "token é obrigatório",
+	DispositivoTokenInvalido:       "token inválido",
+	DispositivoPlataformaInvalida:  "plataforma inválida. Use ios, android ou web",
+	WebhookNaoEncontrado:           "webhook não encontrado",
+	WebhookURLInvalida:             "url inválida. Use um endereço http ou https",
+	WebhookURLLocal:                "a url não pode apontar para um endereço local",
+	WebhookEventosObrigatorios:     "informe ao menos um evento",
+	WebhookEventoInvalido:          "evento inválido: %s",
+	WebhookExcesso:                 "cada usuário pode ter no máximo %d webhooks",
+	EnvelopeNaoEncontrado:          "envelope não encontrado",
+	EnvelopeVencimentoInvalido:     "mês de vencimento inválido. Use YYYY-MM",
+	EnvelopeVencimentoPassado:      "o vencimento de um envelope não recorrente não pode ser anterior ao mês corrente",
+	MovimentoNaoEncontrado:         "movimento não encontrado",
+	MetaNaoEncontrada:              "meta não encontrada",
+	MetaValorAlvoNaoPositivo:       "o valor alvo deve ser maior que zero",
+	MetaDataAlvoInvalida:           "data alvo inválida. Use YYYY-MM-DD",
+	MetaDataAlvoPassada:            "a data alvo não pode estar no passado",
+	MetaContribuicaoNegativa:       "a contribuição automática não pode ser negativa",
+	AporteNaoEncontrado:            "aporte não encontrado",
+	DividaNaoEncontrada:            "dívida não encontrada",
+	DividaPapelInvalido:            "papel inválido. Use devedor ou credor",
+	DividaPrincipalNaoPositivo:     "o valor principal deve ser maior que zero",
+	DividaTaxaInvalida:             "a taxa de juros mensal deve estar entre 0 e 100%%",
+	DividaParcelasInvalidas:        "o número de parcelas deve estar entre 1 e %d",
+	DividaDataInicioInvalida:       "data de início da dívida inválida. Use YYYY-MM-DD",
+	DividaSistemaInvalido:          "sistema de amortização inválido. Use price ou sac",
+	DividaComPagamentos:            "não é possível alterar as condições de uma dívida com pagamentos registrados",
+	DividaQuitada:                  "a dívida já está quitada",
+	DividaValorExcedeSaldo:         "o valor excede o saldo devedor de %s",
+	DividaValorMensalInsuficiente:  "o valor mensal não cobre nem os juros do mês",
+	PagamentoNaoEncontrado:         "pagamento não encontrado",
+	PagamentoDataFutura:            "a data do pagamento não pode estar no futuro",
+	FamiliaSemParticipacao:         "você não participa de uma família",
+	FamiliaJaParticipa:             "você já participa de uma família",
+	FamiliaSemPermissao:            "apenas administradores da família podem fazer isso",
+	FamiliaPapelInvalido:           "papel inválido. Use administrador ou membro",
+	FamiliaSemAdministrador:        "a família precisa de pelo menos um administrador",
+	FamiliaPromovaAdministrador:    "promova outro membro a administrador antes de sair da família",
+	FamiliaUseSair:                 "para deixar a família, use a opção de sair",
+	FamiliaMembroExistente:         "este usuário já é membro da família",
+	FamiliaMembroDeOutra:           "este usuário já participa de outra família",
+	FamiliaSaiaAntes:               "saia da sua família atual antes de aceitar o convite",
+	MembroNaoEncontrado:            "membro não encontrado",
+	ConviteNaoEncontrado:           "convite não encontrado",
+	ConviteExpirado:                "convite expirado",
+	ConvitePendenteExistente:       "já existe um convite pendente para este e-mail",
+	GrupoNaoEncontrado:             "grupo não encontrado",
+	GrupoUsuarioNaoMembro:          "o usuário %d não é membro do grupo",
+	GrupoPagadorNaoMembro:          "quem pagou precisa ser membro do grupo",
+	GrupoRecebedorNaoMembro:        "quem recebeu precisa ser membro do grupo",
+	GrupoExcluirSemPermissao:       "apenas quem criou o grupo pode excluí-lo",
+	GrupoRemoverMembroSemPermissao: "apenas quem criou o grupo pode remover outros membros",
+	GrupoSaldosPendentes:           "acerte todos os saldos antes de excluir o grupo",
+	GrupoSaldoMembroPendente:       "o saldo do membro precisa estar zerado para sair do grupo",
+	DivisaoNaoEncontrada:           "divisão não encontrada",
+	DivisaoTipoInvalido:            "tipo de divisão inválido. Use igual, percentual ou valor",
+	DivisaoSemParticipantes:        "informe ao menos um participante",
+	DivisaoParticipanteRepetido:    "participante repetido na divisão",
+	DivisaoPercentualNaoPositivo:   "o percentual de cada participante deve ser maior que zero",
+	DivisaoValorNaoPositivo:        "o valor de cada participante deve ser maior que zero",
+	DivisaoSomaPercentuais:         "a soma dos percentuais deve ser 100 (informado: %.2f)",
+	DivisaoSomaValores:             "a soma das partes (%.2f) deve ser igual ao valor da despesa (%.2f)",
+	DivisaoExcluirSemPermissao:     "apenas quem lançou ou quem pagou pode excluir a divisão",
+	AcertoNaoEncontrado:            "acerto não encontrado",
+	AcertoSemDestinatario:          "informe para quem o pagamento foi feito",
+	AcertoMesmaPessoa:              "quem paga e quem recebe devem ser pessoas diferentes",
+	AcertoSemParticipacao:          "só é possível registrar acertos dos quais você participa",
+	AcertoSemValorPendente:         "não há valor pendente entre esses membros. Informe o valor do acerto",
+	AcertoExcluirSemPermissao:      "apenas quem pagou ou quem recebeu pode excluir o acerto",
+
+	// Notificações
+	NotificacaoNomeLimiteGeral:     "limite geral",
+	NotificacaoNomeLimiteCategoria: "limite de %s",
+	NotificacaoLimiteAtingido:      "Você atingiu %d%% do %s",
+	NotificacaoLimiteEstourado:     "Você estourou o %s",
+	NotificacaoLimiteUltrapassado:  "Seu gasto passou de %d%% do %s",
+	NotificacaoLimiteMensagem:      "Gasto de %s de %s em %s (%.2f%%).",
+	NotificacaoPrevisaoTitulo:      "No ritmo atual, você vai ultrapassar o limite",
+	NotificacaoPrevisaoMensagem:    "A previsão para %s é de %s, acima do limite de %s.",
+	NotificacaoConviteTitulo:       "Convite para família",
+	NotificacaoConviteMensagem:     "Você foi convidado para participar da família %s.",
+	MotivoFechamentoAutomatico:     "fechamento automático após o período de carência",
+}
+
+var mesesPortugues = [12]string{
+	"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+	"julho", "agosto", "setembro", "outubro", "novembro", "dezembro",
+}
@@ -0,0 +1,59 @@
+package i18n
+
+import "github.com/gofiber/fiber/v2"
+
+const (
+	localIdioma          = "idioma"
+	localIdiomaDoUsuario = "idiomaDoUsuario"
+)
+
+// DefinirIdioma fixa o idioma da requisição, normalmente o do Accept-Language.
+func DefinirIdioma(ctx *fiber.Ctx, idioma string) {
+	ctx.Locals(localIdioma, idioma)
+}
+
+// DefinirIdiomaDoUsuario registra como descobrir o idioma preferido do
+// usuário. A consulta só acontece se a resposta precisar de tradução e a
+// requisição estiver autenticada.
+func DefinirIdiomaDoUsuario(ctx *fiber.Ctx, idiomaDoUsuario func(userID uint) string) {
+	ctx.Locals(localIdiomaDoUsuario, idiomaDoUsuario)
+}
+
+// IdiomaDaRequisicao escolhe, nesta ordem: o idioma do Accept-Language, o
+// das preferências do usuário autenticado e o idioma padrão.
+func IdiomaDaRequisicao(ctx *fiber.Ctx) string {
+	if idioma, ok := ctx.Locals(localIdioma).(string); ok && idioma != "" {
+		return idioma
+	}
+
+	idiomaDoUsuario, ok := ctx.Locals(localIdiomaDoUsuario).(func(uint) string)
+	if userID, autenticado := ctx.Locals("userID").(uint); ok && autenticado {
+		if idioma, ok := NormalizarIdioma(idiomaDoUsuario(userID)); ok {
+			return idioma
+		}
+	}
+	return IdiomaPadrao
+}
+
+// Resposta é o corpo de erro da API: a mensagem traduzida em "error" e o
+// código estável em "code".
+func Resposta(ctx *fiber.Ctx, err error) fiber.Map {
+	codigo, mensagem := Mensagem(IdiomaDaRequisicao(ctx), err)
+	return fiber.Map{"error": mensagem, "code": codigo}
+}
+
+func RespostaCodigo(ctx *fiber.Ctx, codigo Codigo, args ...interface{}) fiber.Map {
+	return Resposta(ctx, NovoErro(codigo, args...))
+}
+
+// ErroItem é o erro de um item nas respostas que processam vários itens de
+// uma vez, como lotes e importações, no mesmo formato do corpo de erro.
+type ErroItem struct {
+	Codigo   Codigo `json:"code"`
+	Mensagem string `json:"error"`
+}
+
+func NovoErroItem(idioma string, err error) *ErroItem {
+	codigo, mensagem := Mensagem(idioma, err)
+	return &ErroItem{Codigo: codigo, Mensagem: mensagem}
+}
@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+)
+
+func IniciarFechamentoAutomaticoMeses(fechamentoService *services.FechamentoService, relogio relogio.Relogio, intervalo time.Duration) {
+	executar := func() {
+		fechados, err := fechamentoService.FecharMesesAutomaticamente(relogio.Agora())
+		if err != nil {
+			log.Printf("Falha ao fechar meses automaticamente: %v", err)
+		}
+		if fechados > 0 {
+			log.Printf("%d mês(es) fechado(s) automaticamente", fechados)
+		}
+	}
+
+	go func() {
+		executar()
+
+		ticker := time.NewTicker(intervalo)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			executar()
+		}
+	}()
+}
@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+)
+
+func IniciarCriacaoAutomaticaLimites(limiteService *services.LimiteService, relogio relogio.Relogio, intervalo time.Duration) {
+	executar := func() {
+		criados, err := limiteService.CriarLimitesAutomaticos(relogio.Agora())
+		if err != nil {
+			log.Printf("Falha ao criar limites automáticos: %v", err)
+		}
+		if criados > 0 {
+			log.Printf("%d limite(s) criado(s) automaticamente", criados)
+		}
+	}
+
+	go func() {
+		executar()
+
+		ticker := time.NewTicker(intervalo)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			executar()
+		}
+	}()
+}
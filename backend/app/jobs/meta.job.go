@@ -0,0 +1,32 @@
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+)
+
+func IniciarAportesAutomaticosMetas(metaService *services.MetaService, relogio relogio.Relogio, intervalo time.Duration) {
+	executar := func() {
+		criados, err := metaService.RegistrarAportesAutomaticos(relogio.Agora())
+		if err != nil {
+			log.Printf("Falha ao registrar aportes automáticos: %v", err)
+		}
+		if criados > 0 {
+			log.Printf("%d aporte(s) automático(s) registrado(s)", criados)
+		}
+	}
+
+	go func() {
+		executar()
+
+		ticker := time.NewTicker(intervalo)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			executar()
+		}
+	}()
+}
@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+)
+
+func IniciarDespachoOutbox(outboxService *services.OutboxService, relogio relogio.Relogio, intervalo time.Duration) {
+	executar := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		// Processa lotes até esvaziar a fila de mensagens prontas
+		for {
+			processadas, err := outboxService.ProcessarPendentes(ctx, relogio.Agora().UTC())
+			if err != nil {
+				log.Printf("Falha ao despachar mensagens da outbox: %v", err)
+				return
+			}
+			if processadas == 0 || ctx.Err() != nil {
+				return
+			}
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(intervalo)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			executar()
+		}
+	}()
+}
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
+	"github.com/Vicente/Password-Mobile-App/backend/app/services"
+)
+
+func IniciarDespachoWebhooks(webhookService *services.WebhookService, relogio relogio.Relogio, intervalo time.Duration) {
+	executar := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		for {
+			processadas, err := webhookService.ProcessarPendentes(ctx, relogio.Agora().UTC())
+			if err != nil {
+				log.Printf("Falha ao despachar webhooks: %v", err)
+				return
+			}
+			if processadas == 0 || ctx.Err() != nil {
+				return
+			}
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(intervalo)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			executar()
+		}
+	}()
+}
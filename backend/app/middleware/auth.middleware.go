@@ -4,6 +4,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v4"
 )
@@ -14,18 +15,14 @@ func AuthMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Token de acesso é obrigatório",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(i18n.RespostaCodigo(c, i18n.TokenAcessoObrigatorio))
 		}
 
 		tokenString := ""
 		if strings.HasPrefix(authHeader, "Bearer ") {
 			tokenString = strings.TrimPrefix(authHeader, "Bearer ")
 		} else {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Formato de token inválido",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(i18n.RespostaCodigo(c, i18n.TokenAcessoFormatoInvalido))
 		}
 
 		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -36,36 +33,26 @@ func AuthMiddleware() fiber.Handler {
 		})
 
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Token inválido",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(i18n.RespostaCodigo(c, i18n.TokenAcessoInvalido))
 		}
 
 		if !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Token expirado ou inválido",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(i18n.RespostaCodigo(c, i18n.TokenAcessoExpirado))
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Claims do token inválidos",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(i18n.RespostaCodigo(c, i18n.TokenAcessoClaimsInvalidos))
 		}
 
 		userID, ok := claims["id"].(float64)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "ID do usuário não encontrado no token",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(i18n.RespostaCodigo(c, i18n.TokenAcessoSemUsuario))
 		}
 
 		userEmail, ok := claims["email"].(string)
 		if !ok {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"error": "Email do usuário não encontrado no token",
-			})
+			return c.Status(fiber.StatusUnauthorized).JSON(i18n.RespostaCodigo(c, i18n.TokenAcessoSemEmail))
 		}
 
 		c.Locals("userID", uint(userID))
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/gofiber/fiber/v2"
+)
+
+// IdiomaMiddleware escolhe o idioma das mensagens de erro. Um Accept-Language
+// suportado vale para a requisição toda; sem ele, as rotas autenticadas usam
+// o idioma das preferências do usuário.
+func IdiomaMiddleware(idiomaDoUsuario func(userID uint) string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if idioma, ok := i18n.IdiomaDoCabecalho(c.Get("Accept-Language")); ok {
+			i18n.DefinirIdioma(c, idioma)
+		}
+		i18n.DefinirIdiomaDoUsuario(c, idiomaDoUsuario)
+		return c.Next()
+	}
+}
@@ -0,0 +1,251 @@
+package parsers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingLatin1      = "latin1"
+	EncodingWindows1252 = "windows-1252"
+)
+
+var (
+	delimitadoresCSV = []rune{';', ',', '\t', '|'}
+
+	formatosData = []string{
+		"02/01/2006",
+		"2006-01-02",
+		"02-01-2006",
+		"02.01.2006",
+		"2006/01/02",
+		"02/01/06",
+	}
+
+	virgulaDecimal = regexp.MustCompile(`,\d{1,2}$`)
+	pontoDecimal   = regexp.MustCompile(`\.\d{1,2}$`)
+)
+
+// DecodificarTexto converte o conteúdo bruto para UTF-8. Sem encoding
+// informado, assume Windows-1252 sempre que os bytes não forem UTF-8 válido,
+// que é o caso comum dos extratos exportados por bancos brasileiros. Ele
+// coincide com o Latin-1 nos acentos, mas usa a faixa 0x80-0x9F para
+// caracteres como "€" e "–" em vez de códigos de controle.
+func DecodificarTexto(raw []byte, encoding string) (string, string, error) {
+	raw = bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf"))
+
+	switch strings.ToLower(encoding) {
+	case "":
+		if utf8.Valid(raw) {
+			return string(raw), EncodingUTF8, nil
+		}
+		return decodificar(charmap.Windows1252, raw), EncodingWindows1252, nil
+	case "utf-8", "utf8":
+		if !utf8.Valid(raw) {
+			return "", "", i18n.NovoErro(i18n.ArquivoNaoUTF8)
+		}
+		return string(raw), EncodingUTF8, nil
+	case "latin1", "latin-1", "iso-8859-1":
+		return decodificar(charmap.ISO8859_1, raw), EncodingLatin1, nil
+	case "windows-1252", "cp1252":
+		return decodificar(charmap.Windows1252, raw), EncodingWindows1252, nil
+	default:
+		return "", "", i18n.NovoErro(i18n.EncodingNaoSuportado, encoding)
+	}
+}
+
+// decodificar não falha: nas tabelas de um byte, todo byte tem um caractere
+// correspondente.
+func decodificar(tabela *charmap.Charmap, raw []byte) string {
+	texto, _ := tabela.NewDecoder().Bytes(raw)
+	return string(texto)
+}
+
+// DetectarDelimitador escolhe, entre os delimitadores suportados, aquele que
+// aparece o mesmo número de vezes nas primeiras linhas do arquivo.
+func DetectarDelimitador(texto string) rune {
+	linhas := make([]string, 0, 10)
+	for _, linha := range strings.Split(texto, "\n") {
+		if strings.TrimSpace(linha) == "" {
+			continue
+		}
+		linhas = append(linhas, linha)
+		if len(linhas) == 10 {
+			break
+		}
+	}
+
+	melhor := ';'
+	melhorContagem := 0
+	for _, delimitador := range delimitadoresCSV {
+		contagem := -1
+		consistente := true
+		for _, linha := range linhas {
+			n := contarForaDeAspas(linha, delimitador)
+			if contagem == -1 {
+				contagem = n
+			} else if n != contagem {
+				consistente = false
+				break
+			}
+		}
+		if consistente && contagem > melhorContagem {
+			melhor = delimitador
+			melhorContagem = contagem
+		}
+	}
+
+	return melhor
+}
+
+func contarForaDeAspas(linha string, delimitador rune) int {
+	total := 0
+	entreAspas := false
+	for _, r := range linha {
+		if r == '"' {
+			entreAspas = !entreAspas
+		} else if r == delimitador && !entreAspas {
+			total++
+		}
+	}
+	return total
+}
+
+func LerCSV(texto string, delimitador rune) ([][]string, error) {
+	reader := csv.NewReader(strings.NewReader(texto))
+	reader.Comma = delimitador
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	registros, err := reader.ReadAll()
+	if err != nil {
+		return nil, i18n.NovoErro(i18n.CSVIlegivel, err)
+	}
+
+	var linhas [][]string
+	for _, registro := range registros {
+		vazio := true
+		for _, campo := range registro {
+			if strings.TrimSpace(campo) != "" {
+				vazio = false
+				break
+			}
+		}
+		if !vazio {
+			linhas = append(linhas, registro)
+		}
+	}
+
+	return linhas, nil
+}
+
+// DetectarSeparadorDecimal analisa amostras de valores e retorna "," quando
+// predominam valores no formato brasileiro (1.234,56).
+func DetectarSeparadorDecimal(amostras []string) string {
+	virgulas, pontos := 0, 0
+	for _, amostra := range amostras {
+		amostra = strings.TrimSpace(amostra)
+		if virgulaDecimal.MatchString(amostra) {
+			virgulas++
+		} else if pontoDecimal.MatchString(amostra) {
+			pontos++
+		}
+	}
+	if virgulas >= pontos && virgulas > 0 {
+		return ","
+	}
+	if pontos > 0 {
+		return "."
+	}
+	return ","
+}
+
+// ParseValor interpreta valores monetários como "R$ -1.234,56", "(12,00)"
+// ou "1,234.56" de acordo com o separador decimal informado.
+func ParseValor(valor string, separadorDecimal string) (float64, error) {
+	original := valor
+	valor = strings.TrimSpace(valor)
+	valor = strings.ReplaceAll(valor, "R$", "")
+	valor = strings.ReplaceAll(valor, " ", "")
+	valor = strings.ReplaceAll(valor, " ", "")
+
+	negativo := false
+	if strings.HasPrefix(valor, "(") && strings.HasSuffix(valor, ")") {
+		negativo = true
+		valor = strings.Trim(valor, "()")
+	}
+	if strings.HasSuffix(valor, "-") {
+		negativo = true
+		valor = strings.TrimSuffix(valor, "-")
+	}
+	if strings.HasSuffix(strings.ToUpper(valor), "D") {
+		negativo = true
+		valor = valor[:len(valor)-1]
+	} else if strings.HasSuffix(strings.ToUpper(valor), "C") {
+		valor = valor[:len(valor)-1]
+	}
+
+	if separadorDecimal == "," {
+		valor = strings.ReplaceAll(valor, ".", "")
+		valor = strings.ReplaceAll(valor, ",", ".")
+	} else {
+		valor = strings.ReplaceAll(valor, ",", "")
+	}
+
+	numero, err := strconv.ParseFloat(valor, 64)
+	if err != nil || math.IsNaN(numero) || math.IsInf(numero, 0) {
+		return 0, i18n.NovoErro(i18n.ValorInvalidoDetalhe, strings.TrimSpace(original))
+	}
+
+	if negativo {
+		numero = -math.Abs(numero)
+	}
+	return math.Round(numero*100) / 100, nil
+}
+
+// DetectarFormatoData retorna o primeiro formato capaz de interpretar todas
+// as amostras. Formatos brasileiros (dia/mês) têm precedência.
+func DetectarFormatoData(amostras []string) string {
+	for _, formato := range formatosData {
+		valido := false
+		for _, amostra := range amostras {
+			amostra = strings.TrimSpace(amostra)
+			if amostra == "" {
+				continue
+			}
+			if _, err := time.Parse(formato, amostra); err != nil {
+				valido = false
+				break
+			}
+			valido = true
+		}
+		if valido {
+			return formato
+		}
+	}
+	return formatosData[0]
+}
+
+func ParseData(data string, formato string) (time.Time, error) {
+	data = strings.TrimSpace(data)
+	if len(data) > len(formato) && strings.Contains(data, " ") {
+		data = strings.SplitN(data, " ", 2)[0]
+	}
+
+	t, err := time.Parse(formato, data)
+	if err != nil {
+		return time.Time{}, i18n.NovoErro(i18n.DataInvalidaDetalhe, data)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
@@ -0,0 +1,249 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+)
+
+func TestDecodificarTexto(t *testing.T) {
+	casos := []struct {
+		nome      string
+		raw       []byte
+		informado string
+		texto     string
+		encoding  string
+		erro      i18n.Codigo
+	}{
+		{
+			nome:     "utf-8 detectado",
+			raw:      []byte("Histórico;Valor"),
+			texto:    "Histórico;Valor",
+			encoding: EncodingUTF8,
+		},
+		{
+			nome:     "utf-8 com BOM",
+			raw:      []byte("\xef\xbb\xbfData;Valor"),
+			texto:    "Data;Valor",
+			encoding: EncodingUTF8,
+		},
+		{
+			nome:     "windows-1252 detectado",
+			raw:      []byte("Hist\xf3rico;Cart\xe3o \x96 \x80 10"),
+			texto:    "Histórico;Cartão – € 10",
+			encoding: EncodingWindows1252,
+		},
+		{
+			nome:      "windows-1252 informado",
+			raw:       []byte("\x93Padaria\x94;12,50"),
+			informado: "Windows-1252",
+			texto:     "“Padaria”;12,50",
+			encoding:  EncodingWindows1252,
+		},
+		{
+			nome:      "latin-1 informado",
+			raw:       []byte("Jo\xe3o;Ca\xe7a"),
+			informado: "iso-8859-1",
+			texto:     "João;Caça",
+			encoding:  EncodingLatin1,
+		},
+		{
+			nome:      "utf-8 informado com bytes inválidos",
+			raw:       []byte("Hist\xf3rico"),
+			informado: "utf-8",
+			erro:      i18n.ArquivoNaoUTF8,
+		},
+		{
+			nome:      "encoding não suportado",
+			raw:       []byte("Data"),
+			informado: "utf-16",
+			erro:      i18n.EncodingNaoSuportado,
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			texto, encoding, err := DecodificarTexto(caso.raw, caso.informado)
+			if caso.erro != "" {
+				if !i18n.TemCodigo(err, caso.erro) {
+					t.Fatalf("esperava o erro %s, veio %v (%q)", caso.erro, err, texto)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if texto != caso.texto {
+				t.Errorf("texto %q, esperava %q", texto, caso.texto)
+			}
+			if encoding != caso.encoding {
+				t.Errorf("encoding %s, esperava %s", encoding, caso.encoding)
+			}
+		})
+	}
+}
+
+func TestDetectarDelimitador(t *testing.T) {
+	casos := []struct {
+		nome        string
+		texto       string
+		delimitador rune
+	}{
+		{
+			nome:        "ponto e vírgula",
+			texto:       "Data;Histórico;Valor\n01/02/2025;Padaria;-12,50\n02/02/2025;Mercado;-100,00\n",
+			delimitador: ';',
+		},
+		{
+			nome:        "vírgula",
+			texto:       "Date,Description,Amount\n2025-02-01,Bakery,-12.50\n",
+			delimitador: ',',
+		},
+		{
+			nome:        "tabulação",
+			texto:       "Data\tHistórico\tValor\n01/02/2025\tPadaria\t-12,50\n",
+			delimitador: '\t',
+		},
+		{
+			nome:        "barra vertical",
+			texto:       "Data|Histórico|Valor\n01/02/2025|Padaria|-12,50\n",
+			delimitador: '|',
+		},
+		{
+			nome:        "vírgulas decimais entre aspas não contam",
+			texto:       "Data;Valor\n01/02/2025;\"1.234,56\"\n02/02/2025;\"7,00\"\n",
+			delimitador: ';',
+		},
+		{
+			nome:        "linhas em branco são ignoradas",
+			texto:       "Data,Valor\n\n01/02/2025,10\r\n\n",
+			delimitador: ',',
+		},
+		{
+			nome:        "uma coluna usa o padrão",
+			texto:       "Valor\n10\n",
+			delimitador: ';',
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if delimitador := DetectarDelimitador(caso.texto); delimitador != caso.delimitador {
+				t.Errorf("delimitador %q, esperava %q", delimitador, caso.delimitador)
+			}
+		})
+	}
+}
+
+func TestLerCSV(t *testing.T) {
+	linhas, err := LerCSV("Data;Histórico;Valor\n\n01/02/2025; \"Padaria; centro\";\"-1.234,56\"\n;;\n", ';')
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if len(linhas) != 2 {
+		t.Fatalf("esperava 2 linhas, vieram %d: %q", len(linhas), linhas)
+	}
+	if linhas[1][1] != "Padaria; centro" || linhas[1][2] != "-1.234,56" {
+		t.Errorf("linha lida como %q", linhas[1])
+	}
+}
+
+func TestDetectarSeparadorDecimal(t *testing.T) {
+	casos := []struct {
+		nome      string
+		amostras  []string
+		separador string
+	}{
+		{nome: "brasileiro", amostras: []string{"-1.234,56", "10,00", "7,5"}, separador: ","},
+		{nome: "americano", amostras: []string{"-1,234.56", "10.00", "7.5"}, separador: "."},
+		{nome: "empate favorece a vírgula", amostras: []string{"1,50", "1.50"}, separador: ","},
+		{nome: "sem casas decimais", amostras: []string{"10", "1.234"}, separador: ","},
+		{nome: "sem amostras", separador: ","},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if separador := DetectarSeparadorDecimal(caso.amostras); separador != caso.separador {
+				t.Errorf("separador %q, esperava %q", separador, caso.separador)
+			}
+		})
+	}
+}
+
+func TestParseValor(t *testing.T) {
+	casos := []struct {
+		valor     string
+		separador string
+		numero    float64
+		erro      bool
+	}{
+		{valor: "1.234,56", separador: ",", numero: 1234.56},
+		{valor: "-1.234,56", separador: ",", numero: -1234.56},
+		{valor: "R$ -12,50", separador: ",", numero: -12.5},
+		{valor: "R$ 1.000,00", separador: ",", numero: 1000},
+		{valor: "(12,00)", separador: ",", numero: -12},
+		{valor: "12,00-", separador: ",", numero: -12},
+		{valor: "12,00 D", separador: ",", numero: -12},
+		{valor: "12,00 C", separador: ",", numero: 12},
+		{valor: "0,005", separador: ",", numero: 0.01},
+		{valor: "1,234.56", separador: ".", numero: 1234.56},
+		{valor: "-1,234.56", separador: ".", numero: -1234.56},
+		{valor: "(99.90)", separador: ".", numero: -99.9},
+		{valor: "", separador: ",", erro: true},
+		{valor: "abc", separador: ",", erro: true},
+		{valor: "NaN", separador: ".", erro: true},
+		{valor: "Inf", separador: ".", erro: true},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.valor, func(t *testing.T) {
+			numero, err := ParseValor(caso.valor, caso.separador)
+			if caso.erro {
+				if !i18n.TemCodigo(err, i18n.ValorInvalidoDetalhe) {
+					t.Fatalf("esperava o erro %s, veio %v (%v)", i18n.ValorInvalidoDetalhe, err, numero)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if numero != caso.numero {
+				t.Errorf("valor %v, esperava %v", numero, caso.numero)
+			}
+		})
+	}
+}
+
+func TestParseData(t *testing.T) {
+	casos := []struct {
+		amostras []string
+		formato  string
+		data     time.Time
+	}{
+		{amostras: []string{"31/01/2025", "01/02/2025"}, formato: "02/01/2006", data: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{amostras: []string{"2025-01-31", ""}, formato: "2006-01-02", data: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{amostras: []string{"31.01.2025"}, formato: "02.01.2006", data: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{amostras: []string{"31/01/25"}, formato: "02/01/06", data: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.formato, func(t *testing.T) {
+			formato := DetectarFormatoData(caso.amostras)
+			if formato != caso.formato {
+				t.Fatalf("formato %q, esperava %q", formato, caso.formato)
+			}
+			data, err := ParseData(caso.amostras[0]+" 10:30", formato)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if !data.Equal(caso.data) {
+				t.Errorf("data %v, esperava %v", data, caso.data)
+			}
+		})
+	}
+
+	if _, err := ParseData("32/01/2025", "02/01/2006"); !i18n.TemCodigo(err, i18n.DataInvalidaDetalhe) {
+		t.Errorf("esperava o erro %s para uma data inexistente, veio %v", i18n.DataInvalidaDetalhe, err)
+	}
+}
@@ -0,0 +1,131 @@
+package parsers
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+)
+
+const (
+	VersaoOFX1 = "1.x"
+	VersaoOFX2 = "2.x"
+)
+
+var (
+	blocoTransacaoOFX = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+	campoOFX          = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+	dataOFX           = regexp.MustCompile(`^(\d{8})`)
+)
+
+type TransacaoOFX struct {
+	Tipo      string
+	Data      time.Time
+	Valor     float64
+	FITID     string
+	Descricao string
+}
+
+type ExtratoOFX struct {
+	Versao     string
+	BancoID    string
+	ContaID    string
+	Moeda      string
+	Transacoes []TransacaoOFX
+}
+
+func camposOFX(bloco string) map[string]string {
+	campos := make(map[string]string)
+	for _, m := range campoOFX.FindAllStringSubmatch(bloco, -1) {
+		chave := strings.ToUpper(m[1])
+		if _, existe := campos[chave]; !existe {
+			campos[chave] = strings.TrimSpace(m[2])
+		}
+	}
+	return campos
+}
+
+func parseDataOFX(valor string) (time.Time, error) {
+	m := dataOFX.FindStringSubmatch(strings.TrimSpace(valor))
+	if m == nil {
+		return time.Time{}, i18n.NovoErro(i18n.DataInvalidaDetalhe, valor)
+	}
+	t, err := time.Parse("20060102", m[1])
+	if err != nil {
+		return time.Time{}, i18n.NovoErro(i18n.DataInvalidaDetalhe, valor)
+	}
+	return t, nil
+}
+
+// parseValorOFX aceita o ponto decimal da especificação e também o formato
+// brasileiro ("-1.234,56") exportado por alguns bancos: o separador que
+// aparece por último é o decimal, a menos que o ponto se repita ("1.234.567").
+func parseValorOFX(valor string) (float64, error) {
+	valor = strings.TrimSpace(valor)
+	separadorDecimal := "."
+	if strings.LastIndex(valor, ",") > strings.LastIndex(valor, ".") || strings.Count(valor, ".") > 1 {
+		separadorDecimal = ","
+	}
+	return ParseValor(valor, separadorDecimal)
+}
+
+// ParseOFX interpreta extratos OFX 1.x (SGML, sem tags de fechamento nos
+// elementos simples) e 2.x (XML). Os blocos <STMTTRN> são sempre fechados
+// nas duas versões, e os elementos simples são lidos até o próximo "<".
+func ParseOFX(texto string) (*ExtratoOFX, error) {
+	inicio := strings.Index(strings.ToUpper(texto), "<OFX>")
+	if inicio < 0 {
+		return nil, i18n.NovoErro(i18n.OFXInvalido)
+	}
+
+	extrato := &ExtratoOFX{Versao: VersaoOFX1}
+	cabecalho := strings.ToUpper(texto[:inicio])
+	if strings.Contains(cabecalho, "<?XML") || strings.Contains(cabecalho, "<?OFX") {
+		extrato.Versao = VersaoOFX2
+	}
+
+	corpo := texto[inicio:]
+	campos := camposOFX(blocoTransacaoOFX.ReplaceAllString(corpo, ""))
+	extrato.BancoID = campos["BANKID"]
+	extrato.ContaID = campos["ACCTID"]
+	extrato.Moeda = campos["CURDEF"]
+
+	for _, bloco := range blocoTransacaoOFX.FindAllStringSubmatch(corpo, -1) {
+		campos := camposOFX(bloco[1])
+
+		data, err := parseDataOFX(campos["DTPOSTED"])
+		if err != nil {
+			return nil, err
+		}
+
+		valor, err := parseValorOFX(campos["TRNAMT"])
+		if err != nil {
+			return nil, err
+		}
+
+		descricao := campos["NAME"]
+		if memo := campos["MEMO"]; memo != "" && memo != descricao {
+			if descricao == "" {
+				descricao = memo
+			} else {
+				descricao = descricao + " - " + memo
+			}
+		}
+
+		fitid := campos["FITID"]
+		if fitid == "" {
+			return nil, i18n.NovoErro(i18n.OFXSemFitid)
+		}
+
+		extrato.Transacoes = append(extrato.Transacoes, TransacaoOFX{
+			Tipo:      strings.ToUpper(campos["TRNTYPE"]),
+			Data:      data,
+			Valor:     valor,
+			FITID:     fitid,
+			Descricao: descricao,
+		})
+	}
+
+	return extrato, nil
+}
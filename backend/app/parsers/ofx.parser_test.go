@@ -0,0 +1,163 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+)
+
+const extratoOFX1 = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+ENCODING:USASCII
+CHARSET:1252
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>BRL
+<BANKACCTFROM>
+<BANKID>0341
+<ACCTID>12345-6
+</BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250131120000[-3:BRT]
+<TRNAMT>-1.234,56
+<FITID>A1
+<NAME>PADARIA
+<MEMO>CARTAO 1234
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250201
+<TRNAMT>2500.00
+<FITID>A2
+<MEMO>SALARIO
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const extratoOFX2 = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>BRL</CURDEF>
+<BANKACCTFROM><BANKID>260</BANKID><ACCTID>98765</ACCTID></BANKACCTFROM>
+<BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>DEBIT</TRNTYPE>
+<DTPOSTED>20250215000000</DTPOSTED>
+<TRNAMT>-45.9</TRNAMT>
+<FITID>B1</FITID>
+<NAME>MERCADO</NAME>
+<MEMO>MERCADO</MEMO>
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+func TestParseOFX(t *testing.T) {
+	casos := []struct {
+		nome       string
+		texto      string
+		versao     string
+		bancoID    string
+		contaID    string
+		transacoes []TransacaoOFX
+	}{
+		{
+			nome:    "1.x em SGML",
+			texto:   extratoOFX1,
+			versao:  VersaoOFX1,
+			bancoID: "0341",
+			contaID: "12345-6",
+			transacoes: []TransacaoOFX{
+				{Tipo: "DEBIT", Data: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), Valor: -1234.56, FITID: "A1", Descricao: "PADARIA - CARTAO 1234"},
+				{Tipo: "CREDIT", Data: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Valor: 2500, FITID: "A2", Descricao: "SALARIO"},
+			},
+		},
+		{
+			nome:    "2.x em XML",
+			texto:   extratoOFX2,
+			versao:  VersaoOFX2,
+			bancoID: "260",
+			contaID: "98765",
+			transacoes: []TransacaoOFX{
+				{Tipo: "DEBIT", Data: time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC), Valor: -45.9, FITID: "B1", Descricao: "MERCADO"},
+			},
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			extrato, err := ParseOFX(caso.texto)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if extrato.Versao != caso.versao || extrato.BancoID != caso.bancoID || extrato.ContaID != caso.contaID || extrato.Moeda != "BRL" {
+				t.Errorf("cabeçalho %+v", extrato)
+			}
+			if len(extrato.Transacoes) != len(caso.transacoes) {
+				t.Fatalf("esperava %d transações, vieram %d", len(caso.transacoes), len(extrato.Transacoes))
+			}
+			for i, transacao := range extrato.Transacoes {
+				if transacao != caso.transacoes[i] {
+					t.Errorf("transação %d: %+v, esperava %+v", i, transacao, caso.transacoes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseOFXInvalido(t *testing.T) {
+	casos := []struct {
+		nome  string
+		texto string
+		erro  i18n.Codigo
+	}{
+		{nome: "sem a tag OFX", texto: "OFXHEADER:100\n<STMTTRN><FITID>1</STMTTRN>", erro: i18n.OFXInvalido},
+		{nome: "sem FITID", texto: "<OFX><STMTTRN><DTPOSTED>20250101<TRNAMT>-1.00</STMTTRN></OFX>", erro: i18n.OFXSemFitid},
+		{nome: "data inválida", texto: "<OFX><STMTTRN><DTPOSTED>2025-01-01<TRNAMT>-1.00<FITID>1</STMTTRN></OFX>", erro: i18n.DataInvalidaDetalhe},
+		{nome: "valor inválido", texto: "<OFX><STMTTRN><DTPOSTED>20250101<TRNAMT>abc<FITID>1</STMTTRN></OFX>", erro: i18n.ValorInvalidoDetalhe},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if _, err := ParseOFX(caso.texto); !i18n.TemCodigo(err, caso.erro) {
+				t.Errorf("esperava o erro %s, veio %v", caso.erro, err)
+			}
+		})
+	}
+}
+
+func TestParseValorOFX(t *testing.T) {
+	casos := []struct {
+		valor  string
+		numero float64
+	}{
+		{valor: "-45.90", numero: -45.9},
+		{valor: "1234.5", numero: 1234.5},
+		{valor: "-1,234.56", numero: -1234.56},
+		{valor: "-1.234,56", numero: -1234.56},
+		{valor: "12,5", numero: 12.5},
+		{valor: "1.234.567", numero: 1234567},
+		{valor: " +10.00 ", numero: 10},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.valor, func(t *testing.T) {
+			numero, err := parseValorOFX(caso.valor)
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if numero != caso.numero {
+				t.Errorf("valor %v, esperava %v", numero, caso.numero)
+			}
+		})
+	}
+}
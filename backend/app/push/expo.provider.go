@@ -0,0 +1,142 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	expoPushURL      = "https://exp.host/--/api/v2/push/send"
+	maxMensagensExpo = 100
+)
+
+type ExpoProvider struct {
+	url         string
+	accessToken string
+	client      *http.Client
+}
+
+func NewExpoProvider(accessToken string) *ExpoProvider {
+	return &ExpoProvider{
+		url:         expoPushURL,
+		accessToken: accessToken,
+		client:      &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type expoMensagem struct {
+	To    string                 `json:"to"`
+	Title string                 `json:"title"`
+	Body  string                 `json:"body"`
+	Data  map[string]interface{} `json:"data,omitempty"`
+	Sound string                 `json:"sound"`
+}
+
+type expoTicket struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Details struct {
+		Error string `json:"error"`
+	} `json:"details"`
+}
+
+type expoResposta struct {
+	Data   []expoTicket `json:"data"`
+	Errors []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (p *ExpoProvider) Enviar(ctx context.Context, mensagens []Mensagem) ([]Resultado, error) {
+	resultados := make([]Resultado, 0, len(mensagens))
+
+	// A API do Expo aceita no máximo 100 mensagens por requisição
+	for inicio := 0; inicio < len(mensagens); inicio += maxMensagensExpo {
+		fim := inicio + maxMensagensExpo
+		if fim > len(mensagens) {
+			fim = len(mensagens)
+		}
+
+		parcial, err := p.enviarLote(ctx, mensagens[inicio:fim])
+		if err != nil {
+			return nil, err
+		}
+		resultados = append(resultados, parcial...)
+	}
+
+	return resultados, nil
+}
+
+func (p *ExpoProvider) enviarLote(ctx context.Context, mensagens []Mensagem) ([]Resultado, error) {
+	corpo := make([]expoMensagem, len(mensagens))
+	for i, mensagem := range mensagens {
+		corpo[i] = expoMensagem{
+			To:    mensagem.Token,
+			Title: mensagem.Titulo,
+			Body:  mensagem.Corpo,
+			Data:  mensagem.Dados,
+			Sound: "default",
+		}
+	}
+
+	payload, err := json.Marshal(corpo)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if p.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+p.accessToken)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("expo respondeu com status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var resposta expoResposta
+	if err := json.Unmarshal(body, &resposta); err != nil {
+		return nil, fmt.Errorf("resposta inválida do expo: %w", err)
+	}
+	if len(resposta.Errors) > 0 {
+		return nil, fmt.Errorf("expo recusou o envio: %s", resposta.Errors[0].Message)
+	}
+	if len(resposta.Data) != len(mensagens) {
+		return nil, fmt.Errorf("expo retornou %d tickets para %d mensagens", len(resposta.Data), len(mensagens))
+	}
+
+	resultados := make([]Resultado, len(mensagens))
+	for i, ticket := range resposta.Data {
+		resultados[i] = Resultado{
+			Token:         mensagens[i].Token,
+			Sucesso:       ticket.Status == "ok",
+			TokenInvalido: ticket.Details.Error == "DeviceNotRegistered",
+		}
+		if !resultados[i].Sucesso {
+			resultados[i].Erro = ticket.Message
+		}
+	}
+
+	return resultados, nil
+}
@@ -0,0 +1,47 @@
+package push
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeProvider guarda as mensagens em memória em vez de enviá-las. Serve
+// para desenvolvimento local e testes.
+type FakeProvider struct {
+	mu        sync.Mutex
+	enviadas  []Mensagem
+	Falha     error
+	Invalidos map[string]bool
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{Invalidos: make(map[string]bool)}
+}
+
+func (p *FakeProvider) Enviar(ctx context.Context, mensagens []Mensagem) ([]Resultado, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Falha != nil {
+		return nil, p.Falha
+	}
+
+	resultados := make([]Resultado, len(mensagens))
+	for i, mensagem := range mensagens {
+		if p.Invalidos[mensagem.Token] {
+			resultados[i] = Resultado{Token: mensagem.Token, Erro: "dispositivo não registrado", TokenInvalido: true}
+			continue
+		}
+		p.enviadas = append(p.enviadas, mensagem)
+		resultados[i] = Resultado{Token: mensagem.Token, Sucesso: true}
+	}
+
+	return resultados, nil
+}
+
+func (p *FakeProvider) Enviadas() []Mensagem {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]Mensagem(nil), p.enviadas...)
+}
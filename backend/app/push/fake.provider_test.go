@@ -0,0 +1,55 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFakeProvider(t *testing.T) {
+	mensagens := []Mensagem{
+		{Token: "a", Titulo: "Limite", Corpo: "80% do limite"},
+		{Token: "b", Titulo: "Limite", Corpo: "80% do limite"},
+	}
+
+	casos := []struct {
+		nome      string
+		falha     error
+		invalidos []string
+		sucessos  []bool
+		enviadas  int
+	}{
+		{nome: "entrega para todos", sucessos: []bool{true, true}, enviadas: 2},
+		{nome: "token inválido", invalidos: []string{"b"}, sucessos: []bool{true, false}, enviadas: 1},
+		{nome: "falha de todo o envio", falha: errors.New("sem conexão")},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			provedor := NewFakeProvider()
+			provedor.Falha = caso.falha
+			for _, token := range caso.invalidos {
+				provedor.Invalidos[token] = true
+			}
+
+			resultados, err := provedor.Enviar(context.Background(), mensagens)
+			if caso.falha != nil {
+				if !errors.Is(err, caso.falha) || resultados != nil {
+					t.Fatalf("esperava só o erro %v, veio %v com %+v", caso.falha, err, resultados)
+				}
+			} else if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+
+			for i, sucesso := range caso.sucessos {
+				resultado := resultados[i]
+				if resultado.Token != mensagens[i].Token || resultado.Sucesso != sucesso || resultado.TokenInvalido == sucesso {
+					t.Errorf("resultado %d: %+v", i, resultado)
+				}
+			}
+			if enviadas := provedor.Enviadas(); len(enviadas) != caso.enviadas {
+				t.Errorf("%d mensagens guardadas, esperava %d", len(enviadas), caso.enviadas)
+			}
+		})
+	}
+}
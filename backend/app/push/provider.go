@@ -0,0 +1,28 @@
+package push
+
+import "context"
+
+// Mensagem é uma notificação destinada a um único dispositivo.
+type Mensagem struct {
+	Token  string
+	Titulo string
+	Corpo  string
+	Dados  map[string]interface{}
+}
+
+// Resultado informa o que aconteceu com cada mensagem, na mesma ordem do envio.
+// TokenInvalido indica que o dispositivo não existe mais e deve ser desativado.
+type Resultado struct {
+	Token         string
+	Sucesso       bool
+	Erro          string
+	TokenInvalido bool
+}
+
+// PushProvider entrega mensagens a um serviço de push. O erro retornado
+// representa uma falha de todo o envio (rede, autenticação) e faz a
+// mensagem ser reenviada mais tarde; falhas por dispositivo vêm nos
+// resultados.
+type PushProvider interface {
+	Enviar(ctx context.Context, mensagens []Mensagem) ([]Resultado, error)
+}
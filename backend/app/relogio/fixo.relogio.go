@@ -0,0 +1,35 @@
+package relogio
+
+import (
+	"sync"
+	"time"
+)
+
+// FixoRelogio sempre retorna o mesmo instante até ser ajustado. Serve para
+// simular viradas de mês e de fuso em desenvolvimento local e testes.
+type FixoRelogio struct {
+	mu    sync.Mutex
+	agora time.Time
+}
+
+func NewFixoRelogio(agora time.Time) *FixoRelogio {
+	return &FixoRelogio{agora: agora.UTC()}
+}
+
+func (r *FixoRelogio) Agora() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.agora
+}
+
+func (r *FixoRelogio) Definir(agora time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.agora = agora.UTC()
+}
+
+func (r *FixoRelogio) Avancar(duracao time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.agora = r.agora.Add(duracao)
+}
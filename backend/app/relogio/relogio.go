@@ -0,0 +1,10 @@
+package relogio
+
+import "time"
+
+// Relogio informa o instante atual. Os serviços o recebem no construtor em
+// vez de chamar time.Now diretamente, para que datas como o "mês atual"
+// possam ser fixadas em desenvolvimento e testes.
+type Relogio interface {
+	Agora() time.Time
+}
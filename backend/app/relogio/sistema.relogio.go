@@ -0,0 +1,14 @@
+package relogio
+
+import "time"
+
+// SistemaRelogio usa o relógio do sistema operacional.
+type SistemaRelogio struct{}
+
+func NewSistemaRelogio() *SistemaRelogio {
+	return &SistemaRelogio{}
+}
+
+func (r *SistemaRelogio) Agora() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupAlertaRoutes(app *fiber.App, alertaController *controllers.AlertaController) {
+	alertaRoutes := app.Group("/api/alertas")
+
+	alertaRoutes.Use(middleware.AuthMiddleware())
+
+	alertaRoutes.Get("/", alertaController.GetConfiguracoes)
+	alertaRoutes.Put("/", alertaController.SalvarConfiguracao)
+	alertaRoutes.Delete("/:id", alertaController.DeleteConfiguracao)
+}
@@ -14,6 +14,9 @@ func SetupDespesaRoutes(app *fiber.App, despesaController *controllers.DespesaCo
 	despesaRoutes.Post("/despesa", despesaController.CreateDespesa)
 	despesaRoutes.Get("/despesa/mes/:mesReferencia", despesaController.GetDespesasByMonth)
 	despesaRoutes.Get("/despesas", despesaController.GetDespesasByUser)
+	despesaRoutes.Post("/despesas/batch", despesaController.BatchDespesas)
+	despesaRoutes.Get("/despesas/duplicatas", despesaController.GetDuplicatas)
+	despesaRoutes.Post("/despesas/duplicatas/mesclar", despesaController.MesclarDuplicatas)
 	despesaRoutes.Put("/despesa/:id", despesaController.UpdateDespesa)
 	despesaRoutes.Delete("/despesa/:id", despesaController.DeleteDespesa)
 } 
\ No newline at end of file
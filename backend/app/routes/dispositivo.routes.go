@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupDispositivoRoutes(app *fiber.App, dispositivoController *controllers.DispositivoController) {
+	dispositivoRoutes := app.Group("/api/dispositivos")
+
+	dispositivoRoutes.Use(middleware.AuthMiddleware())
+
+	dispositivoRoutes.Post("/", dispositivoController.RegistrarDispositivo)
+	dispositivoRoutes.Get("/", dispositivoController.GetDispositivosByUser)
+	dispositivoRoutes.Delete("/:id", dispositivoController.DeleteDispositivo)
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupDividaRoutes(app *fiber.App, dividaController *controllers.DividaController) {
+	dividaRoutes := app.Group("/api/dividas")
+
+	dividaRoutes.Use(middleware.AuthMiddleware())
+
+	dividaRoutes.Post("/", dividaController.CreateDivida)
+	dividaRoutes.Get("/", dividaController.GetDividasByUser)
+	dividaRoutes.Get("/:id", dividaController.GetDividaByID)
+	dividaRoutes.Put("/:id", dividaController.UpdateDivida)
+	dividaRoutes.Delete("/:id", dividaController.DeleteDivida)
+	dividaRoutes.Get("/:id/cronograma", dividaController.GetCronograma)
+	dividaRoutes.Get("/:id/saldo", dividaController.GetEvolucaoSaldo)
+	dividaRoutes.Get("/:id/projecao", dividaController.GetProjecaoQuitacao)
+	dividaRoutes.Post("/:id/pagamentos", dividaController.RegistrarPagamento)
+	dividaRoutes.Delete("/:id/pagamentos/:pagamentoId", dividaController.DeletePagamento)
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupDivisaoRoutes(app *fiber.App, divisaoController *controllers.DivisaoController) {
+	grupoRoutes := app.Group("/api/grupos")
+
+	grupoRoutes.Use(middleware.AuthMiddleware())
+
+	grupoRoutes.Post("/", divisaoController.CreateGrupo)
+	grupoRoutes.Get("/", divisaoController.GetGruposByUser)
+	grupoRoutes.Get("/:id", divisaoController.GetGrupoByID)
+	grupoRoutes.Put("/:id", divisaoController.UpdateGrupo)
+	grupoRoutes.Delete("/:id", divisaoController.DeleteGrupo)
+	grupoRoutes.Post("/:id/membros", divisaoController.AddMembro)
+	grupoRoutes.Delete("/:id/membros/:userId", divisaoController.RemoverMembro)
+	grupoRoutes.Post("/:id/divisoes", divisaoController.CreateDivisao)
+	grupoRoutes.Get("/:id/divisoes", divisaoController.GetDivisoes)
+	grupoRoutes.Delete("/:id/divisoes/:divisaoId", divisaoController.DeleteDivisao)
+	grupoRoutes.Get("/:id/saldos", divisaoController.GetSaldos)
+	grupoRoutes.Post("/:id/acertos", divisaoController.RegistrarAcerto)
+	grupoRoutes.Get("/:id/acertos", divisaoController.GetAcertos)
+	grupoRoutes.Delete("/:id/acertos/:acertoId", divisaoController.DeleteAcerto)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupEnvelopeRoutes(app *fiber.App, envelopeController *controllers.EnvelopeController) {
+	envelopeRoutes := app.Group("/api/envelopes")
+
+	envelopeRoutes.Use(middleware.AuthMiddleware())
+
+	envelopeRoutes.Post("/", envelopeController.CreateEnvelope)
+	envelopeRoutes.Get("/", envelopeController.GetEnvelopesByUser)
+	envelopeRoutes.Get("/:id", envelopeController.GetEnvelopeByID)
+	envelopeRoutes.Put("/:id", envelopeController.UpdateEnvelope)
+	envelopeRoutes.Delete("/:id", envelopeController.DeleteEnvelope)
+	envelopeRoutes.Post("/:id/aportes", envelopeController.RegistrarAporte)
+	envelopeRoutes.Post("/:id/gastos", envelopeController.RegistrarGasto)
+	envelopeRoutes.Delete("/:id/movimentos/:movimentoId", envelopeController.DeleteMovimento)
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupFamiliaRoutes(app *fiber.App, familiaController *controllers.FamiliaController) {
+	familiaRoutes := app.Group("/api/familia")
+
+	familiaRoutes.Use(middleware.AuthMiddleware())
+
+	familiaRoutes.Post("/", familiaController.CreateFamilia)
+	familiaRoutes.Get("/", familiaController.GetFamilia)
+	familiaRoutes.Put("/", familiaController.UpdateFamilia)
+	familiaRoutes.Post("/sair", familiaController.SairDaFamilia)
+	familiaRoutes.Put("/membros/:userId", familiaController.AlterarPapel)
+	familiaRoutes.Delete("/membros/:userId", familiaController.RemoverMembro)
+	familiaRoutes.Post("/convites", familiaController.ConvidarMembro)
+	familiaRoutes.Get("/convites", familiaController.GetConvitesDaFamilia)
+	familiaRoutes.Delete("/convites/:id", familiaController.RevogarConvite)
+	familiaRoutes.Get("/resumo/mes/:mesReferencia", familiaController.GetResumoFamilia)
+
+	conviteRoutes := app.Group("/api/convites")
+
+	conviteRoutes.Use(middleware.AuthMiddleware())
+
+	conviteRoutes.Get("/", familiaController.GetMeusConvites)
+	conviteRoutes.Post("/:id/aceitar", familiaController.AceitarConvite)
+	conviteRoutes.Post("/:id/recusar", familiaController.RecusarConvite)
+}
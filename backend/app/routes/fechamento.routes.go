@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupFechamentoRoutes(app *fiber.App, fechamentoController *controllers.FechamentoController) {
+	fechamentoRoutes := app.Group("/api/fechamentos")
+
+	fechamentoRoutes.Use(middleware.AuthMiddleware())
+
+	fechamentoRoutes.Get("/configuracao", fechamentoController.GetConfiguracao)
+	fechamentoRoutes.Put("/configuracao", fechamentoController.SalvarConfiguracao)
+	fechamentoRoutes.Get("/", fechamentoController.GetFechamentos)
+	fechamentoRoutes.Get("/:mesReferencia", fechamentoController.GetFechamento)
+	fechamentoRoutes.Post("/:mesReferencia/fechar", fechamentoController.FecharMes)
+	fechamentoRoutes.Post("/:mesReferencia/reabrir", fechamentoController.ReabrirMes)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupImportacaoRoutes(app *fiber.App, importacaoController *controllers.ImportacaoController) {
+	importacaoRoutes := app.Group("/api/importacoes")
+
+	importacaoRoutes.Use(middleware.AuthMiddleware())
+
+	importacaoRoutes.Post("/csv/preview", importacaoController.PreviewCSV)
+	importacaoRoutes.Post("/csv", importacaoController.ImportarCSV)
+	importacaoRoutes.Post("/ofx/preview", importacaoController.PreviewOFX)
+	importacaoRoutes.Post("/ofx", importacaoController.ImportarOFX)
+	importacaoRoutes.Get("/", importacaoController.GetImportacoesByUser)
+	importacaoRoutes.Delete("/:id", importacaoController.DesfazerImportacao)
+}
@@ -12,8 +12,11 @@ func SetupLimiteRoutes(app *fiber.App, limiteController *controllers.LimiteContr
 	limiteRoutes.Use(middleware.AuthMiddleware())
 
 	limiteRoutes.Post("/limite", limiteController.CreateLimite)
+	limiteRoutes.Get("/limite/configuracao", limiteController.GetConfiguracao)
+	limiteRoutes.Put("/limite/configuracao", limiteController.SalvarConfiguracao)
 	limiteRoutes.Get("/limite/mes/:mesReferencia", limiteController.GetLimiteByMonth)
 	limiteRoutes.Get("/limites", limiteController.GetLimitesByUser)
+	limiteRoutes.Get("/limites/mes/:mesReferencia", limiteController.GetLimitesDoMes)
 	limiteRoutes.Put("/limite/:id", limiteController.UpdateLimite)
 	limiteRoutes.Delete("/limite/:id", limiteController.DeleteLimite)
 } 
\ No newline at end of file
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupMetaRoutes(app *fiber.App, metaController *controllers.MetaController) {
+	metaRoutes := app.Group("/api/metas")
+
+	metaRoutes.Use(middleware.AuthMiddleware())
+
+	metaRoutes.Post("/", metaController.CreateMeta)
+	metaRoutes.Get("/", metaController.GetMetasByUser)
+	metaRoutes.Get("/:id", metaController.GetMetaByID)
+	metaRoutes.Put("/:id", metaController.UpdateMeta)
+	metaRoutes.Delete("/:id", metaController.DeleteMeta)
+	metaRoutes.Post("/:id/aportes", metaController.RegistrarAporte)
+	metaRoutes.Delete("/:id/aportes/:aporteId", metaController.DeleteAporte)
+}
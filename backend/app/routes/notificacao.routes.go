@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupNotificacaoRoutes(app *fiber.App, notificacaoController *controllers.NotificacaoController) {
+	notificacaoRoutes := app.Group("/api/notificacoes")
+
+	notificacaoRoutes.Use(middleware.AuthMiddleware())
+
+	notificacaoRoutes.Get("/", notificacaoController.GetNotificacoes)
+	notificacaoRoutes.Put("/lidas", notificacaoController.MarcarTodasComoLidas)
+	notificacaoRoutes.Put("/:id/lida", notificacaoController.MarcarLida)
+	notificacaoRoutes.Put("/:id/nao-lida", notificacaoController.MarcarNaoLida)
+	notificacaoRoutes.Delete("/:id", notificacaoController.DeleteNotificacao)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupPeriodoRoutes(app *fiber.App, periodoController *controllers.PeriodoController) {
+	periodoRoutes := app.Group("/api/periodos")
+
+	periodoRoutes.Use(middleware.AuthMiddleware())
+
+	periodoRoutes.Get("/configuracao", periodoController.GetConfiguracao)
+	periodoRoutes.Put("/configuracao", periodoController.SalvarConfiguracao)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupPreferenciaRoutes(app *fiber.App, preferenciaController *controllers.PreferenciaController) {
+	preferenciaRoutes := app.Group("/api/preferencias")
+
+	preferenciaRoutes.Use(middleware.AuthMiddleware())
+
+	preferenciaRoutes.Get("/", preferenciaController.GetPreferencias)
+	preferenciaRoutes.Patch("/", preferenciaController.AtualizarPreferencias)
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupPrevisaoRoutes(app *fiber.App, previsaoController *controllers.PrevisaoController) {
+	previsaoRoutes := app.Group("/api")
+
+	previsaoRoutes.Use(middleware.AuthMiddleware())
+
+	previsaoRoutes.Get("/previsao/mes/:mesReferencia", previsaoController.GetPrevisaoDoMes)
+}
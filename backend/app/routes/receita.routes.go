@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupReceitaRoutes(app *fiber.App, receitaController *controllers.ReceitaController) {
+	receitaRoutes := app.Group("/api")
+
+	receitaRoutes.Use(middleware.AuthMiddleware())
+
+	receitaRoutes.Get("/receita/mes/:mesReferencia", receitaController.GetReceitasByMonth)
+	receitaRoutes.Get("/receitas", receitaController.GetReceitasByUser)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRegraRoutes(app *fiber.App, regraController *controllers.RegraController) {
+	regraRoutes := app.Group("/api/regras")
+
+	regraRoutes.Use(middleware.AuthMiddleware())
+
+	regraRoutes.Post("/", regraController.CreateRegra)
+	regraRoutes.Get("/", regraController.GetRegrasByUser)
+	regraRoutes.Put("/ordem", regraController.OrdenarRegras)
+	regraRoutes.Post("/testar", regraController.TestarRegra)
+	regraRoutes.Post("/aplicar", regraController.AplicarRegras)
+	regraRoutes.Put("/:id", regraController.UpdateRegra)
+	regraRoutes.Delete("/:id", regraController.DeleteRegra)
+	regraRoutes.Post("/:id/testar", regraController.TestarRegraExistente)
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupRelatorioRoutes(app *fiber.App, relatorioController *controllers.RelatorioController) {
+	relatorioRoutes := app.Group("/api/relatorios")
+
+	relatorioRoutes.Use(middleware.AuthMiddleware())
+
+	relatorioRoutes.Get("/ano/:ano", relatorioController.GetRelatorioAnual)
+	relatorioRoutes.Get("/periodo", relatorioController.GetRelatorioPeriodo)
+}
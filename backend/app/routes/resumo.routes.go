@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupResumoRoutes(app *fiber.App, resumoController *controllers.ResumoController) {
+	resumoRoutes := app.Group("/api")
+
+	resumoRoutes.Use(middleware.AuthMiddleware())
+
+	resumoRoutes.Get("/resumo/mes/:mesReferencia", resumoController.GetResumoDoMes)
+	resumoRoutes.Get("/resumo/periodo", resumoController.GetResumoPeriodo)
+	resumoRoutes.Get("/resumo/periodos", resumoController.GetHistoricoPeriodos)
+}
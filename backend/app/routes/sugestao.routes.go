@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupSugestaoRoutes(app *fiber.App, sugestaoController *controllers.SugestaoController) {
+	sugestaoRoutes := app.Group("/api")
+
+	sugestaoRoutes.Use(middleware.AuthMiddleware())
+
+	sugestaoRoutes.Get("/limites/sugestao/:mesReferencia", sugestaoController.GetSugestoes)
+	sugestaoRoutes.Post("/limites/sugestao/:mesReferencia/aplicar", sugestaoController.AplicarSugestoes)
+}
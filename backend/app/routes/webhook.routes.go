@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+func SetupWebhookRoutes(app *fiber.App, webhookController *controllers.WebhookController) {
+	webhookRoutes := app.Group("/api/webhooks")
+
+	webhookRoutes.Use(middleware.AuthMiddleware())
+
+	webhookRoutes.Post("/", webhookController.CreateWebhook)
+	webhookRoutes.Get("/", webhookController.GetWebhooksByUser)
+	webhookRoutes.Put("/:id", webhookController.UpdateWebhook)
+	webhookRoutes.Delete("/:id", webhookController.DeleteWebhook)
+	webhookRoutes.Post("/:id/teste", webhookController.EnviarTeste)
+	webhookRoutes.Get("/:id/entregas", webhookController.GetEntregas)
+}
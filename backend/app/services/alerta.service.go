@@ -0,0 +1,343 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const (
+	tipoNotificacaoLimite   = "limite"
+	tipoNotificacaoPrevisao = "previsao"
+	maxPercentuaisAlerta    = 10
+)
+
+var percentuaisAlertaPadrao = []int{50, 80, 100}
+
+type AlertaService struct {
+	alertaDAL          *dal.AlertaDAL
+	notificacaoDAL     *dal.NotificacaoDAL
+	limiteService      *LimiteService
+	webhookService     *WebhookService
+	previsaoService    *PrevisaoService
+	preferenciaService *PreferenciaService
+}
+
+func NewAlertaService(alertaDAL *dal.AlertaDAL, notificacaoDAL *dal.NotificacaoDAL, limiteService *LimiteService, webhookService *WebhookService, previsaoService *PrevisaoService, preferenciaService *PreferenciaService) *AlertaService {
+	return &AlertaService{
+		alertaDAL:          alertaDAL,
+		notificacaoDAL:     notificacaoDAL,
+		limiteService:      limiteService,
+		webhookService:     webhookService,
+		previsaoService:    previsaoService,
+		preferenciaService: preferenciaService,
+	}
+}
+
+func juntarPercentuais(percentuais []int) string {
+	partes := make([]string, len(percentuais))
+	for i, percentual := range percentuais {
+		partes[i] = strconv.Itoa(percentual)
+	}
+	return strings.Join(partes, ",")
+}
+
+func separarPercentuais(texto string) []int {
+	percentuais := []int{}
+	for _, parte := range strings.Split(texto, ",") {
+		if percentual, err := strconv.Atoi(strings.TrimSpace(parte)); err == nil {
+			percentuais = append(percentuais, percentual)
+		}
+	}
+	return percentuais
+}
+
+// normalizarPercentuais ordena e remove repetidos. Uma lista vazia desativa
+// os alertas da categoria.
+func normalizarPercentuais(percentuais []int) ([]int, error) {
+	if len(percentuais) > maxPercentuaisAlerta {
+		return nil, i18n.NovoErro(i18n.AlertaPercentuaisExcesso, maxPercentuaisAlerta)
+	}
+
+	vistos := make(map[int]bool)
+	resultado := []int{}
+	for _, percentual := range percentuais {
+		if percentual <= 0 || percentual > 1000 {
+			return nil, i18n.NovoErro(i18n.AlertaPercentuaisInvalidos)
+		}
+		if !vistos[percentual] {
+			vistos[percentual] = true
+			resultado = append(resultado, percentual)
+		}
+	}
+	sort.Ints(resultado)
+	return resultado, nil
+}
+
+func toConfiguracaoAlertaResponse(configuracao *types.ConfiguracaoAlerta) *types.ConfiguracaoAlertaResponse {
+	return &types.ConfiguracaoAlertaResponse{
+		ID:          configuracao.ID,
+		Categoria:   configuracao.Categoria,
+		Percentuais: separarPercentuais(configuracao.Percentuais),
+	}
+}
+
+func (s *AlertaService) GetConfiguracoes(userID uint) ([]types.ConfiguracaoAlertaResponse, error) {
+	configuracoes, err := s.alertaDAL.GetConfiguracoesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := []types.ConfiguracaoAlertaResponse{}
+	possuiGeral := false
+	for i := range configuracoes {
+		if configuracoes[i].Categoria == "" {
+			possuiGeral = true
+		}
+		response = append(response, *toConfiguracaoAlertaResponse(&configuracoes[i]))
+	}
+
+	if !possuiGeral {
+		geral := types.ConfiguracaoAlertaResponse{Percentuais: percentuaisAlertaPadrao, Padrao: true}
+		response = append([]types.ConfiguracaoAlertaResponse{geral}, response...)
+	}
+
+	return response, nil
+}
+
+func (s *AlertaService) SalvarConfiguracao(userID uint, req *types.ConfiguracaoAlertaRequest) (*types.ConfiguracaoAlertaResponse, error) {
+	percentuais, err := normalizarPercentuais(req.Percentuais)
+	if err != nil {
+		return nil, err
+	}
+
+	categoria := strings.TrimSpace(req.Categoria)
+
+	configuracao, err := s.alertaDAL.GetConfiguracaoByCategoria(userID, categoria)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		configuracao = &types.ConfiguracaoAlerta{UserID: userID}
+	}
+
+	configuracao.Categoria = categoria
+	configuracao.Percentuais = juntarPercentuais(percentuais)
+
+	if err := s.alertaDAL.SaveConfiguracao(configuracao); err != nil {
+		return nil, err
+	}
+
+	return toConfiguracaoAlertaResponse(configuracao), nil
+}
+
+func (s *AlertaService) DeleteConfiguracao(userID uint, configuracaoID uint) error {
+	if _, err := s.alertaDAL.GetConfiguracaoByID(configuracaoID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return i18n.NovoErro(i18n.AlertaNaoEncontrado)
+		}
+		return err
+	}
+
+	return s.alertaDAL.DeleteConfiguracao(configuracaoID, userID)
+}
+
+func (s *AlertaService) percentuaisPorCategoria(userID uint) (map[string][]int, []int, error) {
+	configuracoes, err := s.alertaDAL.GetConfiguracoesByUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	porCategoria := make(map[string][]int)
+	padrao := percentuaisAlertaPadrao
+	for _, configuracao := range configuracoes {
+		if configuracao.Categoria == "" {
+			padrao = separarPercentuais(configuracao.Percentuais)
+			continue
+		}
+		porCategoria[strings.ToLower(configuracao.Categoria)] = separarPercentuais(configuracao.Percentuais)
+	}
+
+	return porCategoria, padrao, nil
+}
+
+// atingiuPercentual compara o gasto com o percentual do limite em centavos,
+// sem passar pelo PercentualUsado arredondado: 99,996% não conta como 100%.
+func atingiuPercentual(limite *types.LimiteConsumoResponse, percentual int) bool {
+	gasto := int64(math.Round(limite.Gasto * 100))
+	valor := int64(math.Round(limite.ValorEfetivo * 100))
+	return gasto*100 >= valor*int64(percentual)
+}
+
+func novaNotificacaoLimite(userID uint, mesReferencia string, limite *types.LimiteConsumoResponse, percentual int, preferencia *types.Preferencia) (*types.Notificacao, error) {
+	mes, err := parseMonthYear(mesReferencia)
+	if err != nil {
+		return nil, err
+	}
+
+	idioma := preferencia.Idioma
+	nomeLimite := i18n.Traduzir(idioma, i18n.NotificacaoNomeLimiteGeral)
+	if limite.Categoria != "" {
+		nomeLimite = i18n.Traduzir(idioma, i18n.NotificacaoNomeLimiteCategoria, limite.Categoria)
+	}
+
+	titulo := i18n.Traduzir(idioma, i18n.NotificacaoLimiteAtingido, percentual, nomeLimite)
+	if percentual == 100 {
+		titulo = i18n.Traduzir(idioma, i18n.NotificacaoLimiteEstourado, nomeLimite)
+	} else if percentual > 100 {
+		titulo = i18n.Traduzir(idioma, i18n.NotificacaoLimiteUltrapassado, percentual, nomeLimite)
+	}
+
+	return &types.Notificacao{
+		UserID:        userID,
+		Chave:         fmt.Sprintf("%s|%s|%s|%d", tipoNotificacaoLimite, strings.ToLower(limite.Categoria), mesReferencia, percentual),
+		Tipo:          tipoNotificacaoLimite,
+		Titulo:        titulo,
+		Mensagem:      i18n.Traduzir(idioma, i18n.NotificacaoLimiteMensagem, formatarMoeda(limite.Gasto, preferencia), formatarMoeda(limite.ValorEfetivo, preferencia), mesReferencia, limite.PercentualUsado),
+		Categoria:     limite.Categoria,
+		MesReferencia: &mes,
+		Percentual:    percentual,
+	}, nil
+}
+
+// AvaliarLimites gera as notificações dos percentuais já atingidos no mês
+// para cada membro da família, com os percentuais e as preferências de cada
+// um. Cada percentual gera no máximo uma notificação por membro, limite e
+// mês, mesmo que o gasto caia e volte a subir.
+func (s *AlertaService) AvaliarLimites(userID uint, mesReferencia string) (int, error) {
+	limites, err := s.limiteService.GetLimitesDoMes(userID, mesReferencia)
+	if err != nil {
+		return 0, err
+	}
+
+	consumos := limites.Categorias
+	if limites.Geral != nil {
+		consumos = append([]types.LimiteConsumoResponse{*limites.Geral}, consumos...)
+	}
+
+	// O evento de limite excedido independe dos percentuais configurados e
+	// é publicado uma única vez por limite e mês
+	for i := range consumos {
+		limite := &consumos[i]
+		if limite.ValorEfetivo > 0 && atingiuPercentual(limite, 100) {
+			eventoID := fmt.Sprintf("%s:%s:%s", eventoLimiteExcedido, strings.ToLower(limite.Categoria), limites.MesReferencia)
+			s.webhookService.publicarEvento(userID, eventoLimiteExcedido, eventoID, map[string]interface{}{
+				"mesReferencia": limites.MesReferencia,
+				"limite":        limite,
+			})
+		}
+	}
+
+	destinatarios, err := s.notificacaoDAL.GetDestinatarios(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	criadas := 0
+	for _, destinatarioID := range destinatarios {
+		criadasMembro, err := s.notificarLimites(destinatarioID, limites, consumos)
+		criadas += criadasMembro
+		if err != nil {
+			return criadas, err
+		}
+	}
+
+	return criadas, nil
+}
+
+func (s *AlertaService) notificarLimites(userID uint, limites *types.LimitesMesResponse, consumos []types.LimiteConsumoResponse) (int, error) {
+	porCategoria, padrao, err := s.percentuaisPorCategoria(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	preferencia := s.preferenciaService.preferencias(userID)
+
+	criadas := 0
+	for i := range consumos {
+		limite := &consumos[i]
+		if limite.ValorEfetivo <= 0 {
+			continue
+		}
+
+		percentuais, ok := porCategoria[strings.ToLower(limite.Categoria)]
+		if !ok {
+			percentuais = padrao
+		}
+
+		for _, percentual := range percentuais {
+			if !atingiuPercentual(limite, percentual) {
+				continue
+			}
+
+			notificacao, err := novaNotificacaoLimite(userID, limites.MesReferencia, limite, percentual, preferencia)
+			if err != nil {
+				return criadas, err
+			}
+
+			criada, err := s.notificacaoDAL.CreateNotificacaoUnica(notificacao, s.preferenciaService.mensagemPush(notificacao, s.limiteService.periodoService.relogio.Agora().UTC()))
+			if err != nil {
+				return criadas, err
+			}
+			if criada {
+				criadas++
+			}
+		}
+	}
+
+	// Desativar os alertas gerais também desativa o aviso de previsão
+	if len(padrao) > 0 && limites.Geral != nil {
+		criada, err := s.avaliarPrevisao(userID, limites.MesReferencia, limites.Geral)
+		if err != nil {
+			return criadas, err
+		}
+		if criada {
+			criadas++
+		}
+	}
+
+	return criadas, nil
+}
+
+// avaliarPrevisao avisa, uma vez por mês, quando a previsão indica que o
+// limite geral será ultrapassado antes de isso acontecer. Só vale para o mês
+// corrente e depois dos primeiros dias, quando o ritmo já diz alguma coisa.
+func (s *AlertaService) avaliarPrevisao(userID uint, mesReferencia string, geral *types.LimiteConsumoResponse) (bool, error) {
+	mes, err := parseMonthYear(mesReferencia)
+	if err != nil {
+		return false, err
+	}
+
+	agora := s.limiteService.periodoService.agora(userID)
+	if !mes.Equal(s.limiteService.periodoService.mesAtual(userID)) {
+		return false, nil
+	}
+	if atingiuPercentual(geral, 100) {
+		return false, nil
+	}
+
+	previsao, err := s.previsaoService.prever(userID, mes, agora)
+	if err != nil || !previsao.ExcedeLimite || previsao.DiasDecorridos < diasMinimosAlertaPrevisao {
+		return false, err
+	}
+
+	preferencia := s.preferenciaService.preferencias(userID)
+	notificacao := &types.Notificacao{
+		UserID:        userID,
+		Chave:         fmt.Sprintf("%s||%s", tipoNotificacaoPrevisao, mesReferencia),
+		Tipo:          tipoNotificacaoPrevisao,
+		Titulo:        i18n.Traduzir(preferencia.Idioma, i18n.NotificacaoPrevisaoTitulo),
+		Mensagem:      i18n.Traduzir(preferencia.Idioma, i18n.NotificacaoPrevisaoMensagem, mesReferencia, formatarMoeda(previsao.Previsao, preferencia), formatarMoeda(*previsao.Limite, preferencia)),
+		MesReferencia: &mes,
+	}
+
+	return s.notificacaoDAL.CreateNotificacaoUnica(notificacao, s.preferenciaService.mensagemPush(notificacao, agora))
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+)
+
+func TestAtingiuPercentual(t *testing.T) {
+	casos := []struct {
+		nome       string
+		gasto      float64
+		valor      float64
+		percentual int
+		atingiu    bool
+	}{
+		{nome: "exatamente o limite", gasto: 12.35, valor: 12.35, percentual: 100, atingiu: true},
+		{nome: "um centavo abaixo arredonda para 100%", gasto: 2999.99, valor: 3000, percentual: 100},
+		{nome: "um centavo acima", gasto: 3000.01, valor: 3000, percentual: 100, atingiu: true},
+		{nome: "um centavo abaixo de 80%", gasto: 79.99, valor: 100, percentual: 80},
+		{nome: "exatamente 80%", gasto: 80, valor: 100, percentual: 80, atingiu: true},
+		{nome: "percentual acima de 100", gasto: 1200, valor: 1000, percentual: 120, atingiu: true},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			limite := toLimiteConsumoResponse(&types.LimiteSimpleResponse{Valor: caso.valor, ValorEfetivo: caso.valor}, caso.gasto)
+			if atingiu := atingiuPercentual(limite, caso.percentual); atingiu != caso.atingiu {
+				t.Errorf("atingiu %v (percentual usado %v), esperava %v", atingiu, limite.PercentualUsado, caso.atingiu)
+			}
+		})
+	}
+}
@@ -1,13 +1,14 @@
 package services
 
 import (
-	"errors"
 	"net/mail"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
 	"github.com/Vicente/Password-Mobile-App/backend/app/types"
 	"github.com/golang-jwt/jwt/v4"
 	"golang.org/x/crypto/bcrypt"
@@ -20,52 +21,69 @@ var (
 
 type AuthService struct {
 	AuthDAL *dal.AuthDAL
+	relogio relogio.Relogio
 }
 
-func NewAuthService(authDAL *dal.AuthDAL) *AuthService {
+func NewAuthService(authDAL *dal.AuthDAL, relogio relogio.Relogio) *AuthService {
 	return &AuthService{
 		AuthDAL: authDAL,
+		relogio: relogio,
 	}
 }
 
 func (s *AuthService) Signup(req *types.SignupRequest) (*types.User, error) {
 	email := strings.TrimSpace(req.Email)
 	if email == "" {
-		return nil, errors.New("email é obrigatório")
+		return nil, i18n.NovoErro(i18n.EmailObrigatorio)
 	}
 
 	if _, err := mail.ParseAddress(email); err != nil {
-		return nil, errors.New("formato de email inválido")
+		return nil, i18n.NovoErro(i18n.EmailFormatoInvalido)
 	}
 
 	if !strings.Contains(email, "@") || !strings.Contains(email, ".") {
-		return nil, errors.New("email deve conter @ e domínio válido")
+		return nil, i18n.NovoErro(i18n.EmailDominioInvalido)
 	}
 
 	if strings.HasPrefix(email, ".") || strings.HasSuffix(email, ".") ||
 		strings.HasPrefix(email, "@") || strings.HasSuffix(email, "@") {
-		return nil, errors.New("formato de email inválido")
+		return nil, i18n.NovoErro(i18n.EmailFormatoInvalido)
 	}
 
 	email = strings.ToLower(email)
 
 	if len(strings.TrimSpace(req.Nome)) == 0 {
-		return nil, errors.New("nome é obrigatório")
+		return nil, i18n.NovoErro(i18n.NomeObrigatorio)
 	}
 
 	senha := strings.TrimSpace(req.Senha)
 	if len(senha) < 6 {
-		return nil, errors.New("a senha deve ter pelo menos 6 caracteres")
+		return nil, i18n.NovoErro(i18n.SenhaCurta)
 	}
 
 	confirmacaoSenha := strings.TrimSpace(req.ConfirmacaoSenha)
 	if senha != confirmacaoSenha {
-		return nil, errors.New("as senhas não coincidem")
+		return nil, i18n.NovoErro(i18n.SenhasDiferentes)
+	}
+
+	preferencia := novaPreferenciaPadrao(0)
+	if strings.TrimSpace(req.Idioma) != "" {
+		idioma, err := normalizarIdioma(req.Idioma)
+		if err != nil {
+			return nil, err
+		}
+		preferencia.Idioma = idioma
+	}
+	if fusoHorario := strings.TrimSpace(req.FusoHorario); fusoHorario != "" {
+		if _, err := carregarFusoHorario(fusoHorario); err != nil {
+			return nil, err
+		}
+		preferencia.FusoHorario = fusoHorario
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(senha), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, errors.New("erro ao processar senha")
+		return nil, i18n.NovoErro(i18n.SenhaErroProcessamento)
 	}
 
 	user := &types.User{
@@ -73,6 +91,7 @@ func (s *AuthService) Signup(req *types.SignupRequest) (*types.User, error) {
 		DataNascimento: req.DataNascimento,
 		Email:          email,
 		SenhaHash:      string(hashedPassword),
+		Preferencia:    preferencia,
 	}
 
 	if err := s.AuthDAL.CreateUser(user); err != nil {
@@ -87,25 +106,25 @@ func (s *AuthService) Signup(req *types.SignupRequest) (*types.User, error) {
 func (s *AuthService) Login(req *types.LoginRequest) (*types.AuthResponse, error) {
 	email := strings.TrimSpace(strings.ToLower(req.Email))
 	if email == "" {
-		return nil, errors.New("email é obrigatório")
+		return nil, i18n.NovoErro(i18n.EmailObrigatorio)
 	}
 
 	if _, err := mail.ParseAddress(email); err != nil {
-		return nil, errors.New("formato de email inválido")
+		return nil, i18n.NovoErro(i18n.EmailFormatoInvalido)
 	}
 
 	user, err := s.AuthDAL.GetUserByEmail(email)
 	if err != nil {
-		return nil, errors.New("email ou senha inválidos")
+		return nil, i18n.NovoErro(i18n.EmailOuSenhaInvalidos)
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.SenhaHash), []byte(req.Senha)); err != nil {
-		return nil, errors.New("email ou senha inválidos")
+		return nil, i18n.NovoErro(i18n.EmailOuSenhaInvalidos)
 	}
 
 	token, err := s.generateJWT(user)
 	if err != nil {
-		return nil, errors.New("erro ao gerar token de autenticação")
+		return nil, i18n.NovoErro(i18n.TokenErroGeracao)
 	}
 
 	user.Senha = ""
@@ -120,7 +139,7 @@ func (s *AuthService) generateJWT(user *types.User) (string, error) {
 	claims := jwt.MapClaims{
 		"id":    user.ID,
 		"email": user.Email,
-		"exp":   time.Now().Add(jwtExpiry).Unix(),
+		"exp":   s.relogio.Agora().Add(jwtExpiry).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -131,7 +150,7 @@ func (s *AuthService) generateJWT(user *types.User) (string, error) {
 func (s *AuthService) GetUserProfile(userID uint) (*types.UserProfileResponse, error) {
 	user, err := s.AuthDAL.GetUserByID(userID)
 	if err != nil {
-		return nil, errors.New("usuário não encontrado")
+		return nil, i18n.NovoErro(i18n.UsuarioNaoEncontrado)
 	}
 	
 	return &types.UserProfileResponse{
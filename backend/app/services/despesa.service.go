@@ -3,41 +3,87 @@ package services
 import (
 	"errors"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
 	"github.com/Vicente/Password-Mobile-App/backend/app/types"
 	"gorm.io/gorm"
 )
 
 type DespesaService struct {
-	despesaDAL *dal.DespesaDAL
+	despesaDAL        *dal.DespesaDAL
+	regraService      *RegraService
+	alertaService     *AlertaService
+	webhookService    *WebhookService
+	periodoService    *PeriodoService
+	fechamentoService *FechamentoService
 }
 
-func NewDespesaService(despesaDAL *dal.DespesaDAL) *DespesaService {
-	return &DespesaService{despesaDAL: despesaDAL}
+func NewDespesaService(despesaDAL *dal.DespesaDAL, regraService *RegraService, alertaService *AlertaService, webhookService *WebhookService, periodoService *PeriodoService, fechamentoService *FechamentoService) *DespesaService {
+	return &DespesaService{
+		despesaDAL:        despesaDAL,
+		regraService:      regraService,
+		alertaService:     alertaService,
+		webhookService:    webhookService,
+		periodoService:    periodoService,
+		fechamentoService: fechamentoService,
+	}
+}
+
+// avaliarAlertas não interrompe a operação: a despesa já foi gravada e uma
+// falha ao gerar notificações apenas é registrada no log.
+func (s *DespesaService) avaliarAlertas(userID uint, mesReferencia string) {
+	if _, err := s.alertaService.AvaliarLimites(userID, mesReferencia); err != nil {
+		log.Printf("Falha ao avaliar alertas de limite do usuário %d em %s: %v", userID, mesReferencia, err)
+	}
+}
+
+// notificarImportadas publica a criação de cada despesa importada e reavalia
+// os alertas uma vez por mês afetado, como a criação individual faria.
+func (s *DespesaService) notificarImportadas(userID uint, despesas []types.Despesa) {
+	mesesAvaliados := make(map[string]bool)
+	for i := range despesas {
+		despesa := toDespesaSimpleResponse(&despesas[i])
+		s.webhookService.publicarEvento(userID, eventoDespesaCriada, "", despesa)
+		if !mesesAvaliados[despesa.MesReferencia] {
+			mesesAvaliados[despesa.MesReferencia] = true
+			s.avaliarAlertas(userID, despesa.MesReferencia)
+		}
+	}
+}
+
+// notificarExcluidas publica a exclusão de cada despesa. Exclusões não
+// reavaliam alertas, como em DeleteDespesa.
+func (s *DespesaService) notificarExcluidas(userID uint, despesas []types.Despesa) {
+	for i := range despesas {
+		s.webhookService.publicarEvento(userID, eventoDespesaExcluida, "", toDespesaSimpleResponse(&despesas[i]))
+	}
 }
 
+// parseMonthYearDespesa segue parseMonthYear: o mês informado é o rótulo do
+// período, e só a `data` de uma despesa é convertida pelo resolvedor.
 func parseMonthYearDespesa(monthYear string) (time.Time, error) {
 	parts := strings.Split(monthYear, "-")
 	if len(parts) != 2 {
-		return time.Time{}, errors.New("formato de mês inválido. Use YYYY-MM")
+		return time.Time{}, i18n.NovoErro(i18n.MesFormatoInvalido)
 	}
 
 	year, err := strconv.Atoi(parts[0])
 	if err != nil {
-		return time.Time{}, errors.New("ano inválido")
+		return time.Time{}, i18n.NovoErro(i18n.AnoInvalido)
 	}
 
 	month, err := strconv.Atoi(parts[1])
 	if err != nil {
-		return time.Time{}, errors.New("mês inválido")
+		return time.Time{}, i18n.NovoErro(i18n.MesInvalido)
 	}
 
 	if month < 1 || month > 12 {
-		return time.Time{}, errors.New("mês deve estar entre 1 e 12")
+		return time.Time{}, i18n.NovoErro(i18n.MesForaDoIntervalo)
 	}
 
 	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), nil
@@ -47,38 +93,154 @@ func formatMonthYearDespesa(t time.Time) string {
 	return fmt.Sprintf("%04d-%02d", t.Year(), int(t.Month()))
 }
 
-func isBeforeCurrentMonthDespesa(mesReferencia time.Time) bool {
-	now := time.Now().UTC()
-	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-	return mesReferencia.Before(currentMonth)
+func toDespesaSimpleResponse(despesa *types.Despesa) *types.DespesaSimpleResponse {
+	response := &types.DespesaSimpleResponse{
+		ID:            despesa.ID,
+		Descricao:     despesa.Descricao,
+		Valor:         despesa.Valor,
+		MesReferencia: formatMonthYearDespesa(despesa.MesReferencia),
+		Categoria:     despesa.Categoria,
+		Tags:          separarTags(despesa.Tags),
+		Conta:         despesa.Conta,
+		UserID:        despesa.UserID,
+	}
+	if despesa.Data != nil {
+		response.Data = despesa.Data.Format("2006-01-02")
+	}
+	return response
 }
 
 func (s *DespesaService) CreateDespesa(userID uint, req *types.CreateDespesaRequest) (*types.DespesaSimpleResponse, error) {
-	mesReferencia, err := parseMonthYearDespesa(req.MesReferencia)
+	despesa, err := s.createDespesa(s.despesaDAL, userID, req)
 	if err != nil {
 		return nil, err
 	}
 
-	if isBeforeCurrentMonthDespesa(mesReferencia) {
-		return nil, errors.New("não é possível criar despesa para meses anteriores ao mês corrente")
+	s.webhookService.publicarEvento(userID, eventoDespesaCriada, "", despesa)
+	s.avaliarAlertas(userID, despesa.MesReferencia)
+	return despesa, nil
+}
+
+// resolverMesDespesa retorna o mês de referência informado ou, na falta dele,
+// o mês do período de orçamento que contém a data da despesa.
+func (s *DespesaService) resolverMesDespesa(userID uint, mesReferencia string, data string) (time.Time, *time.Time, error) {
+	var dataDespesa *time.Time
+	if data != "" {
+		parsed, err := time.Parse("2006-01-02", data)
+		if err != nil {
+			return time.Time{}, nil, i18n.NovoErro(i18n.DataInvalida)
+		}
+		dataDespesa = &parsed
+	}
+
+	if mesReferencia == "" {
+		if dataDespesa == nil {
+			return time.Time{}, nil, i18n.NovoErro(i18n.MesOuDataObrigatorio)
+		}
+		return s.periodoService.resolvedor(userID).mesReferenciaDe(*dataDespesa), dataDespesa, nil
+	}
+
+	mes, err := parseMonthYearDespesa(mesReferencia)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	return mes, dataDespesa, nil
+}
+
+// criarDespesaVinculada cria a despesa e executa `vincular` na mesma
+// transação, para registros que nascem de uma despesa (como o pagamento de
+// uma dívida). Duplicatas não são verificadas.
+func (s *DespesaService) criarDespesaVinculada(userID uint, req *types.CreateDespesaRequest, vincular func(txDAL *dal.DespesaDAL, despesa *types.DespesaSimpleResponse) error) (*types.DespesaSimpleResponse, error) {
+	req.IgnorarDuplicatas = true
+
+	var despesa *types.DespesaSimpleResponse
+	err := s.despesaDAL.Transaction(func(txDAL *dal.DespesaDAL) error {
+		var err error
+		if despesa, err = s.createDespesa(txDAL, userID, req); err != nil {
+			return err
+		}
+		return vincular(txDAL, despesa)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.webhookService.publicarEvento(userID, eventoDespesaCriada, "", despesa)
+	s.avaliarAlertas(userID, despesa.MesReferencia)
+	return despesa, nil
+}
+
+// excluirDespesaVinculada executa `desvincular` e exclui a despesa na mesma
+// transação. Desvincular antes libera a exclusão, que é recusada enquanto a
+// despesa pertence a um pagamento. Se a despesa já tiver sido excluída,
+// apenas desvincula.
+func (s *DespesaService) excluirDespesaVinculada(userID uint, despesaID uint, desvincular func(txDAL *dal.DespesaDAL) error) error {
+	var despesa *types.DespesaSimpleResponse
+	err := s.despesaDAL.Transaction(func(txDAL *dal.DespesaDAL) error {
+		if err := desvincular(txDAL); err != nil {
+			return err
+		}
+		var err error
+		despesa, err = s.deleteDespesa(txDAL, userID, despesaID)
+		if err != nil && !i18n.TemCodigo(err, i18n.DespesaNaoEncontrada) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if despesa != nil {
+		s.webhookService.publicarEvento(userID, eventoDespesaExcluida, "", despesa)
+	}
+	return nil
+}
+
+func (s *DespesaService) createDespesa(despesaDAL *dal.DespesaDAL, userID uint, req *types.CreateDespesaRequest) (*types.DespesaSimpleResponse, error) {
+	mesReferencia, data, err := s.resolverMesDespesa(userID, req.MesReferencia, req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.fechamentoService.verificarMesAberto(userID, mesReferencia, i18n.MesFechadoCriarDespesa); err != nil {
+		return nil, err
 	}
 
 	despesa := &types.Despesa{
 		Descricao:     req.Descricao,
 		Valor:         req.Valor,
 		MesReferencia: mesReferencia,
+		Data:          data,
+		Categoria:     strings.TrimSpace(req.Categoria),
+		Tags:          juntarTags(req.Tags),
+		Conta:         strings.TrimSpace(req.Conta),
 		UserID:        userID,
 	}
 
-	if err := s.despesaDAL.CreateDespesa(despesa); err != nil {
+	if err := s.regraService.AplicarRegras(userID, despesa); err != nil {
 		return nil, err
 	}
 
-	return &types.DespesaSimpleResponse{
-		Descricao:     despesa.Descricao,
-		Valor:         despesa.Valor,
-		MesReferencia: formatMonthYearDespesa(despesa.MesReferencia),
-	}, nil
+	if !req.IgnorarDuplicatas {
+		candidatos, err := buscarDuplicatas(despesaDAL, despesa)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidatos) > 0 {
+			duplicataErr := &DuplicataError{}
+			for i := range candidatos {
+				duplicataErr.Candidatos = append(duplicataErr.Candidatos, *toDespesaSimpleResponse(&candidatos[i]))
+			}
+			return nil, duplicataErr
+		}
+	}
+
+	if err := despesaDAL.CreateDespesa(despesa); err != nil {
+		return nil, err
+	}
+
+	return toDespesaSimpleResponse(despesa), nil
 }
 
 func (s *DespesaService) GetDespesasByMonth(userID uint, monthYear string) ([]types.DespesaSimpleResponse, error) {
@@ -94,11 +256,7 @@ func (s *DespesaService) GetDespesasByMonth(userID uint, monthYear string) ([]ty
 
 	var response []types.DespesaSimpleResponse
 	for _, despesa := range despesas {
-		response = append(response, types.DespesaSimpleResponse{
-			Descricao:     despesa.Descricao,
-			Valor:         despesa.Valor,
-			MesReferencia: formatMonthYearDespesa(despesa.MesReferencia),
-		})
+		response = append(response, *toDespesaSimpleResponse(&despesa))
 	}
 
 	return response, nil
@@ -112,55 +270,282 @@ func (s *DespesaService) GetDespesasByUser(userID uint) ([]types.DespesaSimpleRe
 
 	var response []types.DespesaSimpleResponse
 	for _, despesa := range despesas {
-		response = append(response, types.DespesaSimpleResponse{
-			Descricao:     despesa.Descricao,
-			Valor:         despesa.Valor,
-			MesReferencia: formatMonthYearDespesa(despesa.MesReferencia),
-		})
+		response = append(response, *toDespesaSimpleResponse(&despesa))
 	}
 
 	return response, nil
 }
 
 func (s *DespesaService) UpdateDespesa(userID uint, despesaID uint, req *types.UpdateDespesaRequest) (*types.DespesaSimpleResponse, error) {
-	despesa, err := s.despesaDAL.GetDespesaByID(despesaID, userID)
+	despesa, err := s.updateDespesa(s.despesaDAL, userID, despesaID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.webhookService.publicarEvento(userID, eventoDespesaAlterada, "", despesa)
+	s.avaliarAlertas(userID, despesa.MesReferencia)
+	return despesa, nil
+}
+
+func (s *DespesaService) updateDespesa(despesaDAL *dal.DespesaDAL, userID uint, despesaID uint, req *types.UpdateDespesaRequest) (*types.DespesaSimpleResponse, error) {
+	despesa, err := despesaDAL.GetDespesaByID(despesaID, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("despesa não encontrada")
+			return nil, i18n.NovoErro(i18n.DespesaNaoEncontrada)
 		}
 		return nil, err
 	}
 
-	if isBeforeCurrentMonthDespesa(despesa.MesReferencia) {
-		return nil, errors.New("não é possível editar despesa de meses anteriores ao mês corrente")
+	if err := verificarDespesaLivre(despesaDAL, despesa.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.fechamentoService.verificarMesAberto(userID, despesa.MesReferencia, i18n.MesFechadoEditarDespesa); err != nil {
+		return nil, err
 	}
 
 	despesa.Descricao = req.Descricao
 	despesa.Valor = req.Valor
+	if req.Categoria != nil {
+		despesa.Categoria = strings.TrimSpace(*req.Categoria)
+	}
+	if req.Tags != nil {
+		despesa.Tags = juntarTags(*req.Tags)
+	}
 
-	if err := s.despesaDAL.UpdateDespesa(despesa); err != nil {
+	if err := despesaDAL.UpdateDespesa(despesa); err != nil {
 		return nil, err
 	}
 
-	return &types.DespesaSimpleResponse{
-		Descricao:     despesa.Descricao,
-		Valor:         despesa.Valor,
-		MesReferencia: formatMonthYearDespesa(despesa.MesReferencia),
-	}, nil
+	return toDespesaSimpleResponse(despesa), nil
+}
+
+// verificarDespesaLivre recusa alterar ou excluir pela rota de despesas uma
+// despesa gerada pelo pagamento de uma dívida ou paga com um envelope, para
+// que o saldo da dívida e o do envelope continuem batendo com as despesas.
+func verificarDespesaLivre(despesaDAL *dal.DespesaDAL, despesaID uint) error {
+	vinculada, err := despesaDAL.PossuiPagamentoDivida(despesaID)
+	if err != nil {
+		return err
+	}
+	if vinculada {
+		return i18n.NovoErro(i18n.DespesaVinculadaDivida)
+	}
+
+	paga, err := despesaDAL.PossuiGastoEnvelope(despesaID)
+	if err != nil {
+		return err
+	}
+	if paga {
+		return i18n.NovoErro(i18n.DespesaVinculadaEnvelope)
+	}
+	return nil
 }
 
 func (s *DespesaService) DeleteDespesa(userID uint, despesaID uint) error {
-	despesa, err := s.despesaDAL.GetDespesaByID(despesaID, userID)
+	despesa, err := s.deleteDespesa(s.despesaDAL, userID, despesaID)
+	if err != nil {
+		return err
+	}
+
+	s.webhookService.publicarEvento(userID, eventoDespesaExcluida, "", despesa)
+	return nil
+}
+
+func (s *DespesaService) deleteDespesa(despesaDAL *dal.DespesaDAL, userID uint, despesaID uint) (*types.DespesaSimpleResponse, error) {
+	despesa, err := despesaDAL.GetDespesaByID(despesaID, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("despesa não encontrada")
+			return nil, i18n.NovoErro(i18n.DespesaNaoEncontrada)
 		}
-		return err
+		return nil, err
+	}
+
+	if err := verificarDespesaLivre(despesaDAL, despesa.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.fechamentoService.verificarMesAberto(userID, despesa.MesReferencia, i18n.MesFechadoExcluirDespesa); err != nil {
+		return nil, err
+	}
+
+	if err := despesaDAL.DeleteDespesa(despesaID, userID); err != nil {
+		return nil, err
+	}
+
+	return toDespesaSimpleResponse(despesa), nil
+}
+
+const (
+	batchModoAtomico       = "atomico"
+	batchModoParcial       = "parcial"
+	batchOperacaoCriar     = "criar"
+	batchOperacaoAtualizar = "atualizar"
+	batchOperacaoExcluir   = "excluir"
+	maxBatchOperacoes      = 500
+)
+
+var errBatchRevertido = errors.New("lote revertido")
+
+func validarDadosDespesa(descricao string, valor float64) error {
+	if strings.TrimSpace(descricao) == "" {
+		return i18n.NovoErro(i18n.DescricaoObrigatoria)
+	}
+	if valor <= 0 {
+		return i18n.NovoErro(i18n.ValorNaoPositivo)
+	}
+	return nil
+}
+
+func (s *DespesaService) executarOperacaoBatch(despesaDAL *dal.DespesaDAL, userID uint, op *types.BatchDespesaOperacao) (*types.DespesaSimpleResponse, error) {
+	switch op.Operacao {
+	case batchOperacaoCriar:
+		if err := validarDadosDespesa(op.Descricao, op.Valor); err != nil {
+			return nil, err
+		}
+		if op.MesReferencia == "" && op.Data == "" {
+			return nil, i18n.NovoErro(i18n.MesOuDataObrigatorio)
+		}
+		req := &types.CreateDespesaRequest{
+			Descricao:         op.Descricao,
+			Valor:             op.Valor,
+			MesReferencia:     op.MesReferencia,
+			Data:              op.Data,
+			Conta:             op.Conta,
+			IgnorarDuplicatas: op.IgnorarDuplicatas,
+		}
+		if op.Categoria != nil {
+			req.Categoria = *op.Categoria
+		}
+		if op.Tags != nil {
+			req.Tags = *op.Tags
+		}
+		return s.createDespesa(despesaDAL, userID, req)
+	case batchOperacaoAtualizar:
+		if op.ID == 0 {
+			return nil, i18n.NovoErro(i18n.LoteIDAtualizarObrigatorio)
+		}
+		if err := validarDadosDespesa(op.Descricao, op.Valor); err != nil {
+			return nil, err
+		}
+		return s.updateDespesa(despesaDAL, userID, op.ID, &types.UpdateDespesaRequest{
+			Descricao: op.Descricao,
+			Valor:     op.Valor,
+			Categoria: op.Categoria,
+			Tags:      op.Tags,
+		})
+	case batchOperacaoExcluir:
+		if op.ID == 0 {
+			return nil, i18n.NovoErro(i18n.LoteIDExcluirObrigatorio)
+		}
+		return s.deleteDespesa(despesaDAL, userID, op.ID)
+	default:
+		return nil, i18n.NovoErro(i18n.LoteOperacaoInvalida)
+	}
+}
+
+// BatchDespesas traduz o erro de cada operação para o idioma informado, já
+// que ele vai no corpo de uma resposta de sucesso.
+func (s *DespesaService) BatchDespesas(userID uint, idioma string, req *types.BatchDespesaRequest) (*types.BatchDespesaResponse, error) {
+	modo := req.Modo
+	if modo == "" {
+		modo = batchModoAtomico
+	}
+	if modo != batchModoAtomico && modo != batchModoParcial {
+		return nil, i18n.NovoErro(i18n.LoteModoInvalido)
+	}
+
+	if len(req.Operacoes) == 0 {
+		return nil, i18n.NovoErro(i18n.LoteVazio)
+	}
+	if len(req.Operacoes) > maxBatchOperacoes {
+		return nil, i18n.NovoErro(i18n.LoteExcesso, maxBatchOperacoes)
+	}
+
+	resultados := make([]types.BatchDespesaResultado, len(req.Operacoes))
+
+	err := s.despesaDAL.Transaction(func(txDAL *dal.DespesaDAL) error {
+		falhou := false
+
+		for i := range req.Operacoes {
+			op := &req.Operacoes[i]
+			resultado := types.BatchDespesaResultado{Indice: i, Operacao: op.Operacao, ID: op.ID}
+
+			var despesa *types.DespesaSimpleResponse
+			var opErr error
+			if modo == batchModoParcial {
+				opErr = txDAL.Transaction(func(itemDAL *dal.DespesaDAL) error {
+					var err error
+					despesa, err = s.executarOperacaoBatch(itemDAL, userID, op)
+					return err
+				})
+			} else {
+				despesa, opErr = s.executarOperacaoBatch(txDAL, userID, op)
+			}
+
+			if opErr != nil {
+				resultado.Erro = i18n.NovoErroItem(idioma, opErr)
+				var duplicataErr *DuplicataError
+				if errors.As(opErr, &duplicataErr) {
+					resultado.Duplicatas = duplicataErr.Candidatos
+				}
+				falhou = true
+			} else {
+				resultado.Sucesso = true
+				resultado.Despesa = despesa
+				if despesa != nil {
+					resultado.ID = despesa.ID
+				}
+			}
+			resultados[i] = resultado
+		}
+
+		if falhou && modo == batchModoAtomico {
+			return errBatchRevertido
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBatchRevertido) {
+		return nil, err
 	}
 
-	if isBeforeCurrentMonthDespesa(despesa.MesReferencia) {
-		return errors.New("não é possível excluir despesa de meses anteriores ao mês corrente")
+	response := &types.BatchDespesaResponse{
+		Modo:       modo,
+		Total:      len(resultados),
+		Resultados: resultados,
 	}
 
-	return s.despesaDAL.DeleteDespesa(despesaID, userID)
-} 
\ No newline at end of file
+	for i := range resultados {
+		if errors.Is(err, errBatchRevertido) && resultados[i].Sucesso {
+			resultados[i].Sucesso = false
+			resultados[i].Despesa = nil
+			resultados[i].ID = req.Operacoes[i].ID
+			resultados[i].Erro = i18n.NovoErroItem(idioma, i18n.NovoErro(i18n.LoteRevertido))
+		}
+		if resultados[i].Sucesso {
+			response.Aplicadas++
+		} else {
+			response.Falhas++
+		}
+	}
+	response.Sucesso = response.Falhas == 0
+
+	eventos := map[string]string{
+		batchOperacaoCriar:     eventoDespesaCriada,
+		batchOperacaoAtualizar: eventoDespesaAlterada,
+		batchOperacaoExcluir:   eventoDespesaExcluida,
+	}
+	mesesAvaliados := make(map[string]bool)
+	for _, resultado := range resultados {
+		if !resultado.Sucesso || resultado.Despesa == nil {
+			continue
+		}
+		s.webhookService.publicarEvento(userID, eventos[resultado.Operacao], "", resultado.Despesa)
+		if resultado.Operacao != batchOperacaoExcluir && !mesesAvaliados[resultado.Despesa.MesReferencia] {
+			mesesAvaliados[resultado.Despesa.MesReferencia] = true
+			s.avaliarAlertas(userID, resultado.Despesa.MesReferencia)
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type DispositivoService struct {
+	dispositivoDAL *dal.DispositivoDAL
+}
+
+func NewDispositivoService(dispositivoDAL *dal.DispositivoDAL) *DispositivoService {
+	return &DispositivoService{dispositivoDAL: dispositivoDAL}
+}
+
+func toDispositivoSimpleResponse(dispositivo *types.DispositivoPush) *types.DispositivoSimpleResponse {
+	return &types.DispositivoSimpleResponse{
+		ID:         dispositivo.ID,
+		Token:      dispositivo.Token,
+		Plataforma: dispositivo.Plataforma,
+		Ativo:      dispositivo.Ativo,
+		CriadoEm:   dispositivo.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// RegistrarDispositivo é idempotente. Se o token já pertencia a outro usuário
+// (por exemplo, após trocar de conta no mesmo aparelho), ele passa a ser do
+// usuário atual.
+func (s *DispositivoService) RegistrarDispositivo(userID uint, req *types.RegistrarDispositivoRequest) (*types.DispositivoSimpleResponse, error) {
+	token := strings.TrimSpace(req.Token)
+	if token == "" {
+		return nil, i18n.NovoErro(i18n.DispositivoTokenObrigatorio)
+	}
+	if len(token) > 255 {
+		return nil, i18n.NovoErro(i18n.DispositivoTokenInvalido)
+	}
+
+	plataforma := strings.ToLower(strings.TrimSpace(req.Plataforma))
+	if plataforma != "" && plataforma != "ios" && plataforma != "android" && plataforma != "web" {
+		return nil, i18n.NovoErro(i18n.DispositivoPlataformaInvalida)
+	}
+
+	dispositivo, err := s.dispositivoDAL.GetDispositivoByToken(token)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		dispositivo = &types.DispositivoPush{Token: token}
+	}
+
+	dispositivo.UserID = userID
+	dispositivo.Plataforma = plataforma
+	dispositivo.Ativo = true
+
+	if err := s.dispositivoDAL.SaveDispositivo(dispositivo); err != nil {
+		return nil, err
+	}
+
+	return toDispositivoSimpleResponse(dispositivo), nil
+}
+
+func (s *DispositivoService) GetDispositivosByUser(userID uint) ([]types.DispositivoSimpleResponse, error) {
+	dispositivos, err := s.dispositivoDAL.GetDispositivosByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.DispositivoSimpleResponse
+	for i := range dispositivos {
+		response = append(response, *toDispositivoSimpleResponse(&dispositivos[i]))
+	}
+
+	return response, nil
+}
+
+func (s *DispositivoService) DeleteDispositivo(userID uint, dispositivoID uint) error {
+	if _, err := s.dispositivoDAL.GetDispositivoByID(dispositivoID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return i18n.NovoErro(i18n.DispositivoNaoEncontrado)
+		}
+		return err
+	}
+
+	return s.dispositivoDAL.DeleteDispositivo(dispositivoID, userID)
+}
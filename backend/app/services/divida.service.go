@@ -0,0 +1,557 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const (
+	papelDividaDevedor = "devedor"
+	papelDividaCredor  = "credor"
+
+	sistemaDividaPrice = "price"
+	sistemaDividaSAC   = "sac"
+
+	statusDividaEmDia    = "em_dia"
+	statusDividaAtrasada = "atrasada"
+	statusDividaQuitada  = "quitada"
+
+	maxParcelasDivida  = 480
+	maxMesesProjecao   = 1200
+	toleranciaCentavos = 0.005
+)
+
+type DividaService struct {
+	dividaDAL      *dal.DividaDAL
+	despesaService *DespesaService
+	periodoService *PeriodoService
+}
+
+func NewDividaService(dividaDAL *dal.DividaDAL, despesaService *DespesaService, periodoService *PeriodoService) *DividaService {
+	return &DividaService{
+		dividaDAL:      dividaDAL,
+		despesaService: despesaService,
+		periodoService: periodoService,
+	}
+}
+
+// somarMeses avança a data mantendo o dia, ou o último dia do mês quando ele
+// não existe (31/01 + 1 mês = 28/02).
+func somarMeses(data time.Time, meses int) time.Time {
+	mes := inicioDoMes(data).AddDate(0, meses, 0)
+	ultimoDia := mes.AddDate(0, 1, -1).Day()
+	dia := data.Day()
+	if dia > ultimoDia {
+		dia = ultimoDia
+	}
+	return mes.AddDate(0, 0, dia-1)
+}
+
+// gerarCronograma calcula as parcelas pela tabela Price (parcelas iguais) ou
+// SAC (amortização constante, parcelas decrescentes). A última parcela
+// absorve as diferenças de arredondamento.
+func gerarCronograma(divida *types.Divida) []types.ParcelaDividaResponse {
+	taxa := divida.TaxaJuros / 100
+	n := divida.Parcelas
+
+	prestacao := divida.Principal / float64(n)
+	if divida.Sistema == sistemaDividaPrice && taxa > 0 {
+		prestacao = divida.Principal * taxa / (1 - math.Pow(1+taxa, -float64(n)))
+	}
+
+	parcelas := make([]types.ParcelaDividaResponse, 0, n)
+	saldo := divida.Principal
+	for k := 1; k <= n; k++ {
+		juros := saldo * taxa
+		amortizacao := divida.Principal / float64(n)
+		if divida.Sistema == sistemaDividaPrice {
+			amortizacao = prestacao - juros
+		}
+		if k == n {
+			amortizacao = saldo
+		}
+		saldo -= amortizacao
+
+		parcelas = append(parcelas, types.ParcelaDividaResponse{
+			Numero:       k,
+			Vencimento:   somarMeses(divida.DataInicio, k).Format("2006-01-02"),
+			Valor:        arredondar(amortizacao + juros),
+			Juros:        arredondar(juros),
+			Amortizacao:  arredondar(amortizacao),
+			SaldoDevedor: arredondar(math.Max(saldo, 0)),
+		})
+	}
+	return parcelas
+}
+
+// marcarParcelasPagas considera paga cada parcela coberta pelo total já pago,
+// em ordem de vencimento. Retorna quantas estão pagas.
+func marcarParcelasPagas(parcelas []types.ParcelaDividaResponse, totalPago float64) int {
+	acumulado := 0.0
+	pagas := 0
+	for i := range parcelas {
+		acumulado += parcelas[i].Valor
+		if acumulado <= totalPago+toleranciaCentavos {
+			parcelas[i].Paga = true
+			pagas++
+		}
+	}
+	return pagas
+}
+
+// evoluirSaldo reconstrói o saldo mês a mês, do mês de início até `ate`: a
+// cada mês o saldo rende os juros e depois descontam-se os pagamentos do mês.
+// Pagamentos anteriores ao início contam no primeiro mês.
+func evoluirSaldo(divida *types.Divida, pagamentos []types.PagamentoDivida, parcelas []types.ParcelaDividaResponse, ate time.Time) []types.SaldoDividaMesResponse {
+	taxa := divida.TaxaJuros / 100
+	inicio := inicioDoMes(divida.DataInicio)
+	meses := mesesEntre(inicio, inicioDoMes(ate))
+
+	pagosPorMes := make(map[int]float64)
+	for _, pagamento := range pagamentos {
+		k := mesesEntre(inicio, inicioDoMes(pagamento.Data)) - 1
+		if k < 0 {
+			k = 0
+		}
+		pagosPorMes[k] += pagamento.Valor
+	}
+
+	evolucao := make([]types.SaldoDividaMesResponse, 0, meses)
+	saldo := divida.Principal
+	for k := 0; k < meses; k++ {
+		if k > 0 {
+			saldo += saldo * taxa
+		}
+		saldo = math.Max(saldo-pagosPorMes[k], 0)
+
+		previsto := divida.Principal
+		if k > 0 {
+			previsto = 0
+			if k <= len(parcelas) {
+				previsto = parcelas[k-1].SaldoDevedor
+			}
+		}
+
+		evolucao = append(evolucao, types.SaldoDividaMesResponse{
+			MesReferencia: formatMonthYear(inicio.AddDate(0, k, 0)),
+			SaldoPrevisto: previsto,
+			SaldoReal:     arredondar(saldo),
+			Pago:          arredondar(pagosPorMes[k]),
+		})
+	}
+	return evolucao
+}
+
+func saldoAtualDivida(divida *types.Divida, pagamentos []types.PagamentoDivida, parcelas []types.ParcelaDividaResponse, hoje time.Time) float64 {
+	evolucao := evoluirSaldo(divida, pagamentos, parcelas, hoje)
+	if len(evolucao) == 0 {
+		return divida.Principal
+	}
+	return evolucao[len(evolucao)-1].SaldoReal
+}
+
+func calcularDivida(divida *types.Divida, pagamentos []types.PagamentoDivida, hoje time.Time) *types.DividaResponse {
+	response := &types.DividaResponse{
+		ID:          divida.ID,
+		Descricao:   divida.Descricao,
+		Papel:       divida.Papel,
+		Contraparte: divida.Contraparte,
+		Principal:   divida.Principal,
+		TaxaJuros:   divida.TaxaJuros,
+		Parcelas:    divida.Parcelas,
+		Sistema:     divida.Sistema,
+		DataInicio:  divida.DataInicio.Format("2006-01-02"),
+		Categoria:   divida.Categoria,
+	}
+
+	for _, pagamento := range pagamentos {
+		response.TotalPago += pagamento.Valor
+	}
+	response.TotalPago = arredondar(response.TotalPago)
+
+	parcelas := gerarCronograma(divida)
+	response.ParcelasPagas = marcarParcelasPagas(parcelas, response.TotalPago)
+	response.SaldoDevedor = saldoAtualDivida(divida, pagamentos, parcelas, hoje)
+
+	response.Status = statusDividaEmDia
+	if response.SaldoDevedor <= toleranciaCentavos {
+		response.Status = statusDividaQuitada
+		return response
+	}
+
+	for i := range parcelas {
+		if parcelas[i].Paga {
+			continue
+		}
+		proxima := parcelas[i]
+		response.ProximaParcela = &proxima
+		if proxima.Vencimento < hoje.Format("2006-01-02") {
+			response.Status = statusDividaAtrasada
+		}
+		break
+	}
+
+	return response
+}
+
+func toPagamentoDividaResponse(pagamento *types.PagamentoDivida) types.PagamentoDividaResponse {
+	return types.PagamentoDividaResponse{
+		ID:        pagamento.ID,
+		Valor:     pagamento.Valor,
+		Data:      pagamento.Data.Format("2006-01-02"),
+		DespesaID: pagamento.DespesaID,
+		ReceitaID: pagamento.ReceitaID,
+	}
+}
+
+func validarDivida(req *types.DividaRequest, divida *types.Divida) error {
+	descricao := strings.TrimSpace(req.Descricao)
+	if descricao == "" {
+		return i18n.NovoErro(i18n.DescricaoObrigatoria)
+	}
+
+	papel := strings.ToLower(strings.TrimSpace(req.Papel))
+	if papel == "" {
+		papel = papelDividaDevedor
+	}
+	if papel != papelDividaDevedor && papel != papelDividaCredor {
+		return i18n.NovoErro(i18n.DividaPapelInvalido)
+	}
+
+	sistema := strings.ToLower(strings.TrimSpace(req.Sistema))
+	if sistema == "" {
+		sistema = sistemaDividaPrice
+	}
+	if sistema != sistemaDividaPrice && sistema != sistemaDividaSAC {
+		return i18n.NovoErro(i18n.DividaSistemaInvalido)
+	}
+
+	if req.Principal <= 0 {
+		return i18n.NovoErro(i18n.DividaPrincipalNaoPositivo)
+	}
+	if req.TaxaJuros < 0 || req.TaxaJuros > 100 {
+		return i18n.NovoErro(i18n.DividaTaxaInvalida)
+	}
+	if req.Parcelas < 1 || req.Parcelas > maxParcelasDivida {
+		return i18n.NovoErro(i18n.DividaParcelasInvalidas, maxParcelasDivida)
+	}
+
+	dataInicio, err := time.Parse("2006-01-02", req.DataInicio)
+	if err != nil {
+		return i18n.NovoErro(i18n.DividaDataInicioInvalida)
+	}
+
+	divida.Descricao = descricao
+	divida.Papel = papel
+	divida.Contraparte = strings.TrimSpace(req.Contraparte)
+	divida.Principal = req.Principal
+	divida.TaxaJuros = req.TaxaJuros
+	divida.Parcelas = req.Parcelas
+	divida.Sistema = sistema
+	divida.DataInicio = dataInicio
+	divida.Categoria = strings.TrimSpace(req.Categoria)
+	return nil
+}
+
+func (s *DividaService) getDivida(userID uint, dividaID uint) (*types.Divida, []types.PagamentoDivida, error) {
+	divida, err := s.dividaDAL.GetDividaByID(dividaID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, i18n.NovoErro(i18n.DividaNaoEncontrada)
+		}
+		return nil, nil, err
+	}
+
+	pagamentos, err := s.dividaDAL.GetPagamentosByDivida(divida.ID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return divida, pagamentos, nil
+}
+
+func detalharDivida(divida *types.Divida, pagamentos []types.PagamentoDivida, hoje time.Time) *types.DividaDetalheResponse {
+	detalhe := &types.DividaDetalheResponse{
+		DividaResponse: *calcularDivida(divida, pagamentos, hoje),
+		Pagamentos:     []types.PagamentoDividaResponse{},
+	}
+	for i := range pagamentos {
+		detalhe.Pagamentos = append(detalhe.Pagamentos, toPagamentoDividaResponse(&pagamentos[i]))
+	}
+	return detalhe
+}
+
+func (s *DividaService) CreateDivida(userID uint, req *types.DividaRequest) (*types.DividaResponse, error) {
+	divida := &types.Divida{UserID: userID}
+	if err := validarDivida(req, divida); err != nil {
+		return nil, err
+	}
+
+	if err := s.dividaDAL.CreateDivida(divida); err != nil {
+		return nil, err
+	}
+
+	return calcularDivida(divida, nil, s.periodoService.agora(userID)), nil
+}
+
+func (s *DividaService) GetDividasByUser(userID uint) ([]types.DividaResponse, error) {
+	dividas, err := s.dividaDAL.GetDividasByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(dividas) == 0 {
+		return []types.DividaResponse{}, nil
+	}
+
+	pagamentos, err := s.dividaDAL.GetPagamentosByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	pagamentosPorDivida := make(map[uint][]types.PagamentoDivida)
+	for _, pagamento := range pagamentos {
+		pagamentosPorDivida[pagamento.DividaID] = append(pagamentosPorDivida[pagamento.DividaID], pagamento)
+	}
+
+	hoje := s.periodoService.agora(userID)
+	var response []types.DividaResponse
+	for i := range dividas {
+		response = append(response, *calcularDivida(&dividas[i], pagamentosPorDivida[dividas[i].ID], hoje))
+	}
+	return response, nil
+}
+
+func (s *DividaService) GetDividaByID(userID uint, dividaID uint) (*types.DividaDetalheResponse, error) {
+	divida, pagamentos, err := s.getDivida(userID, dividaID)
+	if err != nil {
+		return nil, err
+	}
+	return detalharDivida(divida, pagamentos, s.periodoService.agora(userID)), nil
+}
+
+// UpdateDivida só altera valores, taxa, parcelas e datas enquanto não houver
+// pagamentos; depois disso, apenas os dados descritivos.
+func (s *DividaService) UpdateDivida(userID uint, dividaID uint, req *types.DividaRequest) (*types.DividaDetalheResponse, error) {
+	divida, pagamentos, err := s.getDivida(userID, dividaID)
+	if err != nil {
+		return nil, err
+	}
+
+	anterior := *divida
+	if err := validarDivida(req, divida); err != nil {
+		return nil, err
+	}
+
+	if len(pagamentos) > 0 && (divida.Papel != anterior.Papel || divida.Principal != anterior.Principal ||
+		divida.TaxaJuros != anterior.TaxaJuros || divida.Parcelas != anterior.Parcelas ||
+		divida.Sistema != anterior.Sistema || !divida.DataInicio.Equal(anterior.DataInicio)) {
+		return nil, i18n.NovoErro(i18n.DividaComPagamentos)
+	}
+
+	if err := s.dividaDAL.UpdateDivida(divida); err != nil {
+		return nil, err
+	}
+
+	return detalharDivida(divida, pagamentos, s.periodoService.agora(userID)), nil
+}
+
+func (s *DividaService) DeleteDivida(userID uint, dividaID uint) error {
+	if _, _, err := s.getDivida(userID, dividaID); err != nil {
+		return err
+	}
+	return s.dividaDAL.DeleteDivida(dividaID, userID)
+}
+
+func (s *DividaService) GetCronograma(userID uint, dividaID uint) ([]types.ParcelaDividaResponse, error) {
+	divida, pagamentos, err := s.getDivida(userID, dividaID)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPago := 0.0
+	for _, pagamento := range pagamentos {
+		totalPago += pagamento.Valor
+	}
+
+	parcelas := gerarCronograma(divida)
+	marcarParcelasPagas(parcelas, totalPago)
+	return parcelas, nil
+}
+
+// GetEvolucaoSaldo compara, mês a mês até o mês corrente, o saldo previsto
+// no cronograma com o saldo real, considerando os pagamentos feitos.
+func (s *DividaService) GetEvolucaoSaldo(userID uint, dividaID uint) ([]types.SaldoDividaMesResponse, error) {
+	divida, pagamentos, err := s.getDivida(userID, dividaID)
+	if err != nil {
+		return nil, err
+	}
+
+	return evoluirSaldo(divida, pagamentos, gerarCronograma(divida), s.periodoService.agora(userID)), nil
+}
+
+// GetProjecaoQuitacao simula pagamentos mensais fixos a partir do próximo mês
+// até zerar o saldo atual. Sem valor informado, usa o da próxima parcela.
+func (s *DividaService) GetProjecaoQuitacao(userID uint, dividaID uint, valorMensal float64) (*types.ProjecaoQuitacaoResponse, error) {
+	divida, pagamentos, err := s.getDivida(userID, dividaID)
+	if err != nil {
+		return nil, err
+	}
+
+	hoje := s.periodoService.agora(userID)
+	calculada := calcularDivida(divida, pagamentos, hoje)
+	if calculada.Status == statusDividaQuitada {
+		return nil, i18n.NovoErro(i18n.DividaQuitada)
+	}
+
+	taxa := divida.TaxaJuros / 100
+	saldo := calculada.SaldoDevedor
+	if valorMensal <= 0 {
+		valorMensal = arredondar(saldo * (1 + taxa))
+		if calculada.ProximaParcela != nil {
+			valorMensal = calculada.ProximaParcela.Valor
+		}
+	}
+
+	if valorMensal <= saldo*taxa+toleranciaCentavos {
+		return nil, i18n.NovoErro(i18n.DividaValorMensalInsuficiente)
+	}
+
+	projecao := &types.ProjecaoQuitacaoResponse{
+		SaldoAtual:  saldo,
+		ValorMensal: valorMensal,
+	}
+	for saldo > toleranciaCentavos && projecao.Meses < maxMesesProjecao {
+		juros := saldo * taxa
+		saldo += juros
+		pagamento := math.Min(valorMensal, saldo)
+		saldo -= pagamento
+
+		projecao.Meses++
+		projecao.TotalJuros += juros
+		projecao.TotalAPagar += pagamento
+	}
+
+	projecao.MesQuitacao = formatMonthYear(inicioDoMes(hoje).AddDate(0, projecao.Meses, 0))
+	projecao.TotalJuros = arredondar(projecao.TotalJuros)
+	projecao.TotalAPagar = arredondar(projecao.TotalAPagar)
+	return projecao, nil
+}
+
+// RegistrarPagamento registra um pagamento (ou recebimento, para o credor).
+// Sem valor, paga o que falta da próxima parcela. O pagamento gera uma
+// despesa na categoria da dívida; o recebimento gera uma receita.
+func (s *DividaService) RegistrarPagamento(userID uint, dividaID uint, req *types.PagamentoDividaRequest) (*types.DividaDetalheResponse, error) {
+	divida, pagamentos, err := s.getDivida(userID, dividaID)
+	if err != nil {
+		return nil, err
+	}
+
+	hoje := s.periodoService.agora(userID)
+	data := inicioDoDia(hoje)
+	if req.Data != "" {
+		if data, err = time.Parse("2006-01-02", req.Data); err != nil {
+			return nil, i18n.NovoErro(i18n.DataInvalida)
+		}
+		if data.After(inicioDoDia(hoje)) {
+			return nil, i18n.NovoErro(i18n.PagamentoDataFutura)
+		}
+	}
+
+	calculada := calcularDivida(divida, pagamentos, hoje)
+	if calculada.Status == statusDividaQuitada {
+		return nil, i18n.NovoErro(i18n.DividaQuitada)
+	}
+
+	valor := req.Valor
+	if valor == 0 {
+		valor = calculada.SaldoDevedor
+		if proxima := calculada.ProximaParcela; proxima != nil {
+			// O que falta da próxima parcela, descontando pagamentos parciais
+			previsto := 0.0
+			for _, parcela := range gerarCronograma(divida)[:proxima.Numero] {
+				previsto += parcela.Valor
+			}
+			valor = math.Min(arredondar(previsto-calculada.TotalPago), calculada.SaldoDevedor)
+		}
+	}
+	if valor <= 0 {
+		return nil, i18n.NovoErro(i18n.ValorNaoPositivo)
+	}
+	if valor > calculada.SaldoDevedor+toleranciaCentavos {
+		return nil, i18n.NovoErro(i18n.DividaValorExcedeSaldo, formatarMoeda(calculada.SaldoDevedor, s.periodoService.preferenciaService.preferencias(userID)))
+	}
+
+	pagamento := &types.PagamentoDivida{
+		DividaID: divida.ID,
+		UserID:   userID,
+		Valor:    valor,
+		Data:     data,
+	}
+
+	descricao := divida.Descricao
+	if calculada.ProximaParcela != nil {
+		descricao = fmt.Sprintf("%s - parcela %d/%d", divida.Descricao, calculada.ProximaParcela.Numero, divida.Parcelas)
+	}
+
+	if divida.Papel == papelDividaCredor {
+		receita := &types.Receita{
+			Descricao:     descricao,
+			Valor:         valor,
+			MesReferencia: s.periodoService.resolvedor(userID).mesReferenciaDe(data),
+			Data:          &data,
+			UserID:        userID,
+		}
+		if err := s.dividaDAL.CreatePagamentoComReceita(pagamento, receita); err != nil {
+			return nil, err
+		}
+	} else {
+		_, err := s.despesaService.criarDespesaVinculada(userID, &types.CreateDespesaRequest{
+			Descricao: descricao,
+			Valor:     valor,
+			Data:      data.Format("2006-01-02"),
+			Categoria: divida.Categoria,
+		}, func(txDAL *dal.DespesaDAL, despesa *types.DespesaSimpleResponse) error {
+			pagamento.DespesaID = &despesa.ID
+			return s.dividaDAL.NaTransacao(txDAL).CreatePagamento(pagamento)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return detalharDivida(divida, append(pagamentos, *pagamento), hoje), nil
+}
+
+// DeletePagamento desfaz o pagamento junto com a despesa ou receita gerada.
+func (s *DividaService) DeletePagamento(userID uint, dividaID uint, pagamentoID uint) (*types.DividaDetalheResponse, error) {
+	if _, _, err := s.getDivida(userID, dividaID); err != nil {
+		return nil, err
+	}
+
+	pagamento, err := s.dividaDAL.GetPagamentoByID(pagamentoID, dividaID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, i18n.NovoErro(i18n.PagamentoNaoEncontrado)
+		}
+		return nil, err
+	}
+
+	if pagamento.DespesaID != nil {
+		err = s.despesaService.excluirDespesaVinculada(userID, *pagamento.DespesaID, func(txDAL *dal.DespesaDAL) error {
+			return s.dividaDAL.NaTransacao(txDAL).DeletePagamento(pagamento)
+		})
+	} else {
+		err = s.dividaDAL.DeletePagamento(pagamento)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetDividaByID(userID, dividaID)
+}
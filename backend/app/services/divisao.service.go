@@ -0,0 +1,741 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const (
+	tipoDivisaoIgual      = "igual"
+	tipoDivisaoPercentual = "percentual"
+	tipoDivisaoValor      = "valor"
+)
+
+type DivisaoService struct {
+	divisaoDAL     *dal.DivisaoDAL
+	authDAL        *dal.AuthDAL
+	periodoService *PeriodoService
+}
+
+func NewDivisaoService(divisaoDAL *dal.DivisaoDAL, authDAL *dal.AuthDAL, periodoService *PeriodoService) *DivisaoService {
+	return &DivisaoService{divisaoDAL: divisaoDAL, authDAL: authDAL, periodoService: periodoService}
+}
+
+// Os cálculos de divisão e saldo são feitos em centavos para que as partes
+// sempre somem exatamente o valor total.
+func centavos(valor float64) int64 {
+	return int64(math.Round(valor * 100))
+}
+
+func reais(centavos int64) float64 {
+	return float64(centavos) / 100
+}
+
+// dividirValor calcula a parte de cada participante. Tudo é comparado em
+// inteiros (centavos e centésimos de ponto percentual), e a diferença de
+// arredondamento é acertada um centavo por vez a partir dos primeiros
+// participantes da lista, para que as partes somem exatamente o total.
+func dividirValor(valor float64, tipo string, participantes []types.ParticipanteDivisaoRequest) ([]types.ParticipanteDivisao, error) {
+	total := centavos(valor)
+	partes := make([]int64, len(participantes))
+
+	switch tipo {
+	case tipoDivisaoIgual:
+		for i := range participantes {
+			partes[i] = total / int64(len(participantes))
+		}
+	case tipoDivisaoPercentual:
+		centesimos := make([]int64, len(participantes))
+		soma := int64(0)
+		for i, participante := range participantes {
+			centesimos[i] = int64(math.Round(participante.Percentual * 100))
+			if centesimos[i] <= 0 {
+				return nil, i18n.NovoErro(i18n.DivisaoPercentualNaoPositivo)
+			}
+			soma += centesimos[i]
+		}
+		if soma != 100*100 {
+			return nil, i18n.NovoErro(i18n.DivisaoSomaPercentuais, float64(soma)/100)
+		}
+		for i := range participantes {
+			partes[i] = total * centesimos[i] / (100 * 100)
+		}
+	case tipoDivisaoValor:
+		soma := int64(0)
+		for i, participante := range participantes {
+			partes[i] = centavos(participante.Valor)
+			if partes[i] <= 0 {
+				return nil, i18n.NovoErro(i18n.DivisaoValorNaoPositivo)
+			}
+			soma += partes[i]
+		}
+		if soma != total {
+			return nil, i18n.NovoErro(i18n.DivisaoSomaValores, reais(soma), reais(total))
+		}
+	default:
+		return nil, i18n.NovoErro(i18n.DivisaoTipoInvalido)
+	}
+
+	atribuido := int64(0)
+	for _, parte := range partes {
+		atribuido += parte
+	}
+	for i := 0; atribuido != total; i = (i + 1) % len(partes) {
+		if atribuido < total {
+			partes[i]++
+			atribuido++
+		} else if partes[i] > 0 {
+			partes[i]--
+			atribuido--
+		}
+	}
+
+	resultado := make([]types.ParticipanteDivisao, len(participantes))
+	for i, participante := range participantes {
+		resultado[i] = types.ParticipanteDivisao{UserID: participante.UserID, Valor: reais(partes[i])}
+		if tipo == tipoDivisaoPercentual {
+			percentual := participante.Percentual
+			resultado[i].Percentual = &percentual
+		}
+	}
+	return resultado, nil
+}
+
+// calcularSaldos retorna, em centavos, quanto cada pessoa tem a receber
+// (positivo) ou a pagar (negativo) no grupo.
+func calcularSaldos(divisoes []types.Divisao, acertos []types.AcertoDivisao) map[uint]int64 {
+	saldos := make(map[uint]int64)
+	for _, divisao := range divisoes {
+		saldos[divisao.PagadorID] += centavos(divisao.Valor)
+		for _, participante := range divisao.Participantes {
+			saldos[participante.UserID] -= centavos(participante.Valor)
+		}
+	}
+	for _, acerto := range acertos {
+		saldos[acerto.DeID] += centavos(acerto.Valor)
+		saldos[acerto.ParaID] -= centavos(acerto.Valor)
+	}
+	return saldos
+}
+
+type transferencia struct {
+	deID   uint
+	paraID uint
+	valor  int64
+}
+
+// simplificarDividas sugere as transferências que zeram os saldos: a cada
+// passo, quem mais deve paga a quem mais tem a receber. Com n pessoas, são no
+// máximo n-1 transferências.
+func simplificarDividas(saldos map[uint]int64) []transferencia {
+	type pendente struct {
+		userID uint
+		valor  int64
+	}
+
+	var credores, devedores []pendente
+	for userID, saldo := range saldos {
+		switch {
+		case saldo > 0:
+			credores = append(credores, pendente{userID, saldo})
+		case saldo < 0:
+			devedores = append(devedores, pendente{userID, -saldo})
+		}
+	}
+
+	ordenar := func(lista []pendente) {
+		sort.Slice(lista, func(i, j int) bool {
+			if lista[i].valor != lista[j].valor {
+				return lista[i].valor > lista[j].valor
+			}
+			return lista[i].userID < lista[j].userID
+		})
+	}
+
+	var transferencias []transferencia
+	for len(credores) > 0 && len(devedores) > 0 {
+		ordenar(credores)
+		ordenar(devedores)
+
+		valor := credores[0].valor
+		if devedores[0].valor < valor {
+			valor = devedores[0].valor
+		}
+		transferencias = append(transferencias, transferencia{deID: devedores[0].userID, paraID: credores[0].userID, valor: valor})
+
+		credores[0].valor -= valor
+		devedores[0].valor -= valor
+		if credores[0].valor == 0 {
+			credores = credores[1:]
+		}
+		if devedores[0].valor == 0 {
+			devedores = devedores[1:]
+		}
+	}
+
+	return transferencias
+}
+
+// parseDataDivisao usa o dia de `agora` quando a data não é informada.
+func parseDataDivisao(data string, agora time.Time) (time.Time, error) {
+	if data == "" {
+		return inicioDoDia(agora), nil
+	}
+	parsed, err := time.Parse("2006-01-02", data)
+	if err != nil {
+		return time.Time{}, i18n.NovoErro(i18n.DataInvalida)
+	}
+	return parsed, nil
+}
+
+func (s *DivisaoService) getGrupo(userID uint, grupoID uint) (*types.GrupoDivisao, error) {
+	grupo, err := s.divisaoDAL.GetGrupoByID(grupoID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, i18n.NovoErro(i18n.GrupoNaoEncontrado)
+		}
+		return nil, err
+	}
+	return grupo, nil
+}
+
+func (s *DivisaoService) getUserIDPorEmail(email string) (uint, error) {
+	endereco, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil {
+		return 0, i18n.NovoErro(i18n.EmailInvalidoDetalhe, email)
+	}
+
+	usuario, err := s.authDAL.GetUserByEmail(strings.ToLower(endereco.Address))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, i18n.NovoErro(i18n.EmailNaoCadastrado, endereco.Address)
+		}
+		return 0, err
+	}
+	return usuario.ID, nil
+}
+
+// nomesDoGrupo mapeia os usuários do grupo pelo ID. Ex-membros que ainda
+// aparecem em divisões ou acertos são buscados à parte.
+func (s *DivisaoService) nomesDoGrupo(membros []types.MembroGrupoDivisao, saldos map[uint]int64) map[uint]types.User {
+	usuarios := make(map[uint]types.User)
+	for _, membro := range membros {
+		usuarios[membro.UserID] = membro.User
+	}
+	for userID := range saldos {
+		if _, ok := usuarios[userID]; ok {
+			continue
+		}
+		if usuario, err := s.authDAL.GetUserByID(userID); err == nil {
+			usuarios[userID] = *usuario
+		}
+	}
+	return usuarios
+}
+
+func (s *DivisaoService) carregarSaldos(grupoID uint) (map[uint]int64, error) {
+	divisoes, err := s.divisaoDAL.GetDivisoesByGrupo(grupoID)
+	if err != nil {
+		return nil, err
+	}
+	acertos, err := s.divisaoDAL.GetAcertosByGrupo(grupoID)
+	if err != nil {
+		return nil, err
+	}
+	return calcularSaldos(divisoes, acertos), nil
+}
+
+func (s *DivisaoService) toGrupoResponse(userID uint, grupo *types.GrupoDivisao) (*types.GrupoDivisaoResponse, error) {
+	membros, err := s.divisaoDAL.GetMembros(grupo.ID)
+	if err != nil {
+		return nil, err
+	}
+	saldos, err := s.carregarSaldos(grupo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.GrupoDivisaoResponse{
+		ID:       grupo.ID,
+		Nome:     grupo.Nome,
+		MeuSaldo: reais(saldos[userID]),
+		Membros:  make([]types.MembroGrupoDivisaoResponse, 0, len(membros)),
+	}
+	for _, membro := range membros {
+		response.Membros = append(response.Membros, types.MembroGrupoDivisaoResponse{
+			UserID: membro.UserID,
+			Nome:   membro.User.Nome,
+			Email:  membro.User.Email,
+			Saldo:  reais(saldos[membro.UserID]),
+		})
+	}
+	return response, nil
+}
+
+func (s *DivisaoService) CreateGrupo(userID uint, req *types.GrupoDivisaoRequest) (*types.GrupoDivisaoResponse, error) {
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return nil, i18n.NovoErro(i18n.NomeObrigatorio)
+	}
+
+	userIDs := []uint{userID}
+	for _, email := range req.Emails {
+		membroID, err := s.getUserIDPorEmail(email)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, membroID)
+	}
+
+	grupo := &types.GrupoDivisao{Nome: nome, CriadoPorID: userID}
+	if err := s.divisaoDAL.CreateGrupo(grupo, userIDs); err != nil {
+		return nil, err
+	}
+
+	return s.toGrupoResponse(userID, grupo)
+}
+
+func (s *DivisaoService) GetGruposByUser(userID uint) ([]types.GrupoDivisaoResponse, error) {
+	grupos, err := s.divisaoDAL.GetGruposByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.GrupoDivisaoResponse
+	for i := range grupos {
+		grupo, err := s.toGrupoResponse(userID, &grupos[i])
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, *grupo)
+	}
+	return response, nil
+}
+
+func (s *DivisaoService) GetGrupoByID(userID uint, grupoID uint) (*types.GrupoDivisaoResponse, error) {
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toGrupoResponse(userID, grupo)
+}
+
+func (s *DivisaoService) UpdateGrupo(userID uint, grupoID uint, req *types.GrupoDivisaoRequest) (*types.GrupoDivisaoResponse, error) {
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return nil, i18n.NovoErro(i18n.NomeObrigatorio)
+	}
+
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return nil, err
+	}
+
+	grupo.Nome = nome
+	if err := s.divisaoDAL.UpdateGrupo(grupo); err != nil {
+		return nil, err
+	}
+	return s.toGrupoResponse(userID, grupo)
+}
+
+// DeleteGrupo só é permitido a quem criou o grupo e depois que todos os
+// saldos foram acertados.
+func (s *DivisaoService) DeleteGrupo(userID uint, grupoID uint) error {
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return err
+	}
+	if grupo.CriadoPorID != userID {
+		return i18n.NovoErro(i18n.GrupoExcluirSemPermissao)
+	}
+
+	saldos, err := s.carregarSaldos(grupo.ID)
+	if err != nil {
+		return err
+	}
+	for _, saldo := range saldos {
+		if saldo != 0 {
+			return i18n.NovoErro(i18n.GrupoSaldosPendentes)
+		}
+	}
+
+	return s.divisaoDAL.DeleteGrupo(grupo.ID)
+}
+
+func (s *DivisaoService) AddMembro(userID uint, grupoID uint, req *types.MembroGrupoDivisaoRequest) (*types.GrupoDivisaoResponse, error) {
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return nil, err
+	}
+
+	membroID, err := s.getUserIDPorEmail(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.divisaoDAL.AddMembro(&types.MembroGrupoDivisao{GrupoID: grupo.ID, UserID: membroID}); err != nil {
+		return nil, err
+	}
+	return s.toGrupoResponse(userID, grupo)
+}
+
+// RemoverMembro tira alguém do grupo (ou o próprio usuário, para sair). Só
+// quem criou o grupo remove outros membros, e o saldo de quem sai precisa
+// estar zerado.
+func (s *DivisaoService) RemoverMembro(userID uint, grupoID uint, membroID uint) error {
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return err
+	}
+	if membroID != userID && grupo.CriadoPorID != userID {
+		return i18n.NovoErro(i18n.GrupoRemoverMembroSemPermissao)
+	}
+
+	membros, err := s.divisaoDAL.GetMembros(grupo.ID)
+	if err != nil {
+		return err
+	}
+	encontrado := false
+	for _, membro := range membros {
+		if membro.UserID == membroID {
+			encontrado = true
+			break
+		}
+	}
+	if !encontrado {
+		return i18n.NovoErro(i18n.MembroNaoEncontrado)
+	}
+
+	saldos, err := s.carregarSaldos(grupo.ID)
+	if err != nil {
+		return err
+	}
+	if saldos[membroID] != 0 {
+		return i18n.NovoErro(i18n.GrupoSaldoMembroPendente)
+	}
+
+	return s.divisaoDAL.DeleteMembro(grupo.ID, membroID)
+}
+
+func toDivisaoResponse(divisao *types.Divisao, usuarios map[uint]types.User) types.DivisaoResponse {
+	response := types.DivisaoResponse{
+		ID:            divisao.ID,
+		Descricao:     divisao.Descricao,
+		Valor:         divisao.Valor,
+		Tipo:          divisao.Tipo,
+		Data:          divisao.Data.Format("2006-01-02"),
+		PagadorID:     divisao.PagadorID,
+		Pagador:       usuarios[divisao.PagadorID].Nome,
+		Participantes: make([]types.ParticipanteDivisaoResponse, 0, len(divisao.Participantes)),
+	}
+	for _, participante := range divisao.Participantes {
+		response.Participantes = append(response.Participantes, types.ParticipanteDivisaoResponse{
+			UserID:     participante.UserID,
+			Nome:       usuarios[participante.UserID].Nome,
+			Valor:      participante.Valor,
+			Percentual: participante.Percentual,
+		})
+	}
+	return response
+}
+
+func (s *DivisaoService) CreateDivisao(userID uint, grupoID uint, req *types.DivisaoRequest) (*types.DivisaoResponse, error) {
+	descricao := strings.TrimSpace(req.Descricao)
+	if descricao == "" {
+		return nil, i18n.NovoErro(i18n.DescricaoObrigatoria)
+	}
+	if req.Valor <= 0 {
+		return nil, i18n.NovoErro(i18n.ValorNaoPositivo)
+	}
+	if len(req.Participantes) == 0 {
+		return nil, i18n.NovoErro(i18n.DivisaoSemParticipantes)
+	}
+
+	tipo := strings.ToLower(strings.TrimSpace(req.Tipo))
+	if tipo == "" {
+		tipo = tipoDivisaoIgual
+	}
+
+	data, err := parseDataDivisao(req.Data, s.periodoService.agora(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return nil, err
+	}
+
+	membros, err := s.divisaoDAL.GetMembros(grupo.ID)
+	if err != nil {
+		return nil, err
+	}
+	usuarios := s.nomesDoGrupo(membros, nil)
+
+	pagadorID := req.PagadorID
+	if pagadorID == 0 {
+		pagadorID = userID
+	}
+	if _, ok := usuarios[pagadorID]; !ok {
+		return nil, i18n.NovoErro(i18n.GrupoPagadorNaoMembro)
+	}
+
+	vistos := make(map[uint]bool)
+	for _, participante := range req.Participantes {
+		if _, ok := usuarios[participante.UserID]; !ok {
+			return nil, i18n.NovoErro(i18n.GrupoUsuarioNaoMembro, participante.UserID)
+		}
+		if vistos[participante.UserID] {
+			return nil, i18n.NovoErro(i18n.DivisaoParticipanteRepetido)
+		}
+		vistos[participante.UserID] = true
+	}
+
+	partes, err := dividirValor(req.Valor, tipo, req.Participantes)
+	if err != nil {
+		return nil, err
+	}
+
+	divisao := &types.Divisao{
+		GrupoID:       grupo.ID,
+		PagadorID:     pagadorID,
+		CriadaPorID:   userID,
+		Descricao:     descricao,
+		Valor:         reais(centavos(req.Valor)),
+		Tipo:          tipo,
+		Data:          data,
+		Participantes: partes,
+	}
+	if err := s.divisaoDAL.CreateDivisao(divisao); err != nil {
+		return nil, err
+	}
+
+	response := toDivisaoResponse(divisao, usuarios)
+	return &response, nil
+}
+
+func (s *DivisaoService) GetDivisoes(userID uint, grupoID uint) ([]types.DivisaoResponse, error) {
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return nil, err
+	}
+
+	membros, err := s.divisaoDAL.GetMembros(grupo.ID)
+	if err != nil {
+		return nil, err
+	}
+	divisoes, err := s.divisaoDAL.GetDivisoesByGrupo(grupo.ID)
+	if err != nil {
+		return nil, err
+	}
+	usuarios := s.nomesDoGrupo(membros, calcularSaldos(divisoes, nil))
+
+	var response []types.DivisaoResponse
+	for i := range divisoes {
+		response = append(response, toDivisaoResponse(&divisoes[i], usuarios))
+	}
+	return response, nil
+}
+
+// DeleteDivisao pode ser feito por quem lançou a divisão ou por quem pagou.
+func (s *DivisaoService) DeleteDivisao(userID uint, grupoID uint, divisaoID uint) error {
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return err
+	}
+
+	divisao, err := s.divisaoDAL.GetDivisaoByID(divisaoID, grupo.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return i18n.NovoErro(i18n.DivisaoNaoEncontrada)
+		}
+		return err
+	}
+	if divisao.CriadaPorID != userID && divisao.PagadorID != userID {
+		return i18n.NovoErro(i18n.DivisaoExcluirSemPermissao)
+	}
+
+	return s.divisaoDAL.DeleteDivisao(divisao.ID, grupo.ID)
+}
+
+func (s *DivisaoService) GetSaldos(userID uint, grupoID uint) (*types.SaldosGrupoResponse, error) {
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return nil, err
+	}
+
+	membros, err := s.divisaoDAL.GetMembros(grupo.ID)
+	if err != nil {
+		return nil, err
+	}
+	saldos, err := s.carregarSaldos(grupo.ID)
+	if err != nil {
+		return nil, err
+	}
+	usuarios := s.nomesDoGrupo(membros, saldos)
+
+	response := &types.SaldosGrupoResponse{
+		Membros:        make([]types.MembroGrupoDivisaoResponse, 0, len(membros)),
+		Transferencias: []types.TransferenciaResponse{},
+	}
+	for _, membro := range membros {
+		response.Membros = append(response.Membros, types.MembroGrupoDivisaoResponse{
+			UserID: membro.UserID,
+			Nome:   membro.User.Nome,
+			Email:  membro.User.Email,
+			Saldo:  reais(saldos[membro.UserID]),
+		})
+	}
+	for _, t := range simplificarDividas(saldos) {
+		response.Transferencias = append(response.Transferencias, types.TransferenciaResponse{
+			DeID:   t.deID,
+			De:     usuarios[t.deID].Nome,
+			ParaID: t.paraID,
+			Para:   usuarios[t.paraID].Nome,
+			Valor:  reais(t.valor),
+		})
+	}
+
+	return response, nil
+}
+
+func toAcertoDivisaoResponse(acerto *types.AcertoDivisao, usuarios map[uint]types.User) types.AcertoDivisaoResponse {
+	return types.AcertoDivisaoResponse{
+		ID:         acerto.ID,
+		DeID:       acerto.DeID,
+		De:         usuarios[acerto.DeID].Nome,
+		ParaID:     acerto.ParaID,
+		Para:       usuarios[acerto.ParaID].Nome,
+		Valor:      acerto.Valor,
+		Data:       acerto.Data.Format("2006-01-02"),
+		Observacao: acerto.Observacao,
+	}
+}
+
+// RegistrarAcerto grava um pagamento entre dois membros. Quem registra
+// precisa ser um dos dois; sem valor, vale a transferência sugerida entre
+// eles na simplificação das dívidas.
+func (s *DivisaoService) RegistrarAcerto(userID uint, grupoID uint, req *types.AcertoDivisaoRequest) (*types.AcertoDivisaoResponse, error) {
+	deID := req.DeID
+	if deID == 0 {
+		deID = userID
+	}
+	if req.ParaID == 0 {
+		return nil, i18n.NovoErro(i18n.AcertoSemDestinatario)
+	}
+	if deID == req.ParaID {
+		return nil, i18n.NovoErro(i18n.AcertoMesmaPessoa)
+	}
+	if deID != userID && req.ParaID != userID {
+		return nil, i18n.NovoErro(i18n.AcertoSemParticipacao)
+	}
+	if req.Valor < 0 {
+		return nil, i18n.NovoErro(i18n.ValorNaoPositivo)
+	}
+
+	data, err := parseDataDivisao(req.Data, s.periodoService.agora(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return nil, err
+	}
+
+	membros, err := s.divisaoDAL.GetMembros(grupo.ID)
+	if err != nil {
+		return nil, err
+	}
+	usuarios := s.nomesDoGrupo(membros, nil)
+	if _, ok := usuarios[deID]; !ok {
+		return nil, i18n.NovoErro(i18n.GrupoPagadorNaoMembro)
+	}
+	if _, ok := usuarios[req.ParaID]; !ok {
+		return nil, i18n.NovoErro(i18n.GrupoRecebedorNaoMembro)
+	}
+
+	valor := centavos(req.Valor)
+	if valor == 0 {
+		saldos, err := s.carregarSaldos(grupo.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range simplificarDividas(saldos) {
+			if t.deID == deID && t.paraID == req.ParaID {
+				valor = t.valor
+			}
+		}
+		if valor == 0 {
+			return nil, i18n.NovoErro(i18n.AcertoSemValorPendente)
+		}
+	}
+
+	acerto := &types.AcertoDivisao{
+		GrupoID:     grupo.ID,
+		DeID:        deID,
+		ParaID:      req.ParaID,
+		Valor:       reais(valor),
+		Data:        data,
+		Observacao:  strings.TrimSpace(req.Observacao),
+		CriadoPorID: userID,
+	}
+	if err := s.divisaoDAL.CreateAcerto(acerto); err != nil {
+		return nil, err
+	}
+
+	response := toAcertoDivisaoResponse(acerto, usuarios)
+	return &response, nil
+}
+
+func (s *DivisaoService) GetAcertos(userID uint, grupoID uint) ([]types.AcertoDivisaoResponse, error) {
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return nil, err
+	}
+
+	membros, err := s.divisaoDAL.GetMembros(grupo.ID)
+	if err != nil {
+		return nil, err
+	}
+	acertos, err := s.divisaoDAL.GetAcertosByGrupo(grupo.ID)
+	if err != nil {
+		return nil, err
+	}
+	usuarios := s.nomesDoGrupo(membros, calcularSaldos(nil, acertos))
+
+	var response []types.AcertoDivisaoResponse
+	for i := range acertos {
+		response = append(response, toAcertoDivisaoResponse(&acertos[i], usuarios))
+	}
+	return response, nil
+}
+
+func (s *DivisaoService) DeleteAcerto(userID uint, grupoID uint, acertoID uint) error {
+	grupo, err := s.getGrupo(userID, grupoID)
+	if err != nil {
+		return err
+	}
+
+	acerto, err := s.divisaoDAL.GetAcertoByID(acertoID, grupo.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return i18n.NovoErro(i18n.AcertoNaoEncontrado)
+		}
+		return err
+	}
+	if acerto.DeID != userID && acerto.ParaID != userID {
+		return i18n.NovoErro(i18n.AcertoExcluirSemPermissao)
+	}
+
+	return s.divisaoDAL.DeleteAcerto(acerto.ID, grupo.ID)
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+)
+
+func TestDividirValor(t *testing.T) {
+	casos := []struct {
+		nome          string
+		valor         float64
+		tipo          string
+		participantes []types.ParticipanteDivisaoRequest
+		partes        []float64
+		erro          i18n.Codigo
+	}{
+		{
+			nome:          "igual exata",
+			valor:         90,
+			tipo:          tipoDivisaoIgual,
+			participantes: []types.ParticipanteDivisaoRequest{{UserID: 1}, {UserID: 2}, {UserID: 3}},
+			partes:        []float64{30, 30, 30},
+		},
+		{
+			nome:          "igual com sobra de centavos",
+			valor:         100,
+			tipo:          tipoDivisaoIgual,
+			participantes: []types.ParticipanteDivisaoRequest{{UserID: 1}, {UserID: 2}, {UserID: 3}},
+			partes:        []float64{33.34, 33.33, 33.33},
+		},
+		{
+			nome:          "igual com um centavo",
+			valor:         0.01,
+			tipo:          tipoDivisaoIgual,
+			participantes: []types.ParticipanteDivisaoRequest{{UserID: 1}, {UserID: 2}},
+			partes:        []float64{0.01, 0},
+		},
+		{
+			nome:          "percentual",
+			valor:         200,
+			tipo:          tipoDivisaoPercentual,
+			participantes: []types.ParticipanteDivisaoRequest{{UserID: 1, Percentual: 70}, {UserID: 2, Percentual: 30}},
+			partes:        []float64{140, 60},
+		},
+		{
+			nome:  "percentual com arredondamento",
+			valor: 100,
+			tipo:  tipoDivisaoPercentual,
+			participantes: []types.ParticipanteDivisaoRequest{
+				{UserID: 1, Percentual: 33.33}, {UserID: 2, Percentual: 33.33}, {UserID: 3, Percentual: 33.34},
+			},
+			partes: []float64{33.33, 33.33, 33.34},
+		},
+		{
+			nome:  "percentual com centavos a distribuir",
+			valor: 10.01,
+			tipo:  tipoDivisaoPercentual,
+			participantes: []types.ParticipanteDivisaoRequest{
+				{UserID: 1, Percentual: 50}, {UserID: 2, Percentual: 50},
+			},
+			partes: []float64{5.01, 5},
+		},
+		{
+			nome:  "percentual somando 100.01",
+			valor: 100,
+			tipo:  tipoDivisaoPercentual,
+			participantes: []types.ParticipanteDivisaoRequest{
+				{UserID: 1, Percentual: 50.01}, {UserID: 2, Percentual: 50},
+			},
+			erro: i18n.DivisaoSomaPercentuais,
+		},
+		{
+			nome:  "percentual somando 99.99",
+			valor: 100,
+			tipo:  tipoDivisaoPercentual,
+			participantes: []types.ParticipanteDivisaoRequest{
+				{UserID: 1, Percentual: 49.99}, {UserID: 2, Percentual: 50},
+			},
+			erro: i18n.DivisaoSomaPercentuais,
+		},
+		{
+			nome:          "percentual zerado",
+			valor:         100,
+			tipo:          tipoDivisaoPercentual,
+			participantes: []types.ParticipanteDivisaoRequest{{UserID: 1, Percentual: 100}, {UserID: 2}},
+			erro:          i18n.DivisaoPercentualNaoPositivo,
+		},
+		{
+			nome:          "valores exatos",
+			valor:         150.5,
+			tipo:          tipoDivisaoValor,
+			participantes: []types.ParticipanteDivisaoRequest{{UserID: 1, Valor: 100.25}, {UserID: 2, Valor: 50.25}},
+			partes:        []float64{100.25, 50.25},
+		},
+		{
+			nome:          "valores que não fecham o total",
+			valor:         150,
+			tipo:          tipoDivisaoValor,
+			participantes: []types.ParticipanteDivisaoRequest{{UserID: 1, Valor: 100}, {UserID: 2, Valor: 50.01}},
+			erro:          i18n.DivisaoSomaValores,
+		},
+		{
+			nome:          "valor zerado",
+			valor:         100,
+			tipo:          tipoDivisaoValor,
+			participantes: []types.ParticipanteDivisaoRequest{{UserID: 1, Valor: 100}, {UserID: 2}},
+			erro:          i18n.DivisaoValorNaoPositivo,
+		},
+		{
+			nome:          "tipo inválido",
+			valor:         100,
+			tipo:          "metade",
+			participantes: []types.ParticipanteDivisaoRequest{{UserID: 1}},
+			erro:          i18n.DivisaoTipoInvalido,
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			partes, err := dividirValor(caso.valor, caso.tipo, caso.participantes)
+			if caso.erro != "" {
+				if !i18n.TemCodigo(err, caso.erro) {
+					t.Fatalf("esperava o erro %s, veio %v", caso.erro, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if len(partes) != len(caso.partes) {
+				t.Fatalf("esperava %d partes, vieram %d", len(caso.partes), len(partes))
+			}
+
+			soma := int64(0)
+			for i, parte := range partes {
+				if parte.UserID != caso.participantes[i].UserID {
+					t.Errorf("parte %d: usuário %d, esperava %d", i, parte.UserID, caso.participantes[i].UserID)
+				}
+				if centavos(parte.Valor) != centavos(caso.partes[i]) {
+					t.Errorf("parte %d: %.2f, esperava %.2f", i, parte.Valor, caso.partes[i])
+				}
+				soma += centavos(parte.Valor)
+			}
+			if soma != centavos(caso.valor) {
+				t.Errorf("as partes somam %d centavos, esperava %d", soma, centavos(caso.valor))
+			}
+		})
+	}
+}
+
+func TestSimplificarDividas(t *testing.T) {
+	casos := []struct {
+		nome           string
+		saldos         map[uint]int64
+		transferencias []transferencia
+	}{
+		{
+			nome:   "saldos zerados",
+			saldos: map[uint]int64{1: 0, 2: 0},
+		},
+		{
+			nome:           "uma dívida",
+			saldos:         map[uint]int64{1: 5000, 2: -5000},
+			transferencias: []transferencia{{deID: 2, paraID: 1, valor: 5000}},
+		},
+		{
+			nome:   "um credor e dois devedores",
+			saldos: map[uint]int64{1: 6000, 2: -4000, 3: -2000},
+			transferencias: []transferencia{
+				{deID: 2, paraID: 1, valor: 4000},
+				{deID: 3, paraID: 1, valor: 2000},
+			},
+		},
+		{
+			nome:   "cadeia vira uma transferência",
+			saldos: map[uint]int64{1: 1000, 2: 0, 3: -1000},
+			transferencias: []transferencia{
+				{deID: 3, paraID: 1, valor: 1000},
+			},
+		},
+		{
+			nome:   "quatro pessoas em no máximo três transferências",
+			saldos: map[uint]int64{1: 7000, 2: 3000, 3: -4000, 4: -6000},
+			transferencias: []transferencia{
+				{deID: 4, paraID: 1, valor: 6000},
+				{deID: 3, paraID: 2, valor: 3000},
+				{deID: 3, paraID: 1, valor: 1000},
+			},
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			transferencias := simplificarDividas(caso.saldos)
+			if len(transferencias) != len(caso.transferencias) {
+				t.Fatalf("esperava %d transferências, vieram %v", len(caso.transferencias), transferencias)
+			}
+			for i := range transferencias {
+				if transferencias[i] != caso.transferencias[i] {
+					t.Errorf("transferência %d: %+v, esperava %+v", i, transferencias[i], caso.transferencias[i])
+				}
+			}
+
+			restantes := make(map[uint]int64)
+			for userID, saldo := range caso.saldos {
+				restantes[userID] = saldo
+			}
+			for _, transferencia := range transferencias {
+				restantes[transferencia.deID] += transferencia.valor
+				restantes[transferencia.paraID] -= transferencia.valor
+			}
+			for userID, saldo := range restantes {
+				if saldo != 0 {
+					t.Errorf("usuário %d ficou com saldo %d", userID, saldo)
+				}
+			}
+		})
+	}
+}
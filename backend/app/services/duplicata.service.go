@@ -0,0 +1,327 @@
+package services
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+)
+
+const (
+	similaridadeMinimaDuplicata = 0.75
+	diasMaximosEntreDuplicatas  = 3
+	maxDespesasMesclagem        = 50
+)
+
+type DuplicataError struct {
+	Candidatos []types.DespesaSimpleResponse
+}
+
+func (e *DuplicataError) Error() string {
+	return e.Unwrap().Error()
+}
+
+// Unwrap expõe o código do erro para a tradução da resposta.
+func (e *DuplicataError) Unwrap() error {
+	return i18n.NovoErro(i18n.DespesaDuplicada)
+}
+
+func normalizarDescricao(descricao string) string {
+	descricao = normalizarTexto(descricao)
+	return strings.Join(strings.FieldsFunc(descricao, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func distanciaLevenshtein(a, b []rune) int {
+	anterior := make([]int, len(b)+1)
+	atual := make([]int, len(b)+1)
+	for j := range anterior {
+		anterior[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		atual[0] = i
+		for j := 1; j <= len(b); j++ {
+			custo := 1
+			if a[i-1] == b[j-1] {
+				custo = 0
+			}
+			atual[j] = min(anterior[j]+1, atual[j-1]+1, anterior[j-1]+custo)
+		}
+		anterior, atual = atual, anterior
+	}
+
+	return anterior[len(b)]
+}
+
+func similaridadeDescricao(a, b string) float64 {
+	a, b = normalizarDescricao(a), normalizarDescricao(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	curta, longa := a, b
+	if len(curta) > len(longa) {
+		curta, longa = longa, curta
+	}
+	if len(curta) >= 4 && strings.Contains(longa, curta) {
+		return 0.9
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	edicao := 1 - float64(distanciaLevenshtein(ra, rb))/float64(max(len(ra), len(rb)))
+
+	tokensA := make(map[string]bool)
+	for _, token := range strings.Fields(a) {
+		tokensA[token] = true
+	}
+	tokensB := make(map[string]bool)
+	for _, token := range strings.Fields(b) {
+		tokensB[token] = true
+	}
+	comuns := 0
+	for token := range tokensA {
+		if tokensB[token] {
+			comuns++
+		}
+	}
+	jaccard := float64(comuns) / float64(len(tokensA)+len(tokensB)-comuns)
+
+	return math.Max(edicao, jaccard)
+}
+
+// datasProximas só compara despesas com data. Sem data, o mês de referência
+// não basta: compras mensais repetidas seriam tomadas por duplicatas.
+func datasProximas(a, b *types.Despesa) bool {
+	if a.Data == nil || b.Data == nil {
+		return false
+	}
+	dias := math.Abs(a.Data.Sub(*b.Data).Hours() / 24)
+	return dias <= diasMaximosEntreDuplicatas
+}
+
+func saoPossiveisDuplicatas(a, b *types.Despesa) bool {
+	if math.Abs(a.Valor-b.Valor) >= 0.005 {
+		return false
+	}
+	if !datasProximas(a, b) {
+		return false
+	}
+	return similaridadeDescricao(a.Descricao, b.Descricao) >= similaridadeMinimaDuplicata
+}
+
+func filtrarDuplicatas(despesa *types.Despesa, existentes []types.Despesa) []types.Despesa {
+	var candidatos []types.Despesa
+	for i := range existentes {
+		if existentes[i].ID == despesa.ID && despesa.ID != 0 {
+			continue
+		}
+		if saoPossiveisDuplicatas(despesa, &existentes[i]) {
+			candidatos = append(candidatos, existentes[i])
+		}
+	}
+	return candidatos
+}
+
+func buscarDuplicatas(despesaDAL *dal.DespesaDAL, despesa *types.Despesa) ([]types.Despesa, error) {
+	inicio := despesa.MesReferencia.AddDate(0, -1, 0)
+	fim := despesa.MesReferencia.AddDate(0, 1, 0)
+
+	existentes, err := despesaDAL.GetDespesasByValorBetweenMonths(despesa.UserID, despesa.Valor, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+
+	return filtrarDuplicatas(despesa, existentes), nil
+}
+
+func idsDespesas(despesas []types.Despesa) []uint {
+	ids := make([]uint, 0, len(despesas))
+	for _, despesa := range despesas {
+		ids = append(ids, despesa.ID)
+	}
+	return ids
+}
+
+func limitesMesesDespesas(despesas []types.Despesa) (time.Time, time.Time) {
+	var inicio, fim time.Time
+	for i, despesa := range despesas {
+		if i == 0 || despesa.MesReferencia.Before(inicio) {
+			inicio = despesa.MesReferencia
+		}
+		if i == 0 || despesa.MesReferencia.After(fim) {
+			fim = despesa.MesReferencia
+		}
+	}
+	return inicio.AddDate(0, -1, 0), fim.AddDate(0, 1, 0)
+}
+
+func (s *DespesaService) GetDuplicatas(userID uint) ([]types.GrupoDuplicatas, error) {
+	despesas, err := s.despesaDAL.GetDespesasComValorRepetido(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	grupoDe := make([]int, len(despesas))
+	for i := range grupoDe {
+		grupoDe[i] = i
+	}
+	raiz := func(i int) int {
+		for grupoDe[i] != i {
+			grupoDe[i] = grupoDe[grupoDe[i]]
+			i = grupoDe[i]
+		}
+		return i
+	}
+
+	for i := range despesas {
+		for j := i + 1; j < len(despesas) && math.Abs(despesas[j].Valor-despesas[i].Valor) < 0.005; j++ {
+			if saoPossiveisDuplicatas(&despesas[i], &despesas[j]) {
+				grupoDe[raiz(j)] = raiz(i)
+			}
+		}
+	}
+
+	membros := make(map[int]int)
+	for i := range despesas {
+		membros[raiz(i)]++
+	}
+
+	indices := make(map[int]int)
+	var grupos []types.GrupoDuplicatas
+	for i := range despesas {
+		r := raiz(i)
+		if membros[r] < 2 {
+			continue
+		}
+		idx, ok := indices[r]
+		if !ok {
+			idx = len(grupos)
+			indices[r] = idx
+			grupos = append(grupos, types.GrupoDuplicatas{Valor: despesas[r].Valor})
+		}
+		grupos[idx].Despesas = append(grupos[idx].Despesas, *toDespesaSimpleResponse(&despesas[i]))
+	}
+
+	return grupos, nil
+}
+
+func (s *DespesaService) MesclarDuplicatas(userID uint, req *types.MesclarDuplicatasRequest) (*types.DespesaSimpleResponse, error) {
+	if req.ManterID == 0 {
+		return nil, i18n.NovoErro(i18n.DespesaMantidaObrigatoria)
+	}
+	if len(req.RemoverIDs) == 0 {
+		return nil, i18n.NovoErro(i18n.DespesasRemoverObrigatorias)
+	}
+	if len(req.RemoverIDs) > maxDespesasMesclagem {
+		return nil, i18n.NovoErro(i18n.DespesasMesclarExcesso)
+	}
+
+	ids := append([]uint{req.ManterID}, req.RemoverIDs...)
+	vistos := make(map[uint]bool)
+	for _, id := range ids {
+		if vistos[id] {
+			return nil, i18n.NovoErro(i18n.DespesaMantidaRemovida)
+		}
+		vistos[id] = true
+	}
+
+	despesas, err := s.despesaDAL.GetDespesasByIDs(ids, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(despesas) != len(ids) {
+		return nil, i18n.NovoErro(i18n.DespesaNaoEncontrada)
+	}
+
+	var manter *types.Despesa
+	var remover []types.Despesa
+	for i := range despesas {
+		if despesas[i].ID == req.ManterID {
+			manter = &despesas[i]
+		} else {
+			remover = append(remover, despesas[i])
+		}
+	}
+
+	for i := range remover {
+		if !saoPossiveisDuplicatas(manter, &remover[i]) {
+			return nil, i18n.NovoErro(i18n.DespesaNaoDuplicata, remover[i].ID)
+		}
+	}
+
+	alterada := mesclarCampos(manter, remover)
+	if alterada {
+		if err := verificarDespesaLivre(s.despesaDAL, manter.ID); err != nil {
+			return nil, err
+		}
+		if err := s.fechamentoService.verificarMesAberto(userID, manter.MesReferencia, i18n.MesFechadoEditarDespesa); err != nil {
+			return nil, err
+		}
+	}
+
+	var excluidas []*types.DespesaSimpleResponse
+	err = s.despesaDAL.Transaction(func(txDAL *dal.DespesaDAL) error {
+		for _, despesa := range remover {
+			excluida, err := s.deleteDespesa(txDAL, userID, despesa.ID)
+			if err != nil {
+				return err
+			}
+			excluidas = append(excluidas, excluida)
+		}
+		if !alterada {
+			return nil
+		}
+		return txDAL.UpdateDespesa(manter)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toDespesaSimpleResponse(manter)
+	for _, excluida := range excluidas {
+		s.webhookService.publicarEvento(userID, eventoDespesaExcluida, "", excluida)
+	}
+	if alterada {
+		s.webhookService.publicarEvento(userID, eventoDespesaAlterada, "", response)
+	}
+	return response, nil
+}
+
+// mesclarCampos completa a despesa mantida com os dados que só as removidas
+// têm: data, FITID, conta e categoria vêm da primeira que os tiver, e as tags
+// são unidas. FITID e conta andam juntos, porque o FITID só identifica a
+// transação dentro da conta. Retorna se a despesa mantida mudou.
+func mesclarCampos(manter *types.Despesa, remover []types.Despesa) bool {
+	original := *manter
+	tags := separarTags(manter.Tags)
+	for _, despesa := range remover {
+		if manter.Data == nil && despesa.Data != nil {
+			data := *despesa.Data
+			manter.Data = &data
+		}
+		if manter.FITID == "" && despesa.FITID != "" {
+			manter.FITID = despesa.FITID
+			manter.Conta = despesa.Conta
+		}
+		if manter.Conta == "" {
+			manter.Conta = despesa.Conta
+		}
+		if manter.Categoria == "" {
+			manter.Categoria = despesa.Categoria
+		}
+		tags = append(tags, separarTags(despesa.Tags)...)
+	}
+	manter.Tags = juntarTags(tags)
+
+	return manter.Data != original.Data || manter.FITID != original.FITID || manter.Conta != original.Conta ||
+		manter.Categoria != original.Categoria || manter.Tags != original.Tags
+}
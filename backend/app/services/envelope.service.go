@@ -0,0 +1,426 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const (
+	tipoMovimentoAporte = "aporte"
+	tipoMovimentoGasto  = "gasto"
+
+	statusEnvelopeEmDia     = "em_dia"
+	statusEnvelopeAtrasado  = "atrasado"
+	statusEnvelopeConcluido = "concluido"
+	statusEnvelopeVencido   = "vencido"
+)
+
+type EnvelopeService struct {
+	envelopeDAL    *dal.EnvelopeDAL
+	despesaDAL     *dal.DespesaDAL
+	periodoService *PeriodoService
+}
+
+func NewEnvelopeService(envelopeDAL *dal.EnvelopeDAL, despesaDAL *dal.DespesaDAL, periodoService *PeriodoService) *EnvelopeService {
+	return &EnvelopeService{
+		envelopeDAL:    envelopeDAL,
+		despesaDAL:     despesaDAL,
+		periodoService: periodoService,
+	}
+}
+
+// mesesEntre conta os meses de `de` até `ate`, inclusive. É zero quando `ate`
+// é anterior a `de`.
+func mesesEntre(de time.Time, ate time.Time) int {
+	meses := (ate.Year()-de.Year())*12 + int(ate.Month()) - int(de.Month()) + 1
+	if meses < 0 {
+		return 0
+	}
+	return meses
+}
+
+// proximoVencimento retorna o vencimento do ciclo que contém o mês: o próprio
+// vencimento ou, nos envelopes recorrentes, o primeiro aniversário dele que
+// não ficou para trás.
+func proximoVencimento(envelope *types.Envelope, mes time.Time) time.Time {
+	vencimento := inicioDoMes(envelope.MesVencimento)
+	for envelope.Recorrente && vencimento.Before(mes) {
+		vencimento = vencimento.AddDate(1, 0, 0)
+	}
+	return vencimento
+}
+
+// calcularEnvelope resume o ciclo de doze meses que termina no próximo
+// vencimento. A contribuição mensal distribui o que falta aportar, sem contar
+// o próprio mês, pelos meses restantes até o vencimento, inclusive.
+func calcularEnvelope(envelope *types.Envelope, movimentos []types.MovimentoEnvelope, mes time.Time) *types.EnvelopeResponse {
+	vencimento := proximoVencimento(envelope, mes)
+	inicioCiclo := vencimento.AddDate(0, -11, 0)
+	if criacao := inicioDoMes(envelope.CreatedAt); criacao.After(inicioCiclo) && !criacao.After(vencimento) {
+		inicioCiclo = criacao
+	}
+
+	response := &types.EnvelopeResponse{
+		ID:                envelope.ID,
+		Nome:              envelope.Nome,
+		Categoria:         envelope.Categoria,
+		ValorAlvo:         envelope.ValorAlvo,
+		Recorrente:        envelope.Recorrente,
+		ProximoVencimento: formatMonthYear(vencimento),
+		MesesRestantes:    mesesEntre(mes, vencimento),
+	}
+
+	aportadoAntes := 0.0
+	for _, movimento := range movimentos {
+		if movimento.EnvelopeID != envelope.ID {
+			continue
+		}
+		mesMovimento := inicioDoMes(movimento.MesReferencia)
+		noCiclo := !mesMovimento.Before(inicioCiclo) && !mesMovimento.After(vencimento)
+
+		if movimento.Tipo == tipoMovimentoGasto {
+			response.Saldo -= movimento.Valor
+			if noCiclo {
+				response.GastoNoCiclo += movimento.Valor
+			}
+			continue
+		}
+
+		response.Saldo += movimento.Valor
+		if noCiclo {
+			response.AportadoNoCiclo += movimento.Valor
+			if mesMovimento.Before(mes) {
+				aportadoAntes += movimento.Valor
+			}
+		}
+		if mesMovimento.Equal(mes) {
+			response.AportadoNoMes += movimento.Valor
+		}
+	}
+
+	if response.MesesRestantes > 0 && aportadoAntes < envelope.ValorAlvo {
+		response.ContribuicaoMensal = arredondar((envelope.ValorAlvo - aportadoAntes) / float64(response.MesesRestantes))
+	}
+
+	// Em dia é ter aportado, antes deste mês, a fração do alvo proporcional
+	// aos meses já passados do ciclo
+	esperado := 0.0
+	if mesesCiclo := mesesEntre(inicioCiclo, vencimento); mesesCiclo > 0 {
+		esperado = envelope.ValorAlvo * float64(mesesEntre(inicioCiclo, mes)-1) / float64(mesesCiclo)
+	}
+
+	switch {
+	case response.AportadoNoCiclo >= envelope.ValorAlvo:
+		response.Status = statusEnvelopeConcluido
+	case vencimento.Before(mes):
+		response.Status = statusEnvelopeVencido
+	case aportadoAntes+0.005 < esperado:
+		response.Status = statusEnvelopeAtrasado
+	default:
+		response.Status = statusEnvelopeEmDia
+	}
+
+	response.AportadoNoCiclo = arredondar(response.AportadoNoCiclo)
+	response.GastoNoCiclo = arredondar(response.GastoNoCiclo)
+	response.Saldo = arredondar(response.Saldo)
+	response.AportadoNoMes = arredondar(response.AportadoNoMes)
+	if envelope.ValorAlvo > 0 {
+		response.Progresso = arredondar(response.AportadoNoCiclo / envelope.ValorAlvo * 100)
+	}
+
+	return response
+}
+
+func toMovimentoEnvelopeResponse(movimento *types.MovimentoEnvelope) types.MovimentoEnvelopeResponse {
+	return types.MovimentoEnvelopeResponse{
+		ID:            movimento.ID,
+		Tipo:          movimento.Tipo,
+		Valor:         movimento.Valor,
+		MesReferencia: formatMonthYear(movimento.MesReferencia),
+		Descricao:     movimento.Descricao,
+		DespesaID:     movimento.DespesaID,
+		CreatedAt:     movimento.CreatedAt,
+	}
+}
+
+func (s *EnvelopeService) validarEnvelope(userID uint, req *types.EnvelopeRequest, envelope *types.Envelope) error {
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return i18n.NovoErro(i18n.NomeObrigatorio)
+	}
+	if req.ValorAlvo <= 0 {
+		return i18n.NovoErro(i18n.MetaValorAlvoNaoPositivo)
+	}
+
+	mesVencimento, err := parseMonthYear(req.MesVencimento)
+	if err != nil {
+		return i18n.NovoErro(i18n.EnvelopeVencimentoInvalido)
+	}
+	if !req.Recorrente && s.periodoService.anteriorAoMesAtual(userID, mesVencimento) {
+		return i18n.NovoErro(i18n.EnvelopeVencimentoPassado)
+	}
+
+	envelope.Nome = nome
+	envelope.Categoria = strings.TrimSpace(req.Categoria)
+	envelope.ValorAlvo = req.ValorAlvo
+	envelope.MesVencimento = mesVencimento
+	envelope.Recorrente = req.Recorrente
+	return nil
+}
+
+func (s *EnvelopeService) getEnvelope(userID uint, envelopeID uint) (*types.Envelope, error) {
+	envelope, err := s.envelopeDAL.GetEnvelopeByID(envelopeID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, i18n.NovoErro(i18n.EnvelopeNaoEncontrado)
+		}
+		return nil, err
+	}
+	return envelope, nil
+}
+
+func (s *EnvelopeService) detalharEnvelope(userID uint, envelope *types.Envelope) (*types.EnvelopeDetalheResponse, error) {
+	movimentos, err := s.envelopeDAL.GetMovimentosByEnvelope(envelope.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	detalhe := &types.EnvelopeDetalheResponse{
+		EnvelopeResponse: *calcularEnvelope(envelope, movimentos, s.periodoService.mesAtual(userID)),
+		Movimentos:       []types.MovimentoEnvelopeResponse{},
+	}
+	for i := range movimentos {
+		detalhe.Movimentos = append(detalhe.Movimentos, toMovimentoEnvelopeResponse(&movimentos[i]))
+	}
+	return detalhe, nil
+}
+
+func (s *EnvelopeService) CreateEnvelope(userID uint, req *types.EnvelopeRequest) (*types.EnvelopeResponse, error) {
+	envelope := &types.Envelope{UserID: userID}
+	if err := s.validarEnvelope(userID, req, envelope); err != nil {
+		return nil, err
+	}
+
+	if err := s.envelopeDAL.CreateEnvelope(envelope); err != nil {
+		return nil, err
+	}
+
+	return calcularEnvelope(envelope, nil, s.periodoService.mesAtual(userID)), nil
+}
+
+func (s *EnvelopeService) GetEnvelopesByUser(userID uint) ([]types.EnvelopeResponse, error) {
+	mesAtual := s.periodoService.mesAtual(userID)
+
+	envelopes, err := s.envelopeDAL.GetEnvelopesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelopes) == 0 {
+		return []types.EnvelopeResponse{}, nil
+	}
+
+	movimentos, err := s.envelopeDAL.GetMovimentosByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.EnvelopeResponse
+	for i := range envelopes {
+		response = append(response, *calcularEnvelope(&envelopes[i], movimentos, mesAtual))
+	}
+	return response, nil
+}
+
+func (s *EnvelopeService) GetEnvelopeByID(userID uint, envelopeID uint) (*types.EnvelopeDetalheResponse, error) {
+	envelope, err := s.getEnvelope(userID, envelopeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.detalharEnvelope(userID, envelope)
+}
+
+func (s *EnvelopeService) UpdateEnvelope(userID uint, envelopeID uint, req *types.EnvelopeRequest) (*types.EnvelopeDetalheResponse, error) {
+	envelope, err := s.getEnvelope(userID, envelopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validarEnvelope(userID, req, envelope); err != nil {
+		return nil, err
+	}
+
+	if err := s.envelopeDAL.UpdateEnvelope(envelope); err != nil {
+		return nil, err
+	}
+
+	return s.detalharEnvelope(userID, envelope)
+}
+
+func (s *EnvelopeService) DeleteEnvelope(userID uint, envelopeID uint) error {
+	if _, err := s.getEnvelope(userID, envelopeID); err != nil {
+		return err
+	}
+	return s.envelopeDAL.DeleteEnvelope(envelopeID, userID)
+}
+
+// RegistrarAporte guarda dinheiro no envelope. Sem mês de referência, o
+// aporte conta no mês corrente.
+func (s *EnvelopeService) RegistrarAporte(userID uint, envelopeID uint, req *types.MovimentoEnvelopeRequest) (*types.EnvelopeDetalheResponse, error) {
+	envelope, err := s.getEnvelope(userID, envelopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Valor <= 0 {
+		return nil, i18n.NovoErro(i18n.ValorNaoPositivo)
+	}
+
+	mesReferencia := s.periodoService.mesAtual(userID)
+	if req.MesReferencia != "" {
+		if mesReferencia, err = parseMonthYear(req.MesReferencia); err != nil {
+			return nil, err
+		}
+	}
+
+	movimento := &types.MovimentoEnvelope{
+		EnvelopeID:    envelope.ID,
+		UserID:        userID,
+		Tipo:          tipoMovimentoAporte,
+		Valor:         req.Valor,
+		MesReferencia: mesReferencia,
+		Descricao:     strings.TrimSpace(req.Descricao),
+	}
+	if err := s.envelopeDAL.CreateMovimento(movimento); err != nil {
+		return nil, err
+	}
+
+	return s.detalharEnvelope(userID, envelope)
+}
+
+// RegistrarGasto paga uma despesa com o dinheiro do envelope. Com despesaId,
+// valor, descrição e mês vêm da despesa, que só pode ser vinculada uma vez.
+// Enquanto o gasto existir, a despesa não pode ser alterada nem excluída
+// (veja verificarDespesaLivre), então a cópia do valor não fica defasada.
+func (s *EnvelopeService) RegistrarGasto(userID uint, envelopeID uint, req *types.MovimentoEnvelopeRequest) (*types.EnvelopeDetalheResponse, error) {
+	envelope, err := s.getEnvelope(userID, envelopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	movimento := &types.MovimentoEnvelope{
+		EnvelopeID: envelope.ID,
+		UserID:     userID,
+		Tipo:       tipoMovimentoGasto,
+	}
+
+	if req.DespesaID != nil {
+		despesa, err := s.despesaDAL.GetDespesaByID(*req.DespesaID, userID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, i18n.NovoErro(i18n.DespesaNaoEncontrada)
+			}
+			return nil, err
+		}
+
+		vinculada, err := s.envelopeDAL.ExistsGastoDaDespesa(despesa.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if vinculada {
+			return nil, i18n.NovoErro(i18n.DespesaPagaComEnvelope)
+		}
+
+		movimento.Valor = despesa.Valor
+		movimento.MesReferencia = despesa.MesReferencia
+		movimento.Descricao = despesa.Descricao
+		movimento.DespesaID = &despesa.ID
+	} else {
+		if req.Valor <= 0 {
+			return nil, i18n.NovoErro(i18n.ValorNaoPositivo)
+		}
+
+		movimento.Valor = req.Valor
+		movimento.Descricao = strings.TrimSpace(req.Descricao)
+		movimento.MesReferencia = s.periodoService.mesAtual(userID)
+		if req.MesReferencia != "" {
+			if movimento.MesReferencia, err = parseMonthYear(req.MesReferencia); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.envelopeDAL.CreateMovimento(movimento); err != nil {
+		return nil, err
+	}
+
+	return s.detalharEnvelope(userID, envelope)
+}
+
+func (s *EnvelopeService) DeleteMovimento(userID uint, envelopeID uint, movimentoID uint) error {
+	if _, err := s.getEnvelope(userID, envelopeID); err != nil {
+		return err
+	}
+
+	if _, err := s.envelopeDAL.GetMovimentoByID(movimentoID, envelopeID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return i18n.NovoErro(i18n.MovimentoNaoEncontrado)
+		}
+		return err
+	}
+
+	return s.envelopeDAL.DeleteMovimento(movimentoID, envelopeID, userID)
+}
+
+// resumoDoMes lista a contribuição de cada envelope no mês, usada no resumo
+// mensal. Envelopes vencidos ou já completos não entram.
+func (s *EnvelopeService) resumoDoMes(userID uint, mesReferencia time.Time) ([]types.ResumoEnvelopeResponse, error) {
+	envelopes, err := s.envelopeDAL.GetEnvelopesByUser(userID)
+	if err != nil || len(envelopes) == 0 {
+		return []types.ResumoEnvelopeResponse{}, err
+	}
+
+	todos, err := s.envelopeDAL.GetMovimentosByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Movimentos de meses seguintes não mudam o que valia naquele mês
+	var movimentos []types.MovimentoEnvelope
+	for _, movimento := range todos {
+		if !inicioDoMes(movimento.MesReferencia).After(mesReferencia) {
+			movimentos = append(movimentos, movimento)
+		}
+	}
+
+	resumo := []types.ResumoEnvelopeResponse{}
+	for i := range envelopes {
+		envelope := &envelopes[i]
+		calculado := calcularEnvelope(envelope, movimentos, mesReferencia)
+
+		gastoNoMes := 0.0
+		for _, movimento := range movimentos {
+			if movimento.EnvelopeID == envelope.ID && movimento.Tipo == tipoMovimentoGasto && inicioDoMes(movimento.MesReferencia).Equal(mesReferencia) {
+				gastoNoMes += movimento.Valor
+			}
+		}
+
+		if calculado.ContribuicaoMensal == 0 && calculado.AportadoNoMes == 0 && gastoNoMes == 0 {
+			continue
+		}
+		resumo = append(resumo, types.ResumoEnvelopeResponse{
+			EnvelopeID:    envelope.ID,
+			Nome:          envelope.Nome,
+			Contribuicao:  calculado.ContribuicaoMensal,
+			AportadoNoMes: calculado.AportadoNoMes,
+			GastoNoMes:    arredondar(gastoNoMes),
+		})
+	}
+
+	return resumo, nil
+}
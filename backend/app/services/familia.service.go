@@ -0,0 +1,552 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const (
+	papelFamiliaAdministrador = "administrador"
+	papelFamiliaMembro        = "membro"
+
+	statusConvitePendente = "pendente"
+	statusConviteAceito   = "aceito"
+	statusConviteRecusado = "recusado"
+
+	tipoNotificacaoConvite = "convite"
+
+	validadeConviteFamilia = 7 * 24 * time.Hour
+)
+
+type FamiliaService struct {
+	familiaDAL         *dal.FamiliaDAL
+	authDAL            *dal.AuthDAL
+	despesaDAL         *dal.DespesaDAL
+	notificacaoDAL     *dal.NotificacaoDAL
+	preferenciaService *PreferenciaService
+	relogio            relogio.Relogio
+}
+
+func NewFamiliaService(familiaDAL *dal.FamiliaDAL, authDAL *dal.AuthDAL, despesaDAL *dal.DespesaDAL, notificacaoDAL *dal.NotificacaoDAL, preferenciaService *PreferenciaService, relogio relogio.Relogio) *FamiliaService {
+	return &FamiliaService{
+		familiaDAL:         familiaDAL,
+		authDAL:            authDAL,
+		despesaDAL:         despesaDAL,
+		notificacaoDAL:     notificacaoDAL,
+		preferenciaService: preferenciaService,
+		relogio:            relogio,
+	}
+}
+
+func normalizarPapelFamilia(papel string) (string, error) {
+	papel = strings.ToLower(strings.TrimSpace(papel))
+	switch papel {
+	case "":
+		return papelFamiliaMembro, nil
+	case papelFamiliaAdministrador, papelFamiliaMembro:
+		return papel, nil
+	default:
+		return "", i18n.NovoErro(i18n.FamiliaPapelInvalido)
+	}
+}
+
+func (s *FamiliaService) getMembro(userID uint) (*types.MembroFamilia, error) {
+	membro, err := s.familiaDAL.GetMembroByUser(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, i18n.NovoErro(i18n.FamiliaSemParticipacao)
+		}
+		return nil, err
+	}
+	return membro, nil
+}
+
+func (s *FamiliaService) getAdministrador(userID uint) (*types.MembroFamilia, error) {
+	membro, err := s.getMembro(userID)
+	if err != nil {
+		return nil, err
+	}
+	if membro.Papel != papelFamiliaAdministrador {
+		return nil, i18n.NovoErro(i18n.FamiliaSemPermissao)
+	}
+	return membro, nil
+}
+
+// podeGerenciarOrcamento libera quem está fora de uma família e, dentro dela,
+// apenas os administradores.
+func (s *FamiliaService) podeGerenciarOrcamento(userID uint) (bool, error) {
+	membro, err := s.familiaDAL.GetMembroByUser(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+	return membro.Papel == papelFamiliaAdministrador, nil
+}
+
+func (s *FamiliaService) toFamiliaResponse(familia *types.Familia, meuPapel string) (*types.FamiliaResponse, error) {
+	membros, err := s.familiaDAL.GetMembros(familia.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.FamiliaResponse{
+		ID:       familia.ID,
+		Nome:     familia.Nome,
+		MeuPapel: meuPapel,
+		Membros:  make([]types.MembroFamiliaResponse, 0, len(membros)),
+	}
+	for _, membro := range membros {
+		response.Membros = append(response.Membros, types.MembroFamiliaResponse{
+			UserID:      membro.UserID,
+			Nome:        membro.User.Nome,
+			Email:       membro.User.Email,
+			Papel:       membro.Papel,
+			MembroDesde: membro.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+func (s *FamiliaService) CreateFamilia(userID uint, req *types.FamiliaRequest) (*types.FamiliaResponse, error) {
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return nil, i18n.NovoErro(i18n.NomeObrigatorio)
+	}
+
+	_, err := s.familiaDAL.GetMembroByUser(userID)
+	if err == nil {
+		return nil, i18n.NovoErro(i18n.FamiliaJaParticipa)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	familia := &types.Familia{Nome: nome, CriadaPorID: userID}
+	membro := &types.MembroFamilia{UserID: userID, Papel: papelFamiliaAdministrador}
+	if err := s.familiaDAL.CreateFamilia(familia, membro); err != nil {
+		return nil, err
+	}
+
+	return s.toFamiliaResponse(familia, membro.Papel)
+}
+
+func (s *FamiliaService) GetFamilia(userID uint) (*types.FamiliaResponse, error) {
+	membro, err := s.getMembro(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	familia, err := s.familiaDAL.GetFamiliaByID(membro.FamiliaID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toFamiliaResponse(familia, membro.Papel)
+}
+
+func (s *FamiliaService) UpdateFamilia(userID uint, req *types.FamiliaRequest) (*types.FamiliaResponse, error) {
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return nil, i18n.NovoErro(i18n.NomeObrigatorio)
+	}
+
+	membro, err := s.getAdministrador(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	familia, err := s.familiaDAL.GetFamiliaByID(membro.FamiliaID)
+	if err != nil {
+		return nil, err
+	}
+	familia.Nome = nome
+	if err := s.familiaDAL.UpdateFamilia(familia); err != nil {
+		return nil, err
+	}
+
+	return s.toFamiliaResponse(familia, membro.Papel)
+}
+
+// contarAdministradores retorna quantos membros restam e quantos deles são
+// administradores, desconsiderando o usuário informado.
+func contarAdministradores(membros []types.MembroFamilia, excetoUserID uint) (int, int) {
+	restantes, administradores := 0, 0
+	for _, membro := range membros {
+		if membro.UserID == excetoUserID {
+			continue
+		}
+		restantes++
+		if membro.Papel == papelFamiliaAdministrador {
+			administradores++
+		}
+	}
+	return restantes, administradores
+}
+
+// SairDaFamilia remove o usuário da família. O último membro a sair exclui a
+// família; o último administrador precisa promover outro membro antes.
+func (s *FamiliaService) SairDaFamilia(userID uint) error {
+	membro, err := s.getMembro(userID)
+	if err != nil {
+		return err
+	}
+
+	membros, err := s.familiaDAL.GetMembros(membro.FamiliaID)
+	if err != nil {
+		return err
+	}
+
+	restantes, administradores := contarAdministradores(membros, userID)
+	if restantes > 0 && administradores == 0 {
+		return i18n.NovoErro(i18n.FamiliaPromovaAdministrador)
+	}
+
+	if err := s.familiaDAL.DeleteMembro(membro.FamiliaID, userID); err != nil {
+		return err
+	}
+	if restantes == 0 {
+		return s.familiaDAL.DeleteFamilia(membro.FamiliaID)
+	}
+	return nil
+}
+
+func (s *FamiliaService) AlterarPapel(userID uint, membroID uint, req *types.PapelMembroRequest) (*types.FamiliaResponse, error) {
+	papel, err := normalizarPapelFamilia(req.Papel)
+	if err != nil {
+		return nil, err
+	}
+
+	administrador, err := s.getAdministrador(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	membro, err := s.familiaDAL.GetMembroByUser(membroID)
+	if err != nil || membro.FamiliaID != administrador.FamiliaID {
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, i18n.NovoErro(i18n.MembroNaoEncontrado)
+	}
+
+	if papel != papelFamiliaAdministrador {
+		membros, err := s.familiaDAL.GetMembros(administrador.FamiliaID)
+		if err != nil {
+			return nil, err
+		}
+		if _, administradores := contarAdministradores(membros, membroID); administradores == 0 {
+			return nil, i18n.NovoErro(i18n.FamiliaSemAdministrador)
+		}
+	}
+
+	membro.Papel = papel
+	if err := s.familiaDAL.UpdateMembro(membro); err != nil {
+		return nil, err
+	}
+
+	familia, err := s.familiaDAL.GetFamiliaByID(administrador.FamiliaID)
+	if err != nil {
+		return nil, err
+	}
+	if membroID == userID {
+		administrador.Papel = papel
+	}
+	return s.toFamiliaResponse(familia, administrador.Papel)
+}
+
+func (s *FamiliaService) RemoverMembro(userID uint, membroID uint) error {
+	if membroID == userID {
+		return i18n.NovoErro(i18n.FamiliaUseSair)
+	}
+
+	administrador, err := s.getAdministrador(userID)
+	if err != nil {
+		return err
+	}
+
+	membro, err := s.familiaDAL.GetMembroByUser(membroID)
+	if err != nil || membro.FamiliaID != administrador.FamiliaID {
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return i18n.NovoErro(i18n.MembroNaoEncontrado)
+	}
+
+	return s.familiaDAL.DeleteMembro(administrador.FamiliaID, membroID)
+}
+
+func (s *FamiliaService) toConviteResponse(convite *types.ConviteFamilia, comOrigem bool) types.ConviteFamiliaResponse {
+	response := types.ConviteFamiliaResponse{
+		ID:        convite.ID,
+		FamiliaID: convite.FamiliaID,
+		Email:     convite.Email,
+		Papel:     convite.Papel,
+		Status:    convite.Status,
+		ExpiraEm:  convite.ExpiraEm,
+		CreatedAt: convite.CreatedAt,
+	}
+
+	// Quem recebe o convite vê de qual família ele é e quem o enviou
+	if comOrigem {
+		if familia, err := s.familiaDAL.GetFamiliaByID(convite.FamiliaID); err == nil {
+			response.Familia = familia.Nome
+		}
+		if usuario, err := s.authDAL.GetUserByID(convite.ConvidadoPorID); err == nil {
+			response.ConvidadoPor = usuario.Nome
+		}
+	}
+	return response
+}
+
+// notificarConvite avisa o convidado, se ele já tiver cadastro, pela central
+// de notificações e, se ele não tiver desativado, por push.
+func (s *FamiliaService) notificarConvite(convite *types.ConviteFamilia, familia *types.Familia, agora time.Time) error {
+	convidado, err := s.authDAL.GetUserByEmail(convite.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	idioma := s.preferenciaService.IdiomaDoUsuario(convidado.ID)
+	notificacao := &types.Notificacao{
+		UserID:   convidado.ID,
+		Chave:    fmt.Sprintf("%s|%d", tipoNotificacaoConvite, convite.ID),
+		Tipo:     tipoNotificacaoConvite,
+		Titulo:   i18n.Traduzir(idioma, i18n.NotificacaoConviteTitulo),
+		Mensagem: i18n.Traduzir(idioma, i18n.NotificacaoConviteMensagem, familia.Nome),
+	}
+	_, err = s.notificacaoDAL.CreateNotificacaoUnica(notificacao, s.preferenciaService.mensagemPush(notificacao, agora))
+	return err
+}
+
+func (s *FamiliaService) ConvidarMembro(userID uint, req *types.ConviteFamiliaRequest) (*types.ConviteFamiliaResponse, error) {
+	endereco, err := mail.ParseAddress(strings.TrimSpace(req.Email))
+	if err != nil {
+		return nil, i18n.NovoErro(i18n.EmailInvalido)
+	}
+	email := strings.ToLower(endereco.Address)
+
+	papel, err := normalizarPapelFamilia(req.Papel)
+	if err != nil {
+		return nil, err
+	}
+
+	administrador, err := s.getAdministrador(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	convidado, err := s.authDAL.GetUserByEmail(email)
+	if err == nil {
+		if membro, err := s.familiaDAL.GetMembroByUser(convidado.ID); err == nil {
+			if membro.FamiliaID == administrador.FamiliaID {
+				return nil, i18n.NovoErro(i18n.FamiliaMembroExistente)
+			}
+			return nil, i18n.NovoErro(i18n.FamiliaMembroDeOutra)
+		}
+	}
+
+	agora := s.relogio.Agora().UTC()
+	existe, err := s.familiaDAL.ExistsConvitePendente(administrador.FamiliaID, email, agora)
+	if err != nil {
+		return nil, err
+	}
+	if existe {
+		return nil, i18n.NovoErro(i18n.ConvitePendenteExistente)
+	}
+
+	familia, err := s.familiaDAL.GetFamiliaByID(administrador.FamiliaID)
+	if err != nil {
+		return nil, err
+	}
+
+	convite := &types.ConviteFamilia{
+		FamiliaID:      administrador.FamiliaID,
+		Email:          email,
+		Papel:          papel,
+		ConvidadoPorID: userID,
+		Status:         statusConvitePendente,
+		ExpiraEm:       agora.Add(validadeConviteFamilia),
+	}
+	if err := s.familiaDAL.CreateConvite(convite); err != nil {
+		return nil, err
+	}
+
+	if err := s.notificarConvite(convite, familia, agora); err != nil {
+		return nil, err
+	}
+
+	response := s.toConviteResponse(convite, false)
+	return &response, nil
+}
+
+func (s *FamiliaService) GetConvitesDaFamilia(userID uint) ([]types.ConviteFamiliaResponse, error) {
+	administrador, err := s.getAdministrador(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	convites, err := s.familiaDAL.GetConvitesPendentesByFamilia(administrador.FamiliaID, s.relogio.Agora().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.ConviteFamiliaResponse
+	for i := range convites {
+		response = append(response, s.toConviteResponse(&convites[i], false))
+	}
+	return response, nil
+}
+
+func (s *FamiliaService) RevogarConvite(userID uint, conviteID uint) error {
+	administrador, err := s.getAdministrador(userID)
+	if err != nil {
+		return err
+	}
+
+	convite, err := s.familiaDAL.GetConviteByID(conviteID)
+	if err != nil || convite.FamiliaID != administrador.FamiliaID || convite.Status != statusConvitePendente {
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return i18n.NovoErro(i18n.ConviteNaoEncontrado)
+	}
+
+	return s.familiaDAL.DeleteConvite(convite.ID, administrador.FamiliaID)
+}
+
+func (s *FamiliaService) GetMeusConvites(userID uint) ([]types.ConviteFamiliaResponse, error) {
+	usuario, err := s.authDAL.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	convites, err := s.familiaDAL.GetConvitesPendentesByEmail(usuario.Email, s.relogio.Agora().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.ConviteFamiliaResponse
+	for i := range convites {
+		response = append(response, s.toConviteResponse(&convites[i], true))
+	}
+	return response, nil
+}
+
+// getConviteRecebido só encontra convites pendentes, válidos e enviados ao
+// e-mail do usuário.
+func (s *FamiliaService) getConviteRecebido(userID uint, conviteID uint, agora time.Time) (*types.ConviteFamilia, error) {
+	usuario, err := s.authDAL.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	convite, err := s.familiaDAL.GetConviteByID(conviteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, i18n.NovoErro(i18n.ConviteNaoEncontrado)
+		}
+		return nil, err
+	}
+	if !strings.EqualFold(convite.Email, usuario.Email) || convite.Status != statusConvitePendente {
+		return nil, i18n.NovoErro(i18n.ConviteNaoEncontrado)
+	}
+	if !convite.ExpiraEm.After(agora) {
+		return nil, i18n.NovoErro(i18n.ConviteExpirado)
+	}
+	return convite, nil
+}
+
+func (s *FamiliaService) AceitarConvite(userID uint, conviteID uint, req *types.AceitarConviteRequest) (*types.FamiliaResponse, error) {
+	agora := s.relogio.Agora().UTC()
+	convite, err := s.getConviteRecebido(userID, conviteID, agora)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.familiaDAL.GetMembroByUser(userID)
+	if err == nil {
+		return nil, i18n.NovoErro(i18n.FamiliaSaiaAntes)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	convite.Status = statusConviteAceito
+	convite.RespondidoEm = &agora
+	membro := &types.MembroFamilia{FamiliaID: convite.FamiliaID, UserID: userID, Papel: convite.Papel}
+	if err := s.familiaDAL.EntrarNaFamilia(convite, membro, req.ImportarDespesas); err != nil {
+		return nil, err
+	}
+
+	familia, err := s.familiaDAL.GetFamiliaByID(convite.FamiliaID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toFamiliaResponse(familia, membro.Papel)
+}
+
+func (s *FamiliaService) RecusarConvite(userID uint, conviteID uint) error {
+	agora := s.relogio.Agora().UTC()
+	convite, err := s.getConviteRecebido(userID, conviteID, agora)
+	if err != nil {
+		return err
+	}
+
+	convite.Status = statusConviteRecusado
+	convite.RespondidoEm = &agora
+	return s.familiaDAL.SaveConvite(convite)
+}
+
+// GetResumoFamilia mostra quanto cada membro lançou no mês e sua participação
+// no total da família.
+func (s *FamiliaService) GetResumoFamilia(userID uint, mesReferencia string) (*types.ResumoFamiliaResponse, error) {
+	mes, err := parseMonthYear(mesReferencia)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getMembro(userID); err != nil {
+		return nil, err
+	}
+
+	totais, err := s.despesaDAL.GetTotaisPorMembro(userID, mes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.ResumoFamiliaResponse{
+		MesReferencia: formatMonthYear(mes),
+		Membros:       make([]types.ResumoMembroResponse, 0, len(totais)),
+	}
+	for _, total := range totais {
+		response.TotalGasto += total.Total
+	}
+	for _, total := range totais {
+		membro := types.ResumoMembroResponse{
+			UserID:     total.UserID,
+			Nome:       total.Nome,
+			Total:      arredondar(total.Total),
+			Quantidade: total.Quantidade,
+		}
+		if response.TotalGasto > 0 {
+			membro.Participacao = arredondar(total.Total / response.TotalGasto * 100)
+		}
+		response.Membros = append(response.Membros, membro)
+	}
+	response.TotalGasto = arredondar(response.TotalGasto)
+
+	return response, nil
+}
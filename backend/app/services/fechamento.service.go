@@ -0,0 +1,416 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const (
+	statusMesAberto   = "aberto"
+	statusMesFechado  = "fechado"
+	statusMesReaberto = "reaberto"
+
+	acaoFechamento = "fechamento"
+	acaoReabertura = "reabertura"
+
+	maxDiasCarenciaFechamento = 31
+
+	// Quantos meses para trás o fechamento automático verifica, para cobrir
+	// períodos em que o job ficou parado.
+	mesesFechamentoAutomatico = 3
+)
+
+type FechamentoService struct {
+	fechamentoDAL  *dal.FechamentoDAL
+	despesaDAL     *dal.DespesaDAL
+	limiteDAL      *dal.LimiteDAL
+	periodoService *PeriodoService
+	familiaService *FamiliaService
+}
+
+func NewFechamentoService(fechamentoDAL *dal.FechamentoDAL, despesaDAL *dal.DespesaDAL, limiteDAL *dal.LimiteDAL, periodoService *PeriodoService, familiaService *FamiliaService) *FechamentoService {
+	return &FechamentoService{
+		fechamentoDAL:  fechamentoDAL,
+		despesaDAL:     despesaDAL,
+		limiteDAL:      limiteDAL,
+		periodoService: periodoService,
+		familiaService: familiaService,
+	}
+}
+
+// mesFechado substitui o antigo bloqueio de meses anteriores: um mês só
+// fica imutável depois de fechado, manualmente ou pelo fechamento automático.
+func (s *FechamentoService) mesFechado(userID uint, mesReferencia time.Time) (bool, error) {
+	fechamento, err := s.fechamentoDAL.GetFechamento(userID, inicioDoMes(mesReferencia))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return fechamento.Status == statusMesFechado, nil
+}
+
+// verificarMesAberto retorna o erro do código informado se o mês estiver
+// fechado.
+func (s *FechamentoService) verificarMesAberto(userID uint, mesReferencia time.Time, codigo i18n.Codigo) error {
+	fechado, err := s.mesFechado(userID, mesReferencia)
+	if err != nil {
+		return err
+	}
+	if fechado {
+		return i18n.NovoErro(codigo)
+	}
+	return nil
+}
+
+func (s *FechamentoService) verificarPermissao(userID uint) error {
+	pode, err := s.familiaService.podeGerenciarOrcamento(userID)
+	if err != nil {
+		return err
+	}
+	if !pode {
+		return i18n.NovoErro(i18n.FechamentoSemPermissao)
+	}
+	return nil
+}
+
+// fotografarMes preenche os totais do fechamento com a situação atual do mês.
+// Os limites são gravados pelo valor efetivo, já com o transporte de saldo.
+func (s *FechamentoService) fotografarMes(userID uint, fechamento *types.FechamentoMes) error {
+	totais, err := s.despesaDAL.GetTotaisPorCategoria(userID, fechamento.MesReferencia)
+	if err != nil {
+		return err
+	}
+	limites, err := s.limiteDAL.GetLimitesByUserAndMonth(userID, fechamento.MesReferencia)
+	if err != nil {
+		return err
+	}
+
+	fechamento.TotalDespesas = 0
+	fechamento.QuantidadeDespesas = 0
+	fechamento.LimiteGeral = nil
+
+	categorias := make([]types.CategoriaFechamento, 0, len(totais))
+	indice := make(map[string]int)
+	for _, total := range totais {
+		fechamento.TotalDespesas += total.Total
+		fechamento.QuantidadeDespesas += total.Quantidade
+		if total.Categoria == "" {
+			continue
+		}
+		indice[strings.ToLower(total.Categoria)] = len(categorias)
+		categorias = append(categorias, types.CategoriaFechamento{
+			Categoria:  total.Categoria,
+			Total:      arredondar(total.Total),
+			Quantidade: total.Quantidade,
+		})
+	}
+	fechamento.TotalDespesas = arredondar(fechamento.TotalDespesas)
+
+	calc, err := criarCalculadoraTransporte(s.limiteDAL, s.despesaDAL, userID)
+	if err != nil {
+		return err
+	}
+
+	for i := range limites {
+		limite, err := calc.response(&limites[i])
+		if err != nil {
+			return err
+		}
+		valor := limite.ValorEfetivo
+		if limite.Categoria == "" {
+			fechamento.LimiteGeral = &valor
+			continue
+		}
+		if j, ok := indice[strings.ToLower(limite.Categoria)]; ok {
+			categorias[j].Limite = &valor
+			continue
+		}
+		categorias = append(categorias, types.CategoriaFechamento{Categoria: limite.Categoria, Limite: &valor})
+	}
+
+	dados, err := json.Marshal(categorias)
+	if err != nil {
+		return err
+	}
+	fechamento.Categorias = string(dados)
+	return nil
+}
+
+func (s *FechamentoService) fecharMes(userID uint, mesReferencia time.Time, automatico bool, agora time.Time) (*types.FechamentoMes, error) {
+	periodo := s.periodoService.resolvedor(userID).periodoDoMes(mesReferencia)
+	if !inicioDoDia(agora).After(periodo.Fim) {
+		return nil, i18n.NovoErro(i18n.MesNaoTerminou)
+	}
+
+	fechamento, err := s.fechamentoDAL.GetFechamento(userID, periodo.MesReferencia)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		fechamento = &types.FechamentoMes{UserID: userID, MesReferencia: periodo.MesReferencia}
+	}
+	if fechamento.Status == statusMesFechado {
+		return nil, i18n.NovoErro(i18n.MesJaFechado)
+	}
+	statusAnterior := fechamento.Status
+
+	if err := s.fotografarMes(userID, fechamento); err != nil {
+		return nil, err
+	}
+	fechamento.Status = statusMesFechado
+	fechamento.FechadoEm = &agora
+	fechamento.Automatico = automatico
+
+	auditoria := &types.AuditoriaFechamento{
+		UserID:        userID,
+		Acao:          acaoFechamento,
+		TotalDespesas: fechamento.TotalDespesas,
+	}
+	if automatico {
+		auditoria.CodigoMotivo = string(i18n.MotivoFechamentoAutomatico)
+	}
+
+	// Outro fechamento do mesmo mês pode ter sido gravado depois da leitura
+	salvo, err := s.fechamentoDAL.SaveFechamento(fechamento, statusAnterior, auditoria)
+	if err != nil {
+		return nil, err
+	}
+	if !salvo {
+		return nil, i18n.NovoErro(i18n.MesJaFechado)
+	}
+	return fechamento, nil
+}
+
+func toFechamentoMesResponse(fechamento *types.FechamentoMes) *types.FechamentoMesResponse {
+	response := &types.FechamentoMesResponse{
+		MesReferencia:      formatMonthYear(fechamento.MesReferencia),
+		Status:             fechamento.Status,
+		FechadoEm:          fechamento.FechadoEm,
+		Automatico:         fechamento.Automatico,
+		TotalDespesas:      fechamento.TotalDespesas,
+		QuantidadeDespesas: fechamento.QuantidadeDespesas,
+		LimiteGeral:        fechamento.LimiteGeral,
+	}
+	if fechamento.Categorias != "" {
+		_ = json.Unmarshal([]byte(fechamento.Categorias), &response.Categorias)
+	}
+	return response
+}
+
+func (s *FechamentoService) GetFechamentos(userID uint) ([]types.FechamentoMesResponse, error) {
+	fechamentos, err := s.fechamentoDAL.GetFechamentosByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.FechamentoMesResponse
+	for i := range fechamentos {
+		response = append(response, *toFechamentoMesResponse(&fechamentos[i]))
+	}
+	return response, nil
+}
+
+// GetFechamento mostra a situação do mês. Meses nunca fechados aparecem como
+// abertos, sem totais. Os motivos gravados pelo sistema são traduzidos no
+// idioma informado.
+func (s *FechamentoService) GetFechamento(userID uint, idioma string, monthYear string) (*types.FechamentoMesResponse, error) {
+	mesReferencia, err := parseMonthYear(monthYear)
+	if err != nil {
+		return nil, err
+	}
+
+	fechamento, err := s.fechamentoDAL.GetFechamento(userID, mesReferencia)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &types.FechamentoMesResponse{MesReferencia: formatMonthYear(mesReferencia), Status: statusMesAberto}, nil
+		}
+		return nil, err
+	}
+
+	auditorias, err := s.fechamentoDAL.GetAuditorias(fechamento.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toFechamentoMesResponse(fechamento)
+	for _, auditoria := range auditorias {
+		motivo := auditoria.Motivo
+		if auditoria.CodigoMotivo != "" {
+			motivo = i18n.Traduzir(idioma, i18n.Codigo(auditoria.CodigoMotivo))
+		}
+		response.Historico = append(response.Historico, types.AuditoriaFechamentoResponse{
+			Acao:          auditoria.Acao,
+			Motivo:        motivo,
+			CodigoMotivo:  auditoria.CodigoMotivo,
+			UserID:        auditoria.UserID,
+			TotalDespesas: auditoria.TotalDespesas,
+			Data:          auditoria.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+func (s *FechamentoService) FecharMes(userID uint, monthYear string) (*types.FechamentoMesResponse, error) {
+	mesReferencia, err := parseMonthYear(monthYear)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verificarPermissao(userID); err != nil {
+		return nil, err
+	}
+
+	fechamento, err := s.fecharMes(userID, mesReferencia, false, s.periodoService.agora(userID))
+	if err != nil {
+		return nil, err
+	}
+	return toFechamentoMesResponse(fechamento), nil
+}
+
+// ReabrirMes libera o mês para alterações. O motivo é obrigatório e fica no
+// histórico, junto com o total de despesas no momento da reabertura.
+func (s *FechamentoService) ReabrirMes(userID uint, monthYear string, req *types.ReabrirMesRequest) (*types.FechamentoMesResponse, error) {
+	mesReferencia, err := parseMonthYear(monthYear)
+	if err != nil {
+		return nil, err
+	}
+
+	motivo := strings.TrimSpace(req.Motivo)
+	if motivo == "" {
+		return nil, i18n.NovoErro(i18n.MotivoReaberturaObrigatorio)
+	}
+
+	if err := s.verificarPermissao(userID); err != nil {
+		return nil, err
+	}
+
+	fechamento, err := s.fechamentoDAL.GetFechamento(userID, mesReferencia)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err != nil || fechamento.Status != statusMesFechado {
+		return nil, i18n.NovoErro(i18n.MesNaoFechado)
+	}
+
+	total, err := s.despesaDAL.GetTotalByUserAndMonth(userID, mesReferencia, "")
+	if err != nil {
+		return nil, err
+	}
+
+	fechamento.Status = statusMesReaberto
+	auditoria := &types.AuditoriaFechamento{
+		UserID:        userID,
+		Acao:          acaoReabertura,
+		Motivo:        motivo,
+		TotalDespesas: arredondar(total),
+	}
+	salvo, err := s.fechamentoDAL.SaveFechamento(fechamento, statusMesFechado, auditoria)
+	if err != nil {
+		return nil, err
+	}
+	if !salvo {
+		return nil, i18n.NovoErro(i18n.MesNaoFechado)
+	}
+
+	return toFechamentoMesResponse(fechamento), nil
+}
+
+func (s *FechamentoService) GetConfiguracao(userID uint) (*types.ConfiguracaoFechamentoResponse, error) {
+	configuracao, err := s.fechamentoDAL.GetConfiguracao(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &types.ConfiguracaoFechamentoResponse{}, nil
+		}
+		return nil, err
+	}
+	return &types.ConfiguracaoFechamentoResponse{Automatico: configuracao.Automatico, DiasCarencia: configuracao.DiasCarencia}, nil
+}
+
+func (s *FechamentoService) SalvarConfiguracao(userID uint, req *types.ConfiguracaoFechamentoRequest) (*types.ConfiguracaoFechamentoResponse, error) {
+	if req.DiasCarencia < 0 || req.DiasCarencia > maxDiasCarenciaFechamento {
+		return nil, i18n.NovoErro(i18n.DiasCarenciaInvalidos, maxDiasCarenciaFechamento)
+	}
+
+	configuracao, err := s.fechamentoDAL.GetConfiguracao(userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		configuracao = &types.ConfiguracaoFechamento{UserID: userID}
+	}
+
+	configuracao.Automatico = req.Automatico
+	configuracao.DiasCarencia = req.DiasCarencia
+	if err := s.fechamentoDAL.SaveConfiguracao(configuracao); err != nil {
+		return nil, err
+	}
+
+	return &types.ConfiguracaoFechamentoResponse{Automatico: configuracao.Automatico, DiasCarencia: configuracao.DiasCarencia}, nil
+}
+
+// FecharMesesAutomaticamente fecha os meses cujo período terminou há mais
+// dias que a carência configurada. Meses reabertos não são fechados de novo:
+// depois de uma reabertura, o fechamento volta a ser manual.
+func (s *FechamentoService) FecharMesesAutomaticamente(referencia time.Time) (int, error) {
+	configuracoes, err := s.fechamentoDAL.GetConfiguracoesAutomaticas()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	var ultimoErro error
+	for _, configuracao := range configuracoes {
+		// Na família, só a configuração dos administradores fecha meses
+		pode, err := s.familiaService.podeGerenciarOrcamento(configuracao.UserID)
+		if err != nil {
+			ultimoErro = fmt.Errorf("usuário %d: %w", configuracao.UserID, err)
+			continue
+		}
+		if !pode {
+			continue
+		}
+
+		// O prazo conta em dias do fuso do usuário
+		agora := s.periodoService.noFuso(configuracao.UserID, referencia)
+		resolvedor := s.periodoService.resolvedor(configuracao.UserID)
+		mesAtual := resolvedor.mesReferenciaDe(agora)
+		for i := mesesFechamentoAutomatico; i >= 1; i-- {
+			mes := mesAtual.AddDate(0, -i, 0)
+			prazo := resolvedor.periodoDoMes(mes).Fim.AddDate(0, 0, configuracao.DiasCarencia+1)
+			if inicioDoDia(agora).Before(prazo) {
+				continue
+			}
+
+			_, err := s.fechamentoDAL.GetFechamento(configuracao.UserID, mes)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				ultimoErro = fmt.Errorf("usuário %d: %w", configuracao.UserID, err)
+				continue
+			}
+
+			// Um fechamento manual simultâneo não é erro do job
+			if _, err := s.fecharMes(configuracao.UserID, mes, true, agora); err != nil {
+				if i18n.TemCodigo(err, i18n.MesJaFechado) {
+					continue
+				}
+				ultimoErro = fmt.Errorf("usuário %d: %w", configuracao.UserID, err)
+				continue
+			}
+			total++
+		}
+	}
+
+	return total, ultimoErro
+}
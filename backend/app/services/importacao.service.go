@@ -0,0 +1,687 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/parsers"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const (
+	importacaoOrigemCSV       = "csv"
+	importacaoOrigemOFX       = "ofx"
+	importacaoStatusConcluida = "concluida"
+	importacaoStatusDesfeita  = "desfeita"
+	maxLinhasImportacao       = 10000
+)
+
+var (
+	aliasesColunaDescricao = []string{"descricao", "historico", "lancamento", "estabelecimento", "description", "memo"}
+	aliasesColunaValor     = []string{"valor", "valor (r$)", "quantia", "montante", "value", "amount"}
+	aliasesColunaData      = []string{"data", "data lancamento", "data da compra", "date", "dt"}
+
+	acentos = strings.NewReplacer(
+		"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+		"é", "e", "ê", "e", "è", "e",
+		"í", "i", "ì", "i",
+		"ó", "o", "ô", "o", "õ", "o", "ò", "o",
+		"ú", "u", "ü", "u", "ù", "u",
+		"ç", "c",
+	)
+)
+
+type ImportacaoService struct {
+	importacaoDAL     *dal.ImportacaoDAL
+	despesaDAL        *dal.DespesaDAL
+	despesaService    *DespesaService
+	regraService      *RegraService
+	periodoService    *PeriodoService
+	fechamentoService *FechamentoService
+}
+
+func NewImportacaoService(importacaoDAL *dal.ImportacaoDAL, despesaDAL *dal.DespesaDAL, despesaService *DespesaService, regraService *RegraService, periodoService *PeriodoService, fechamentoService *FechamentoService) *ImportacaoService {
+	return &ImportacaoService{
+		importacaoDAL:     importacaoDAL,
+		despesaDAL:        despesaDAL,
+		despesaService:    despesaService,
+		regraService:      regraService,
+		periodoService:    periodoService,
+		fechamentoService: fechamentoService,
+	}
+}
+
+// verificadorMesFechado consulta cada mês uma única vez por arquivo.
+// Importações não gravam em meses fechados.
+func (s *ImportacaoService) verificadorMesFechado(userID uint) func(mesReferencia time.Time) (bool, error) {
+	fechados := make(map[time.Time]bool)
+	return func(mesReferencia time.Time) (bool, error) {
+		if fechado, ok := fechados[mesReferencia]; ok {
+			return fechado, nil
+		}
+		fechado, err := s.fechamentoService.mesFechado(userID, mesReferencia)
+		if err != nil {
+			return false, err
+		}
+		fechados[mesReferencia] = fechado
+		return fechado, nil
+	}
+}
+
+func (s *ImportacaoService) detectarDuplicatas(userID uint, despesas []types.Despesa) ([][]uint, error) {
+	duplicatas := make([][]uint, len(despesas))
+	if len(despesas) == 0 {
+		return duplicatas, nil
+	}
+
+	inicio, fim := limitesMesesDespesas(despesas)
+	existentes, err := s.despesaDAL.GetDespesasByUserBetweenMonths(userID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range despesas {
+		if candidatos := filtrarDuplicatas(&despesas[i], existentes); len(candidatos) > 0 {
+			duplicatas[i] = idsDespesas(candidatos)
+		}
+	}
+
+	return duplicatas, nil
+}
+
+func normalizarTexto(texto string) string {
+	return acentos.Replace(strings.ToLower(strings.TrimSpace(texto)))
+}
+
+func resolverColuna(colunas []string, informada string, aliases []string, nome string) (int, error) {
+	informada = strings.TrimSpace(informada)
+
+	if informada != "" {
+		if indice, err := strconv.Atoi(informada); err == nil {
+			if indice < 1 || indice > len(colunas) {
+				return -1, i18n.NovoErro(i18n.ColunaIndiceInexistente, indice)
+			}
+			return indice - 1, nil
+		}
+
+		alvo := normalizarTexto(informada)
+		for i, coluna := range colunas {
+			if normalizarTexto(coluna) == alvo {
+				return i, nil
+			}
+		}
+		return -1, i18n.NovoErro(i18n.ColunaNomeInexistente, informada)
+	}
+
+	for _, alias := range aliases {
+		for i, coluna := range colunas {
+			if normalizarTexto(coluna) == alias {
+				return i, nil
+			}
+		}
+	}
+
+	return -1, i18n.NovoErro(i18n.ColunaNaoIdentificada, nome)
+}
+
+func parseDelimitador(delimitador string) (rune, error) {
+	switch delimitador {
+	case "tab", "\\t", "\t":
+		return '\t', nil
+	}
+	if utf8.RuneCountInString(delimitador) != 1 {
+		return 0, i18n.NovoErro(i18n.DelimitadorInvalido)
+	}
+	r, _ := utf8.DecodeRuneInString(delimitador)
+	return r, nil
+}
+
+func formatarDelimitador(delimitador rune) string {
+	if delimitador == '\t' {
+		return "tab"
+	}
+	return string(delimitador)
+}
+
+func campoCSV(registro []string, indice int) string {
+	if indice < 0 || indice >= len(registro) {
+		return ""
+	}
+	return strings.TrimSpace(registro[indice])
+}
+
+// tipoLinhaCSV classifica a linha pelo sinal do valor, como no OFX: débitos
+// viram despesas e créditos, receitas. Com apenasNegativos os créditos são
+// ignorados e o tipo fica vazio.
+func tipoLinhaCSV(valor float64, apenasNegativos bool) string {
+	switch {
+	case valor < 0:
+		return "despesa"
+	case apenasNegativos:
+		return ""
+	default:
+		return "receita"
+	}
+}
+
+// processarCSV traduz o erro de cada linha para o idioma informado, já que
+// as linhas vão no corpo da pré-visualização.
+func (s *ImportacaoService) processarCSV(userID uint, idioma string, conteudo []byte, mapeamento *types.MapeamentoCSV) (*types.PreviewImportacaoResponse, []types.Despesa, []types.Receita, error) {
+	if len(conteudo) == 0 {
+		return nil, nil, nil, i18n.NovoErro(i18n.ArquivoVazio)
+	}
+
+	texto, encoding, err := parsers.DecodificarTexto(conteudo, mapeamento.Encoding)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	delimitador := parsers.DetectarDelimitador(texto)
+	if mapeamento.Delimitador != "" {
+		if delimitador, err = parseDelimitador(mapeamento.Delimitador); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	linhas, err := parsers.LerCSV(texto, delimitador)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var colunas []string
+	if mapeamento.SemCabecalho {
+		if len(linhas) > 0 {
+			for i := range linhas[0] {
+				colunas = append(colunas, strconv.Itoa(i+1))
+			}
+		}
+	} else if len(linhas) > 0 {
+		colunas = linhas[0]
+		linhas = linhas[1:]
+	}
+
+	if len(linhas) == 0 {
+		return nil, nil, nil, i18n.NovoErro(i18n.ArquivoSemLinhas)
+	}
+	if len(linhas) > maxLinhasImportacao {
+		return nil, nil, nil, i18n.NovoErro(i18n.ArquivoExcessoLinhas, maxLinhasImportacao)
+	}
+
+	colunaDescricao, err := resolverColuna(colunas, mapeamento.Descricao, aliasesColunaDescricao, "descricao")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	colunaValor, err := resolverColuna(colunas, mapeamento.Valor, aliasesColunaValor, "valor")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	colunaData, err := resolverColuna(colunas, mapeamento.Data, aliasesColunaData, "data")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	amostrasValor := make([]string, 0, 50)
+	amostrasData := make([]string, 0, 50)
+	for i := 0; i < len(linhas) && i < 50; i++ {
+		amostrasValor = append(amostrasValor, campoCSV(linhas[i], colunaValor))
+		amostrasData = append(amostrasData, campoCSV(linhas[i], colunaData))
+	}
+
+	separadorDecimal := mapeamento.SeparadorDecimal
+	if separadorDecimal == "" {
+		separadorDecimal = parsers.DetectarSeparadorDecimal(amostrasValor)
+	} else if separadorDecimal != "," && separadorDecimal != "." {
+		return nil, nil, nil, i18n.NovoErro(i18n.SeparadorDecimalInvalido)
+	}
+
+	formatoData := mapeamento.FormatoData
+	if formatoData == "" {
+		formatoData = parsers.DetectarFormatoData(amostrasData)
+	}
+
+	resolvido := *mapeamento
+	resolvido.Descricao = colunas[colunaDescricao]
+	resolvido.Valor = colunas[colunaValor]
+	resolvido.Data = colunas[colunaData]
+	resolvido.FormatoData = formatoData
+	resolvido.Delimitador = formatarDelimitador(delimitador)
+	resolvido.Encoding = encoding
+	resolvido.SeparadorDecimal = separadorDecimal
+
+	preview := &types.PreviewImportacaoResponse{
+		Encoding:         encoding,
+		Delimitador:      resolvido.Delimitador,
+		SeparadorDecimal: separadorDecimal,
+		FormatoData:      formatoData,
+		Colunas:          colunas,
+		Mapeamento:       resolvido,
+		TotalLinhas:      len(linhas),
+	}
+
+	primeiraLinha := 2
+	if mapeamento.SemCabecalho {
+		primeiraLinha = 1
+	}
+
+	resolvedor := s.periodoService.resolvedor(userID)
+	mesFechado := s.verificadorMesFechado(userID)
+
+	var despesas []types.Despesa
+	var receitas []types.Receita
+	var linhaDe []int
+	for i, registro := range linhas {
+		linha := types.LinhaImportacao{
+			Linha:     primeiraLinha + i,
+			Descricao: campoCSV(registro, colunaDescricao),
+		}
+
+		valor, errValor := parsers.ParseValor(campoCSV(registro, colunaValor), separadorDecimal)
+		data, errData := parsers.ParseData(campoCSV(registro, colunaData), formatoData)
+		if errValor == nil {
+			linha.Tipo = tipoLinhaCSV(valor, mapeamento.ApenasNegativos)
+		}
+
+		var mesReferencia time.Time
+		fechado := false
+		if errData == nil {
+			mesReferencia = resolvedor.mesReferenciaDe(data)
+			if fechado, err = mesFechado(mesReferencia); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+
+		var erroLinha error
+		switch {
+		case linha.Descricao == "":
+			erroLinha = i18n.NovoErro(i18n.DescricaoObrigatoria)
+		case errValor != nil:
+			erroLinha = errValor
+		case errData != nil:
+			erroLinha = errData
+		case linha.Tipo == "":
+			linha.Ignorada = true
+		case valor == 0:
+			erroLinha = i18n.NovoErro(i18n.ImportacaoValorZero)
+		case fechado:
+			erroLinha = i18n.NovoErro(i18n.ImportacaoLinhaMesFechado, i18n.Mes(mesReferencia))
+		}
+		if erroLinha != nil {
+			linha.Erro = i18n.NovoErroItem(idioma, erroLinha)
+		}
+
+		if errValor == nil {
+			linha.Valor = math.Abs(valor)
+		}
+		if errData == nil {
+			linha.Data = data.Format("2006-01-02")
+			linha.MesReferencia = formatMonthYear(mesReferencia)
+		}
+
+		switch {
+		case linha.Erro != nil:
+			preview.LinhasComErro++
+		case linha.Ignorada:
+			preview.LinhasIgnoradas++
+		case linha.Tipo == "receita":
+			preview.LinhasValidas++
+			preview.Receitas++
+			preview.ValorReceitas += linha.Valor
+
+			dataReceita := data
+			receitas = append(receitas, types.Receita{
+				Descricao:     linha.Descricao,
+				Valor:         linha.Valor,
+				MesReferencia: mesReferencia,
+				Data:          &dataReceita,
+				UserID:        userID,
+			})
+		default:
+			preview.LinhasValidas++
+			preview.ValorTotal += linha.Valor
+
+			dataDespesa := data
+			despesas = append(despesas, types.Despesa{
+				Descricao:     linha.Descricao,
+				Valor:         linha.Valor,
+				MesReferencia: mesReferencia,
+				Data:          &dataDespesa,
+				UserID:        userID,
+			})
+			linhaDe = append(linhaDe, len(preview.Linhas))
+		}
+
+		preview.Linhas = append(preview.Linhas, linha)
+	}
+
+	if err := s.regraService.AplicarRegrasEmLote(userID, despesas); err != nil {
+		return nil, nil, nil, err
+	}
+	for i := range despesas {
+		preview.Linhas[linhaDe[i]].Descricao = despesas[i].Descricao
+		preview.Linhas[linhaDe[i]].Categoria = despesas[i].Categoria
+	}
+
+	duplicatas, err := s.detectarDuplicatas(userID, despesas)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var importaveis []types.Despesa
+	for i := range despesas {
+		if len(duplicatas[i]) > 0 {
+			linha := &preview.Linhas[linhaDe[i]]
+			linha.PossivelDuplicata = true
+			linha.Duplicatas = duplicatas[i]
+			preview.LinhasDuplicadas++
+			if !mapeamento.ImportarDuplicatas {
+				preview.ValorTotal -= despesas[i].Valor
+				continue
+			}
+		}
+		importaveis = append(importaveis, despesas[i])
+	}
+
+	preview.ValorTotal = math.Round(preview.ValorTotal*100) / 100
+	preview.ValorReceitas = math.Round(preview.ValorReceitas*100) / 100
+
+	return preview, importaveis, receitas, nil
+}
+
+func (s *ImportacaoService) processarOFX(userID uint, conteudo []byte, importarDuplicatas bool) (*types.PreviewOFXResponse, []types.Despesa, []types.Receita, error) {
+	if len(conteudo) == 0 {
+		return nil, nil, nil, i18n.NovoErro(i18n.ArquivoVazio)
+	}
+
+	texto, _, err := parsers.DecodificarTexto(conteudo, "")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	extrato, err := parsers.ParseOFX(texto)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(extrato.Transacoes) == 0 {
+		return nil, nil, nil, i18n.NovoErro(i18n.ArquivoSemTransacoes)
+	}
+	if len(extrato.Transacoes) > maxLinhasImportacao {
+		return nil, nil, nil, i18n.NovoErro(i18n.ArquivoExcessoTransacoes, maxLinhasImportacao)
+	}
+
+	fitids := make([]string, 0, len(extrato.Transacoes))
+	for _, transacao := range extrato.Transacoes {
+		fitids = append(fitids, transacao.FITID)
+	}
+
+	existentes, err := s.importacaoDAL.GetFITIDsExistentes(userID, extrato.ContaID, fitids)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	preview := &types.PreviewOFXResponse{
+		Versao:  extrato.Versao,
+		BancoID: extrato.BancoID,
+		ContaID: extrato.ContaID,
+		Moeda:   extrato.Moeda,
+		Total:   len(extrato.Transacoes),
+	}
+
+	resolvedor := s.periodoService.resolvedor(userID)
+	mesFechado := s.verificadorMesFechado(userID)
+
+	var despesas []types.Despesa
+	var receitas []types.Receita
+	var transacaoDe []int
+	vistos := make(map[string]bool)
+
+	for _, transacao := range extrato.Transacoes {
+		data := transacao.Data
+		mesReferencia := resolvedor.mesReferenciaDe(data)
+		valor := math.Round(math.Abs(transacao.Valor)*100) / 100
+
+		item := types.TransacaoImportacaoOFX{
+			FITID:         transacao.FITID,
+			Tipo:          "despesa",
+			Descricao:     transacao.Descricao,
+			Valor:         valor,
+			Data:          data.Format("2006-01-02"),
+			MesReferencia: formatMonthYear(mesReferencia),
+			Duplicada:     existentes[transacao.FITID] || vistos[transacao.FITID],
+		}
+		if transacao.Valor > 0 {
+			item.Tipo = "receita"
+		}
+		vistos[transacao.FITID] = true
+
+		preview.Transacoes = append(preview.Transacoes, item)
+
+		if item.Duplicada {
+			preview.Duplicadas++
+			continue
+		}
+		if valor == 0 {
+			continue
+		}
+
+		fechado, err := mesFechado(mesReferencia)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if fechado {
+			preview.Transacoes[len(preview.Transacoes)-1].MesFechado = true
+			preview.EmMesesFechados++
+			continue
+		}
+
+		descricao := transacao.Descricao
+		if descricao == "" {
+			descricao = transacao.Tipo
+		}
+
+		if item.Tipo == "receita" {
+			preview.Receitas++
+			preview.ValorReceitas += valor
+			receitas = append(receitas, types.Receita{
+				Descricao:     descricao,
+				Valor:         valor,
+				MesReferencia: mesReferencia,
+				Data:          &data,
+				Conta:         extrato.ContaID,
+				FITID:         transacao.FITID,
+				UserID:        userID,
+			})
+		} else {
+			despesas = append(despesas, types.Despesa{
+				Descricao:     descricao,
+				Valor:         valor,
+				MesReferencia: mesReferencia,
+				Data:          &data,
+				Conta:         extrato.ContaID,
+				FITID:         transacao.FITID,
+				UserID:        userID,
+			})
+			transacaoDe = append(transacaoDe, len(preview.Transacoes)-1)
+		}
+	}
+
+	if err := s.regraService.AplicarRegrasEmLote(userID, despesas); err != nil {
+		return nil, nil, nil, err
+	}
+	for i := range despesas {
+		preview.Transacoes[transacaoDe[i]].Descricao = despesas[i].Descricao
+		preview.Transacoes[transacaoDe[i]].Categoria = despesas[i].Categoria
+	}
+
+	duplicatas, err := s.detectarDuplicatas(userID, despesas)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var importaveis []types.Despesa
+	for i := range despesas {
+		if len(duplicatas[i]) > 0 {
+			item := &preview.Transacoes[transacaoDe[i]]
+			item.PossivelDuplicata = true
+			item.Duplicatas = duplicatas[i]
+			preview.PossiveisDuplicatas++
+			if !importarDuplicatas {
+				continue
+			}
+		}
+		preview.Despesas++
+		preview.ValorDespesas += despesas[i].Valor
+		importaveis = append(importaveis, despesas[i])
+	}
+	despesas = importaveis
+
+	preview.ValorDespesas = math.Round(preview.ValorDespesas*100) / 100
+	preview.ValorReceitas = math.Round(preview.ValorReceitas*100) / 100
+
+	return preview, despesas, receitas, nil
+}
+
+func toImportacaoSimpleResponse(importacao *types.Importacao) *types.ImportacaoSimpleResponse {
+	return &types.ImportacaoSimpleResponse{
+		ID:          importacao.ID,
+		Origem:      importacao.Origem,
+		NomeArquivo: importacao.NomeArquivo,
+		Status:      importacao.Status,
+		Quantidade:  importacao.Quantidade,
+		ValorTotal:  importacao.ValorTotal,
+		CriadaEm:    importacao.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func (s *ImportacaoService) PreviewCSV(userID uint, idioma string, conteudo []byte, mapeamento *types.MapeamentoCSV) (*types.PreviewImportacaoResponse, error) {
+	preview, _, _, err := s.processarCSV(userID, idioma, conteudo, mapeamento)
+	return preview, err
+}
+
+func (s *ImportacaoService) ImportarCSV(userID uint, nomeArquivo string, conteudo []byte, mapeamento *types.MapeamentoCSV) (*types.ImportacaoSimpleResponse, error) {
+	// As linhas não vão na resposta da importação, só a contagem de erros
+	preview, despesas, receitas, err := s.processarCSV(userID, i18n.IdiomaPadrao, conteudo, mapeamento)
+	if err != nil {
+		return nil, err
+	}
+
+	if preview.LinhasComErro > 0 && !mapeamento.IgnorarErros {
+		return nil, i18n.NovoErro(i18n.ArquivoLinhasComErro, preview.LinhasComErro)
+	}
+	if len(despesas) == 0 && len(receitas) == 0 {
+		return nil, i18n.NovoErro(i18n.ImportacaoSemLinhasValidas)
+	}
+
+	importacao := &types.Importacao{
+		UserID:      userID,
+		Origem:      importacaoOrigemCSV,
+		NomeArquivo: nomeArquivo,
+		Status:      importacaoStatusConcluida,
+		Quantidade:  len(despesas) + len(receitas),
+		ValorTotal:  preview.ValorTotal,
+	}
+
+	if err := s.importacaoDAL.CreateImportacao(importacao, despesas, receitas); err != nil {
+		return nil, err
+	}
+	s.despesaService.notificarImportadas(userID, despesas)
+
+	return toImportacaoSimpleResponse(importacao), nil
+}
+
+func (s *ImportacaoService) PreviewOFX(userID uint, conteudo []byte, importarDuplicatas bool) (*types.PreviewOFXResponse, error) {
+	preview, _, _, err := s.processarOFX(userID, conteudo, importarDuplicatas)
+	return preview, err
+}
+
+func (s *ImportacaoService) ImportarOFX(userID uint, nomeArquivo string, conteudo []byte, importarDuplicatas bool) (*types.ImportacaoSimpleResponse, error) {
+	preview, despesas, receitas, err := s.processarOFX(userID, conteudo, importarDuplicatas)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(despesas) == 0 && len(receitas) == 0 {
+		return nil, i18n.NovoErro(i18n.ImportacaoSemTransacoesNovas)
+	}
+
+	importacao := &types.Importacao{
+		UserID:      userID,
+		Origem:      importacaoOrigemOFX,
+		NomeArquivo: nomeArquivo,
+		Status:      importacaoStatusConcluida,
+		Quantidade:  len(despesas) + len(receitas),
+		ValorTotal:  preview.ValorDespesas,
+	}
+
+	if err := s.importacaoDAL.CreateImportacao(importacao, despesas, receitas); err != nil {
+		return nil, err
+	}
+	s.despesaService.notificarImportadas(userID, despesas)
+
+	return toImportacaoSimpleResponse(importacao), nil
+}
+
+func (s *ImportacaoService) GetImportacoesByUser(userID uint) ([]types.ImportacaoSimpleResponse, error) {
+	importacoes, err := s.importacaoDAL.GetImportacoesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.ImportacaoSimpleResponse
+	for _, importacao := range importacoes {
+		response = append(response, *toImportacaoSimpleResponse(&importacao))
+	}
+
+	return response, nil
+}
+
+func (s *ImportacaoService) DesfazerImportacao(userID uint, importacaoID uint) error {
+	importacao, err := s.importacaoDAL.GetImportacaoByID(importacaoID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return i18n.NovoErro(i18n.ImportacaoNaoEncontrada)
+		}
+		return err
+	}
+
+	if importacao.Status == importacaoStatusDesfeita {
+		return i18n.NovoErro(i18n.ImportacaoJaDesfeita)
+	}
+
+	meses, err := s.importacaoDAL.GetMesesImportacao(importacao)
+	if err != nil {
+		return err
+	}
+	for _, mes := range meses {
+		fechado, err := s.fechamentoService.mesFechado(userID, mes)
+		if err != nil {
+			return err
+		}
+		if fechado {
+			return i18n.NovoErro(i18n.ImportacaoMesFechado, i18n.Mes(mes))
+		}
+	}
+
+	vinculadas, err := s.importacaoDAL.PossuiDespesasVinculadas(importacao)
+	if err != nil {
+		return err
+	}
+	if vinculadas {
+		return i18n.NovoErro(i18n.ImportacaoDespesaVinculada)
+	}
+
+	despesas, err := s.importacaoDAL.DesfazerImportacao(importacao, importacaoStatusDesfeita)
+	if err != nil {
+		return err
+	}
+
+	s.despesaService.notificarExcluidas(userID, despesas)
+	return nil
+}
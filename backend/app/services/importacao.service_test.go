@@ -0,0 +1,26 @@
+package services
+
+import "testing"
+
+func TestTipoLinhaCSV(t *testing.T) {
+	casos := []struct {
+		nome            string
+		valor           float64
+		apenasNegativos bool
+		tipo            string
+	}{
+		{nome: "débito vira despesa", valor: -12.5, tipo: "despesa"},
+		{nome: "crédito vira receita", valor: 2500, tipo: "receita"},
+		{nome: "débito com apenas negativos", valor: -12.5, apenasNegativos: true, tipo: "despesa"},
+		{nome: "crédito com apenas negativos é ignorado", valor: 2500, apenasNegativos: true},
+		{nome: "zero com apenas negativos é ignorado", valor: 0, apenasNegativos: true},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			if tipo := tipoLinhaCSV(caso.valor, caso.apenasNegativos); tipo != caso.tipo {
+				t.Errorf("tipo %q, esperava %q", tipo, caso.tipo)
+			}
+		})
+	}
+}
@@ -3,41 +3,65 @@ package services
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
 	"github.com/Vicente/Password-Mobile-App/backend/app/types"
 	"gorm.io/gorm"
 )
 
 type LimiteService struct {
-	limiteDAL *dal.LimiteDAL
+	limiteDAL         *dal.LimiteDAL
+	despesaDAL        *dal.DespesaDAL
+	periodoService    *PeriodoService
+	familiaService    *FamiliaService
+	fechamentoService *FechamentoService
 }
 
-func NewLimiteService(limiteDAL *dal.LimiteDAL) *LimiteService {
-	return &LimiteService{limiteDAL: limiteDAL}
+func NewLimiteService(limiteDAL *dal.LimiteDAL, despesaDAL *dal.DespesaDAL, periodoService *PeriodoService, familiaService *FamiliaService, fechamentoService *FechamentoService) *LimiteService {
+	return &LimiteService{limiteDAL: limiteDAL, despesaDAL: despesaDAL, periodoService: periodoService, familiaService: familiaService, fechamentoService: fechamentoService}
 }
 
+// verificarPermissao impede que membros comuns de uma família alterem os
+// limites compartilhados.
+func (s *LimiteService) verificarPermissao(userID uint) error {
+	pode, err := s.familiaService.podeGerenciarOrcamento(userID)
+	if err != nil {
+		return err
+	}
+	if !pode {
+		return i18n.NovoErro(i18n.LimiteSemPermissao)
+	}
+	return nil
+}
+
+// parseMonthYear lê um mês informado explicitamente (YYYY-MM). O resultado é
+// o rótulo do período, e não as datas dele: "2025-01" é sempre 01/01/2025,
+// mesmo num ciclo que começa no dia 5. As datas do período saem de
+// resolvedorPeriodo.periodoDoMes; só datas de despesas passam por
+// mesReferenciaDe.
 func parseMonthYear(monthYear string) (time.Time, error) {
 	parts := strings.Split(monthYear, "-")
 	if len(parts) != 2 {
-		return time.Time{}, errors.New("formato de mês inválido. Use YYYY-MM")
+		return time.Time{}, i18n.NovoErro(i18n.MesFormatoInvalido)
 	}
 
 	year, err := strconv.Atoi(parts[0])
 	if err != nil {
-		return time.Time{}, errors.New("ano inválido")
+		return time.Time{}, i18n.NovoErro(i18n.AnoInvalido)
 	}
 
 	month, err := strconv.Atoi(parts[1])
 	if err != nil {
-		return time.Time{}, errors.New("mês inválido")
+		return time.Time{}, i18n.NovoErro(i18n.MesInvalido)
 	}
 
 	if month < 1 || month > 12 {
-		return time.Time{}, errors.New("mês deve estar entre 1 e 12")
+		return time.Time{}, i18n.NovoErro(i18n.MesForaDoIntervalo)
 	}
 
 	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), nil
@@ -47,10 +71,153 @@ func formatMonthYear(t time.Time) string {
 	return fmt.Sprintf("%04d-%02d", t.Year(), int(t.Month()))
 }
 
-func isBeforeCurrentMonth(mesReferencia time.Time) bool {
-	now := time.Now().UTC()
-	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-	return mesReferencia.Before(currentMonth)
+func arredondar(valor float64) float64 {
+	return math.Round(valor*100) / 100
+}
+
+func chaveLimite(categoria string, mesReferencia time.Time) string {
+	return strings.ToLower(categoria) + "|" + formatMonthYear(mesReferencia)
+}
+
+// calculadoraTransporte calcula, sob demanda, quanto da sobra (ou do excesso)
+// do mês anterior é transportado para limites com rollover. O cálculo é
+// sempre refeito a partir das despesas, então alterações em meses passados
+// refletem automaticamente nos meses seguintes.
+type calculadoraTransporte struct {
+	userID      uint
+	despesaDAL  *dal.DespesaDAL
+	limites     map[string]*types.Limite
+	gastos      map[string]float64
+	transportes map[string]float64
+}
+
+func (s *LimiteService) novaCalculadoraTransporte(userID uint) (*calculadoraTransporte, error) {
+	return criarCalculadoraTransporte(s.limiteDAL, s.despesaDAL, userID)
+}
+
+// criarCalculadoraTransporte recebe os DALs para que o fechamento de mês,
+// que não depende do LimiteService, também calcule o valor efetivo.
+func criarCalculadoraTransporte(limiteDAL *dal.LimiteDAL, despesaDAL *dal.DespesaDAL, userID uint) (*calculadoraTransporte, error) {
+	limites, err := limiteDAL.GetLimitesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	calc := &calculadoraTransporte{
+		userID:      userID,
+		despesaDAL:  despesaDAL,
+		limites:     make(map[string]*types.Limite),
+		gastos:      make(map[string]float64),
+		transportes: make(map[string]float64),
+	}
+	for i := range limites {
+		calc.limites[chaveLimite(limites[i].Categoria, limites[i].MesReferencia)] = &limites[i]
+	}
+
+	return calc, nil
+}
+
+func (c *calculadoraTransporte) gasto(categoria string, mesReferencia time.Time) (float64, error) {
+	chave := chaveLimite(categoria, mesReferencia)
+	if gasto, ok := c.gastos[chave]; ok {
+		return gasto, nil
+	}
+
+	gasto, err := c.despesaDAL.GetTotalByUserAndMonth(c.userID, mesReferencia, categoria)
+	if err != nil {
+		return 0, err
+	}
+	c.gastos[chave] = gasto
+	return gasto, nil
+}
+
+func (c *calculadoraTransporte) transporte(limite *types.Limite) (float64, error) {
+	if !limite.Rollover {
+		return 0, nil
+	}
+
+	chave := chaveLimite(limite.Categoria, limite.MesReferencia)
+	if transporte, ok := c.transportes[chave]; ok {
+		return transporte, nil
+	}
+
+	mesAnterior := limite.MesReferencia.AddDate(0, -1, 0)
+	transporte := 0.0
+
+	if anterior, ok := c.limites[chaveLimite(limite.Categoria, mesAnterior)]; ok {
+		transporteAnterior, err := c.transporte(anterior)
+		if err != nil {
+			return 0, err
+		}
+
+		gasto, err := c.gasto(limite.Categoria, mesAnterior)
+		if err != nil {
+			return 0, err
+		}
+
+		transporte = anterior.Valor + transporteAnterior - gasto
+		if limite.RolloverTeto != nil {
+			transporte = math.Max(-*limite.RolloverTeto, math.Min(*limite.RolloverTeto, transporte))
+		}
+		transporte = arredondar(transporte)
+	}
+
+	c.transportes[chave] = transporte
+	return transporte, nil
+}
+
+func (c *calculadoraTransporte) response(limite *types.Limite) (*types.LimiteSimpleResponse, error) {
+	transporte, err := c.transporte(limite)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.LimiteSimpleResponse{
+		ID:                limite.ID,
+		Valor:             limite.Valor,
+		MesReferencia:     formatMonthYear(limite.MesReferencia),
+		Categoria:         limite.Categoria,
+		Rollover:          limite.Rollover,
+		RolloverTeto:      limite.RolloverTeto,
+		ValorTransportado: transporte,
+		ValorEfetivo:      arredondar(limite.Valor + transporte),
+	}, nil
+}
+
+func (s *LimiteService) toLimiteSimpleResponse(limite *types.Limite) (*types.LimiteSimpleResponse, error) {
+	calc, err := s.novaCalculadoraTransporte(limite.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return calc.response(limite)
+}
+
+func toLimiteConsumoResponse(limite *types.LimiteSimpleResponse, gasto float64) *types.LimiteConsumoResponse {
+	response := &types.LimiteConsumoResponse{
+		ID:                limite.ID,
+		Categoria:         limite.Categoria,
+		Valor:             limite.Valor,
+		ValorTransportado: limite.ValorTransportado,
+		ValorEfetivo:      limite.ValorEfetivo,
+		Gasto:             arredondar(gasto),
+		Restante:          arredondar(limite.ValorEfetivo - gasto),
+	}
+	if limite.ValorEfetivo > 0 {
+		response.PercentualUsado = math.Round(gasto/limite.ValorEfetivo*10000) / 100
+	}
+	return response
+}
+
+// normalizarRolloverTeto trata o teto zero como "sem teto": um teto zero
+// equivaleria a desligar o rollover, e é assim que a edição remove o teto.
+func normalizarRolloverTeto(teto *float64) (*float64, error) {
+	if teto == nil || *teto == 0 {
+		return nil, nil
+	}
+	if *teto < 0 {
+		return nil, i18n.NovoErro(i18n.TetoRolloverNegativo)
+	}
+	return teto, nil
 }
 
 func (s *LimiteService) CreateLimite(userID uint, req *types.CreateLimiteRequest) (*types.LimiteSimpleResponse, error) {
@@ -59,21 +226,38 @@ func (s *LimiteService) CreateLimite(userID uint, req *types.CreateLimiteRequest
 		return nil, err
 	}
 
-	if isBeforeCurrentMonth(mesReferencia) {
-		return nil, errors.New("não é possível criar limite para meses anteriores ao mês corrente")
+	if err := s.fechamentoService.verificarMesAberto(userID, mesReferencia, i18n.MesFechadoCriarLimite); err != nil {
+		return nil, err
+	}
+
+	if err := s.verificarPermissao(userID); err != nil {
+		return nil, err
+	}
+
+	rolloverTeto, err := normalizarRolloverTeto(req.RolloverTeto)
+	if err != nil {
+		return nil, err
 	}
 
-	exists, err := s.limiteDAL.ExistsLimiteForMonth(userID, mesReferencia)
+	categoria := strings.TrimSpace(req.Categoria)
+
+	exists, err := s.limiteDAL.ExistsLimiteForMonth(userID, mesReferencia, categoria)
 	if err != nil {
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("já existe um limite criado para este mês")
+		if categoria != "" {
+			return nil, i18n.NovoErro(i18n.LimiteCategoriaDuplicado)
+		}
+		return nil, i18n.NovoErro(i18n.LimiteMesDuplicado)
 	}
 
 	limite := &types.Limite{
 		Valor:         req.Valor,
 		MesReferencia: mesReferencia,
+		Categoria:     categoria,
+		Rollover:      req.Rollover,
+		RolloverTeto:  rolloverTeto,
 		UserID:        userID,
 	}
 
@@ -81,11 +265,7 @@ func (s *LimiteService) CreateLimite(userID uint, req *types.CreateLimiteRequest
 		return nil, err
 	}
 
-	return &types.LimiteSimpleResponse{
-		ID:            limite.ID,
-		Valor:         limite.Valor,
-		MesReferencia: formatMonthYear(limite.MesReferencia),
-	}, nil
+	return s.toLimiteSimpleResponse(limite)
 }
 
 func (s *LimiteService) GetLimiteByMonth(userID uint, monthYear string) (*types.LimiteSimpleResponse, error) {
@@ -97,16 +277,12 @@ func (s *LimiteService) GetLimiteByMonth(userID uint, monthYear string) (*types.
 	limite, err := s.limiteDAL.GetLimiteByUserAndMonth(userID, mesReferencia)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("limite não encontrado para este mês")
+			return nil, i18n.NovoErro(i18n.LimiteNaoEncontradoMes)
 		}
 		return nil, err
 	}
 
-	return &types.LimiteSimpleResponse{
-		ID:            limite.ID,
-		Valor:         limite.Valor,
-		MesReferencia: formatMonthYear(limite.MesReferencia),
-	}, nil
+	return s.toLimiteSimpleResponse(limite)
 }
 
 func (s *LimiteService) GetLimitesByUser(userID uint) ([]types.LimiteSimpleResponse, error) {
@@ -115,13 +291,18 @@ func (s *LimiteService) GetLimitesByUser(userID uint) ([]types.LimiteSimpleRespo
 		return nil, err
 	}
 
+	calc, err := s.novaCalculadoraTransporte(userID)
+	if err != nil {
+		return nil, err
+	}
+
 	var response []types.LimiteSimpleResponse
-	for _, limite := range limites {
-		response = append(response, types.LimiteSimpleResponse{
-			ID:            limite.ID,
-			Valor:         limite.Valor,
-			MesReferencia: formatMonthYear(limite.MesReferencia),
-		})
+	for i := range limites {
+		limite, err := calc.response(&limites[i])
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, *limite)
 	}
 
 	return response, nil
@@ -131,40 +312,259 @@ func (s *LimiteService) UpdateLimite(userID uint, limiteID uint, req *types.Upda
 	limite, err := s.limiteDAL.GetLimiteByID(limiteID, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("limite não encontrado")
+			return nil, i18n.NovoErro(i18n.LimiteNaoEncontrado)
 		}
 		return nil, err
 	}
 
-	if isBeforeCurrentMonth(limite.MesReferencia) {
-		return nil, errors.New("não é possível editar limite de meses anteriores ao mês corrente")
+	if err := s.fechamentoService.verificarMesAberto(userID, limite.MesReferencia, i18n.MesFechadoEditarLimite); err != nil {
+		return nil, err
+	}
+
+	if err := s.verificarPermissao(userID); err != nil {
+		return nil, err
+	}
+
+	rolloverTeto, err := normalizarRolloverTeto(req.RolloverTeto)
+	if err != nil {
+		return nil, err
 	}
 
 	limite.Valor = req.Valor
+	if req.Rollover != nil {
+		limite.Rollover = *req.Rollover
+	}
+	// Sem o campo, o teto atual é mantido; com zero, é removido
+	if req.RolloverTeto != nil {
+		limite.RolloverTeto = rolloverTeto
+	}
 
 	if err := s.limiteDAL.UpdateLimite(limite); err != nil {
 		return nil, err
 	}
 
-	return &types.LimiteSimpleResponse{
-		ID:            limite.ID,
-		Valor:         limite.Valor,
-		MesReferencia: formatMonthYear(limite.MesReferencia),
-	}, nil
+	return s.toLimiteSimpleResponse(limite)
 }
 
 func (s *LimiteService) DeleteLimite(userID uint, limiteID uint) error {
 	limite, err := s.limiteDAL.GetLimiteByID(limiteID, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("limite não encontrado")
+			return i18n.NovoErro(i18n.LimiteNaoEncontrado)
 		}
 		return err
 	}
 
-	if isBeforeCurrentMonth(limite.MesReferencia) {
-		return errors.New("não é possível excluir limite de meses anteriores ao mês corrente")
+	if err := s.fechamentoService.verificarMesAberto(userID, limite.MesReferencia, i18n.MesFechadoExcluirLimite); err != nil {
+		return err
+	}
+
+	if err := s.verificarPermissao(userID); err != nil {
+		return err
 	}
 
 	return s.limiteDAL.DeleteLimite(limiteID, userID)
-} 
\ No newline at end of file
+}
+
+func (s *LimiteService) GetLimitesDoMes(userID uint, monthYear string) (*types.LimitesMesResponse, error) {
+	mesReferencia, err := parseMonthYear(monthYear)
+	if err != nil {
+		return nil, err
+	}
+
+	limites, err := s.limiteDAL.GetLimitesByUserAndMonth(userID, mesReferencia)
+	if err != nil {
+		return nil, err
+	}
+
+	totais, err := s.despesaDAL.GetTotaisPorCategoria(userID, mesReferencia)
+	if err != nil {
+		return nil, err
+	}
+
+	gastoTotal := 0.0
+	gastoPorCategoria := make(map[string]float64)
+	for _, total := range totais {
+		gastoTotal += total.Total
+		gastoPorCategoria[strings.ToLower(total.Categoria)] += total.Total
+	}
+
+	calc, err := s.novaCalculadoraTransporte(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.LimitesMesResponse{
+		MesReferencia: formatMonthYear(mesReferencia),
+		Categorias:    []types.LimiteConsumoResponse{},
+	}
+	for i := range limites {
+		limite, err := calc.response(&limites[i])
+		if err != nil {
+			return nil, err
+		}
+		if limite.Categoria == "" {
+			response.Geral = toLimiteConsumoResponse(limite, gastoTotal)
+			continue
+		}
+		response.Categorias = append(response.Categorias, *toLimiteConsumoResponse(limite, gastoPorCategoria[strings.ToLower(limite.Categoria)]))
+	}
+
+	return response, nil
+}
+
+const (
+	modoLimiteDesativado     = "desativado"
+	modoLimiteValorPadrao    = "valor_padrao"
+	modoLimiteCopiarAnterior = "copiar_anterior"
+)
+
+func toConfiguracaoLimiteResponse(configuracao *types.ConfiguracaoLimite) *types.ConfiguracaoLimiteResponse {
+	return &types.ConfiguracaoLimiteResponse{
+		Modo:         configuracao.Modo,
+		ValorPadrao:  configuracao.ValorPadrao,
+		Rollover:     configuracao.Rollover,
+		RolloverTeto: configuracao.RolloverTeto,
+	}
+}
+
+func (s *LimiteService) GetConfiguracao(userID uint) (*types.ConfiguracaoLimiteResponse, error) {
+	configuracao, err := s.limiteDAL.GetConfiguracao(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &types.ConfiguracaoLimiteResponse{Modo: modoLimiteDesativado}, nil
+		}
+		return nil, err
+	}
+
+	return toConfiguracaoLimiteResponse(configuracao), nil
+}
+
+func (s *LimiteService) SalvarConfiguracao(userID uint, req *types.ConfiguracaoLimiteRequest) (*types.ConfiguracaoLimiteResponse, error) {
+	switch req.Modo {
+	case modoLimiteDesativado, modoLimiteCopiarAnterior:
+	case modoLimiteValorPadrao:
+		if req.ValorPadrao <= 0 {
+			return nil, i18n.NovoErro(i18n.ValorPadraoNaoPositivo)
+		}
+	default:
+		return nil, i18n.NovoErro(i18n.LimiteModoInvalido)
+	}
+
+	rolloverTeto, err := normalizarRolloverTeto(req.RolloverTeto)
+	if err != nil {
+		return nil, err
+	}
+
+	configuracao, err := s.limiteDAL.GetConfiguracao(userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		configuracao = &types.ConfiguracaoLimite{UserID: userID}
+	}
+
+	configuracao.Modo = req.Modo
+	configuracao.ValorPadrao = req.ValorPadrao
+	configuracao.Rollover = req.Rollover
+	configuracao.RolloverTeto = rolloverTeto
+
+	if err := s.limiteDAL.SaveConfiguracao(configuracao); err != nil {
+		return nil, err
+	}
+
+	return toConfiguracaoLimiteResponse(configuracao), nil
+}
+
+func (s *LimiteService) criarLimiteAutomatico(userID uint, req *types.CreateLimiteRequest, mesReferencia time.Time) (bool, error) {
+	exists, err := s.limiteDAL.ExistsLimiteForMonth(userID, mesReferencia, req.Categoria)
+	if err != nil || exists {
+		return false, err
+	}
+
+	if _, err := s.CreateLimite(userID, req); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *LimiteService) criarLimitesAutomaticosDoUsuario(configuracao *types.ConfiguracaoLimite, mesReferencia time.Time) (int, error) {
+	mes := formatMonthYear(mesReferencia)
+
+	if configuracao.Modo == modoLimiteValorPadrao {
+		criado, err := s.criarLimiteAutomatico(configuracao.UserID, &types.CreateLimiteRequest{
+			Valor:         configuracao.ValorPadrao,
+			MesReferencia: mes,
+			Rollover:      configuracao.Rollover,
+			RolloverTeto:  configuracao.RolloverTeto,
+		}, mesReferencia)
+		if criado {
+			return 1, err
+		}
+		return 0, err
+	}
+
+	mesOrigem, err := s.limiteDAL.GetUltimoMesComLimite(configuracao.UserID, mesReferencia)
+	if err != nil || mesOrigem == nil {
+		return 0, err
+	}
+
+	anteriores, err := s.limiteDAL.GetLimitesByUserAndMonth(configuracao.UserID, *mesOrigem)
+	if err != nil {
+		return 0, err
+	}
+
+	criados := 0
+	for _, anterior := range anteriores {
+		criado, err := s.criarLimiteAutomatico(configuracao.UserID, &types.CreateLimiteRequest{
+			Valor:         anterior.Valor,
+			MesReferencia: mes,
+			Categoria:     anterior.Categoria,
+			Rollover:      anterior.Rollover,
+			RolloverTeto:  anterior.RolloverTeto,
+		}, mesReferencia)
+		if err != nil {
+			return criados, err
+		}
+		if criado {
+			criados++
+		}
+	}
+
+	return criados, nil
+}
+
+// CriarLimitesAutomaticos cria os limites do mês corrente para todos os
+// usuários que configuraram um valor padrão ou a cópia do mês anterior. Pode
+// ser executado várias vezes: meses que já possuem limite não são alterados.
+func (s *LimiteService) CriarLimitesAutomaticos(referencia time.Time) (int, error) {
+	configuracoes, err := s.limiteDAL.GetConfiguracoesAtivas(modoLimiteDesativado)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	var ultimoErro error
+	for i := range configuracoes {
+		// Na família, só a configuração dos administradores cria limites
+		pode, err := s.familiaService.podeGerenciarOrcamento(configuracoes[i].UserID)
+		if err != nil {
+			ultimoErro = fmt.Errorf("usuário %d: %w", configuracoes[i].UserID, err)
+			continue
+		}
+		if !pode {
+			continue
+		}
+
+		// O mês corrente depende do período e do fuso de cada usuário
+		agora := s.periodoService.noFuso(configuracoes[i].UserID, referencia)
+		mesReferencia := s.periodoService.resolvedor(configuracoes[i].UserID).mesReferenciaDe(agora)
+		criados, err := s.criarLimitesAutomaticosDoUsuario(&configuracoes[i], mesReferencia)
+		total += criados
+		if err != nil {
+			ultimoErro = fmt.Errorf("usuário %d: %w", configuracoes[i].UserID, err)
+		}
+	}
+
+	return total, ultimoErro
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const (
+	statusMetaEmAndamento = "em_andamento"
+	statusMetaConcluida   = "concluida"
+	statusMetaAtrasada    = "atrasada"
+)
+
+type MetaService struct {
+	metaDAL        *dal.MetaDAL
+	periodoService *PeriodoService
+}
+
+func NewMetaService(metaDAL *dal.MetaDAL, periodoService *PeriodoService) *MetaService {
+	return &MetaService{metaDAL: metaDAL, periodoService: periodoService}
+}
+
+// calcularMeta distribui o que falta pelos meses até a data alvo, contando o
+// mês corrente. Metas vencidas e não atingidas ficam atrasadas e pedem o
+// restante de uma vez.
+func calcularMeta(meta *types.Meta, totalAportado float64, mesAtual time.Time) *types.MetaResponse {
+	response := &types.MetaResponse{
+		ID:                     meta.ID,
+		Nome:                   meta.Nome,
+		ValorAlvo:              meta.ValorAlvo,
+		DataAlvo:               meta.DataAlvo.Format("2006-01-02"),
+		Conta:                  meta.Conta,
+		ContribuicaoAutomatica: meta.ContribuicaoAutomatica,
+		TotalAportado:          arredondar(totalAportado),
+		ConcluidaEm:            meta.ConcluidaEm,
+		CreatedAt:              meta.CreatedAt,
+		MesesRestantes:         mesesEntre(mesAtual, inicioDoMes(meta.DataAlvo)),
+	}
+
+	if totalAportado < meta.ValorAlvo {
+		response.Restante = arredondar(meta.ValorAlvo - totalAportado)
+	}
+	if meta.ValorAlvo > 0 {
+		response.Progresso = arredondar(totalAportado / meta.ValorAlvo * 100)
+	}
+
+	switch {
+	case response.Restante == 0:
+		response.Status = statusMetaConcluida
+	case response.MesesRestantes == 0:
+		response.Status = statusMetaAtrasada
+		response.EconomiaMensalNecessaria = response.Restante
+	default:
+		response.Status = statusMetaEmAndamento
+		response.EconomiaMensalNecessaria = arredondar(response.Restante / float64(response.MesesRestantes))
+	}
+
+	return response
+}
+
+func toAporteMetaResponse(aporte *types.AporteMeta) types.AporteMetaResponse {
+	return types.AporteMetaResponse{
+		ID:         aporte.ID,
+		Valor:      aporte.Valor,
+		Data:       aporte.Data.Format("2006-01-02"),
+		Descricao:  aporte.Descricao,
+		Automatico: aporte.Automatico,
+	}
+}
+
+func validarMeta(req *types.MetaRequest, meta *types.Meta) error {
+	nome := strings.TrimSpace(req.Nome)
+	if nome == "" {
+		return i18n.NovoErro(i18n.NomeObrigatorio)
+	}
+	if req.ValorAlvo <= 0 {
+		return i18n.NovoErro(i18n.MetaValorAlvoNaoPositivo)
+	}
+	if req.ContribuicaoAutomatica < 0 {
+		return i18n.NovoErro(i18n.MetaContribuicaoNegativa)
+	}
+
+	dataAlvo, err := time.Parse("2006-01-02", req.DataAlvo)
+	if err != nil {
+		return i18n.NovoErro(i18n.MetaDataAlvoInvalida)
+	}
+
+	meta.Nome = nome
+	meta.ValorAlvo = req.ValorAlvo
+	meta.DataAlvo = dataAlvo
+	meta.Conta = strings.TrimSpace(req.Conta)
+	meta.ContribuicaoAutomatica = req.ContribuicaoAutomatica
+	return nil
+}
+
+func (s *MetaService) getMeta(userID uint, metaID uint) (*types.Meta, error) {
+	meta, err := s.metaDAL.GetMetaByID(metaID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, i18n.NovoErro(i18n.MetaNaoEncontrada)
+		}
+		return nil, err
+	}
+	return meta, nil
+}
+
+// atualizarConclusao registra quando a meta foi atingida, ou desfaz o registro
+// se um aporte excluído a deixou abaixo do alvo.
+func (s *MetaService) atualizarConclusao(meta *types.Meta, totalAportado float64) error {
+	concluida := totalAportado >= meta.ValorAlvo
+	if concluida == (meta.ConcluidaEm != nil) {
+		return nil
+	}
+
+	if concluida {
+		agora := s.periodoService.relogio.Agora().UTC()
+		meta.ConcluidaEm = &agora
+	} else {
+		meta.ConcluidaEm = nil
+	}
+	return s.metaDAL.UpdateMeta(meta)
+}
+
+func (s *MetaService) detalharMeta(userID uint, meta *types.Meta) (*types.MetaDetalheResponse, error) {
+	aportes, err := s.metaDAL.GetAportesByMeta(meta.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0.0
+	detalhe := &types.MetaDetalheResponse{Aportes: []types.AporteMetaResponse{}}
+	for i := range aportes {
+		total += aportes[i].Valor
+		detalhe.Aportes = append(detalhe.Aportes, toAporteMetaResponse(&aportes[i]))
+	}
+
+	if err := s.atualizarConclusao(meta, total); err != nil {
+		return nil, err
+	}
+
+	detalhe.MetaResponse = *calcularMeta(meta, total, s.periodoService.mesAtual(userID))
+	return detalhe, nil
+}
+
+func (s *MetaService) CreateMeta(userID uint, req *types.MetaRequest) (*types.MetaResponse, error) {
+	meta := &types.Meta{UserID: userID}
+	if err := validarMeta(req, meta); err != nil {
+		return nil, err
+	}
+
+	if inicioDoDia(meta.DataAlvo).Before(inicioDoDia(s.periodoService.agora(userID))) {
+		return nil, i18n.NovoErro(i18n.MetaDataAlvoPassada)
+	}
+
+	if err := s.metaDAL.CreateMeta(meta); err != nil {
+		return nil, err
+	}
+
+	return calcularMeta(meta, 0, s.periodoService.mesAtual(userID)), nil
+}
+
+func (s *MetaService) GetMetasByUser(userID uint) ([]types.MetaResponse, error) {
+	metas, err := s.metaDAL.GetMetasByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(metas) == 0 {
+		return []types.MetaResponse{}, nil
+	}
+
+	totais, err := s.metaDAL.GetTotaisAportados(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	mesAtual := s.periodoService.mesAtual(userID)
+	var response []types.MetaResponse
+	for i := range metas {
+		response = append(response, *calcularMeta(&metas[i], totais[metas[i].ID], mesAtual))
+	}
+	return response, nil
+}
+
+func (s *MetaService) GetMetaByID(userID uint, metaID uint) (*types.MetaDetalheResponse, error) {
+	meta, err := s.getMeta(userID, metaID)
+	if err != nil {
+		return nil, err
+	}
+	return s.detalharMeta(userID, meta)
+}
+
+// UpdateMeta permite mover a data alvo para o passado apenas se ela já
+// estava lá; o status da meta é recalculado com o novo alvo.
+func (s *MetaService) UpdateMeta(userID uint, metaID uint, req *types.MetaRequest) (*types.MetaDetalheResponse, error) {
+	meta, err := s.getMeta(userID, metaID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataAnterior := meta.DataAlvo
+	if err := validarMeta(req, meta); err != nil {
+		return nil, err
+	}
+
+	hoje := inicioDoDia(s.periodoService.agora(userID))
+	if !meta.DataAlvo.Equal(dataAnterior) && inicioDoDia(meta.DataAlvo).Before(hoje) {
+		return nil, i18n.NovoErro(i18n.MetaDataAlvoPassada)
+	}
+
+	if err := s.metaDAL.UpdateMeta(meta); err != nil {
+		return nil, err
+	}
+
+	return s.detalharMeta(userID, meta)
+}
+
+func (s *MetaService) DeleteMeta(userID uint, metaID uint) error {
+	if _, err := s.getMeta(userID, metaID); err != nil {
+		return err
+	}
+	return s.metaDAL.DeleteMeta(metaID, userID)
+}
+
+func (s *MetaService) RegistrarAporte(userID uint, metaID uint, req *types.AporteMetaRequest) (*types.MetaDetalheResponse, error) {
+	meta, err := s.getMeta(userID, metaID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Valor <= 0 {
+		return nil, i18n.NovoErro(i18n.ValorNaoPositivo)
+	}
+
+	data := inicioDoDia(s.periodoService.agora(userID))
+	if req.Data != "" {
+		if data, err = time.Parse("2006-01-02", req.Data); err != nil {
+			return nil, i18n.NovoErro(i18n.DataInvalida)
+		}
+	}
+
+	aporte := &types.AporteMeta{
+		MetaID:    meta.ID,
+		UserID:    userID,
+		Valor:     req.Valor,
+		Data:      data,
+		Descricao: strings.TrimSpace(req.Descricao),
+	}
+	if err := s.metaDAL.CreateAporte(aporte); err != nil {
+		return nil, err
+	}
+
+	return s.detalharMeta(userID, meta)
+}
+
+func (s *MetaService) DeleteAporte(userID uint, metaID uint, aporteID uint) (*types.MetaDetalheResponse, error) {
+	meta, err := s.getMeta(userID, metaID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.metaDAL.GetAporteByID(aporteID, metaID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, i18n.NovoErro(i18n.AporteNaoEncontrado)
+		}
+		return nil, err
+	}
+
+	if err := s.metaDAL.DeleteAporte(aporteID, metaID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.detalharMeta(userID, meta)
+}
+
+// registrarAporteAutomatico aporta a contribuição automática no mês, sem
+// passar do que falta para a meta. Cada mês recebe no máximo um aporte
+// automático, mesmo que ele tenha sido excluído depois.
+func (s *MetaService) registrarAporteAutomatico(meta *types.Meta, referencia time.Time) (bool, error) {
+	referencia = s.periodoService.noFuso(meta.UserID, referencia)
+	mesReferencia := s.periodoService.resolvedor(meta.UserID).mesReferenciaDe(referencia)
+	if mesReferencia.Before(inicioDoMes(s.periodoService.noFuso(meta.UserID, meta.CreatedAt))) {
+		return false, nil
+	}
+
+	total, err := s.metaDAL.GetTotalAportado(meta.ID, meta.UserID)
+	if err != nil {
+		return false, err
+	}
+	if total >= meta.ValorAlvo {
+		return false, s.atualizarConclusao(meta, total)
+	}
+
+	valor := meta.ContribuicaoAutomatica
+	if restante := meta.ValorAlvo - total; valor > restante {
+		valor = arredondar(restante)
+	}
+
+	chave := "automatico:" + formatMonthYear(mesReferencia)
+	criado, err := s.metaDAL.CreateAporteUnico(&types.AporteMeta{
+		MetaID:     meta.ID,
+		UserID:     meta.UserID,
+		Valor:      valor,
+		Data:       inicioDoDia(referencia),
+		Descricao:  "Contribuição automática",
+		Automatico: true,
+		Chave:      &chave,
+	})
+	if err != nil || !criado {
+		return false, err
+	}
+
+	return true, s.atualizarConclusao(meta, total+valor)
+}
+
+// RegistrarAportesAutomaticos registra a contribuição do mês corrente de
+// todas as metas com contribuição automática. Pode ser executado várias
+// vezes: meses que já receberam o aporte não são alterados.
+func (s *MetaService) RegistrarAportesAutomaticos(referencia time.Time) (int, error) {
+	metas, err := s.metaDAL.GetMetasComContribuicaoAutomatica()
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	var ultimoErro error
+	for i := range metas {
+		criado, err := s.registrarAporteAutomatico(&metas[i], referencia)
+		if err != nil {
+			ultimoErro = fmt.Errorf("meta %d: %w", metas[i].ID, err)
+			continue
+		}
+		if criado {
+			total++
+		}
+	}
+
+	return total, ultimoErro
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type NotificacaoService struct {
+	notificacaoDAL *dal.NotificacaoDAL
+	relogio        relogio.Relogio
+}
+
+func NewNotificacaoService(notificacaoDAL *dal.NotificacaoDAL, relogio relogio.Relogio) *NotificacaoService {
+	return &NotificacaoService{notificacaoDAL: notificacaoDAL, relogio: relogio}
+}
+
+func toNotificacaoSimpleResponse(notificacao *types.Notificacao) *types.NotificacaoSimpleResponse {
+	response := &types.NotificacaoSimpleResponse{
+		ID:         notificacao.ID,
+		Tipo:       notificacao.Tipo,
+		Titulo:     notificacao.Titulo,
+		Mensagem:   notificacao.Mensagem,
+		Categoria:  notificacao.Categoria,
+		Percentual: notificacao.Percentual,
+		Lida:       notificacao.Lida,
+		CriadaEm:   notificacao.CreatedAt.Format(time.RFC3339),
+	}
+	if notificacao.MesReferencia != nil {
+		response.MesReferencia = formatMonthYear(*notificacao.MesReferencia)
+	}
+	if notificacao.LidaEm != nil {
+		response.LidaEm = notificacao.LidaEm.Format(time.RFC3339)
+	}
+	return response
+}
+
+func (s *NotificacaoService) GetNotificacoes(userID uint, apenasNaoLidas bool) (*types.NotificacoesResponse, error) {
+	notificacoes, err := s.notificacaoDAL.GetNotificacoesByUser(userID, apenasNaoLidas)
+	if err != nil {
+		return nil, err
+	}
+
+	naoLidas, err := s.notificacaoDAL.CountNaoLidas(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.NotificacoesResponse{
+		NaoLidas:     naoLidas,
+		Notificacoes: []types.NotificacaoSimpleResponse{},
+	}
+	for i := range notificacoes {
+		response.Notificacoes = append(response.Notificacoes, *toNotificacaoSimpleResponse(&notificacoes[i]))
+	}
+
+	return response, nil
+}
+
+func (s *NotificacaoService) getNotificacao(userID uint, notificacaoID uint) (*types.Notificacao, error) {
+	notificacao, err := s.notificacaoDAL.GetNotificacaoByID(notificacaoID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, i18n.NovoErro(i18n.NotificacaoNaoEncontrada)
+		}
+		return nil, err
+	}
+	return notificacao, nil
+}
+
+func (s *NotificacaoService) MarcarLida(userID uint, notificacaoID uint, lida bool) (*types.NotificacaoSimpleResponse, error) {
+	notificacao, err := s.getNotificacao(userID, notificacaoID)
+	if err != nil {
+		return nil, err
+	}
+
+	notificacao.Lida = lida
+	notificacao.LidaEm = nil
+	if lida {
+		agora := s.relogio.Agora().UTC()
+		notificacao.LidaEm = &agora
+	}
+
+	if err := s.notificacaoDAL.UpdateNotificacao(notificacao); err != nil {
+		return nil, err
+	}
+
+	return toNotificacaoSimpleResponse(notificacao), nil
+}
+
+func (s *NotificacaoService) MarcarTodasComoLidas(userID uint) (int64, error) {
+	return s.notificacaoDAL.MarcarTodasComoLidas(userID, s.relogio.Agora().UTC())
+}
+
+func (s *NotificacaoService) DeleteNotificacao(userID uint, notificacaoID uint) error {
+	if _, err := s.getNotificacao(userID, notificacaoID); err != nil {
+		return err
+	}
+
+	return s.notificacaoDAL.DeleteNotificacao(notificacaoID, userID)
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/push"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+)
+
+const (
+	canalOutboxPush = "push"
+
+	statusOutboxPendente   = "pendente"
+	statusOutboxEnviada    = "enviada"
+	statusOutboxDescartada = "descartada"
+	statusOutboxFalhou     = "falhou"
+
+	maxTentativasOutbox = 8
+	loteOutbox          = 50
+	reservaOutbox       = 5 * time.Minute
+	esperaBaseOutbox    = 30 * time.Second
+	esperaMaximaOutbox  = time.Hour
+)
+
+// novaMensagemPush monta a mensagem de saída de uma notificação. Ela deve ser
+// gravada junto com a notificação, na mesma transação.
+func novaMensagemPush(notificacao *types.Notificacao, agora time.Time) *types.MensagemOutbox {
+	dados, _ := json.Marshal(map[string]interface{}{
+		"tipo":      notificacao.Tipo,
+		"categoria": notificacao.Categoria,
+	})
+
+	return &types.MensagemOutbox{
+		UserID:           notificacao.UserID,
+		Canal:            canalOutboxPush,
+		Titulo:           notificacao.Titulo,
+		Conteudo:         notificacao.Mensagem,
+		Dados:            string(dados),
+		Status:           statusOutboxPendente,
+		ProximaTentativa: agora,
+	}
+}
+
+// esperaOutbox calcula o intervalo até a próxima tentativa: dobra a cada
+// falha, a partir de 30 segundos, até o máximo de uma hora.
+func esperaOutbox(tentativas int) time.Duration {
+	espera := esperaBaseOutbox
+	for i := 1; i < tentativas && espera < esperaMaximaOutbox; i++ {
+		espera *= 2
+	}
+	if espera > esperaMaximaOutbox {
+		espera = esperaMaximaOutbox
+	}
+	return espera
+}
+
+type OutboxService struct {
+	outboxDAL    *dal.OutboxDAL
+	pushProvider push.PushProvider
+}
+
+func NewOutboxService(outboxDAL *dal.OutboxDAL, pushProvider push.PushProvider) *OutboxService {
+	return &OutboxService{
+		outboxDAL:    outboxDAL,
+		pushProvider: pushProvider,
+	}
+}
+
+// ProcessarPendentes entrega um lote de mensagens prontas para envio e
+// retorna quantas foram processadas.
+func (s *OutboxService) ProcessarPendentes(ctx context.Context, agora time.Time) (int, error) {
+	mensagens, err := s.outboxDAL.ReservarPendentes(agora, loteOutbox, reservaOutbox, statusOutboxPendente)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range mensagens {
+		mensagem := &mensagens[i]
+
+		var envioErr error
+		switch mensagem.Canal {
+		case canalOutboxPush:
+			envioErr = s.enviarPush(ctx, mensagem, agora)
+		default:
+			mensagem.Status = statusOutboxDescartada
+			mensagem.UltimoErro = "canal desconhecido"
+		}
+		registrarTentativa(mensagem, envioErr, agora)
+
+		if err := s.outboxDAL.UpdateMensagem(mensagem); err != nil {
+			log.Printf("Falha ao atualizar a mensagem %d da outbox: %v", mensagem.ID, err)
+		}
+	}
+
+	return len(mensagens), nil
+}
+
+// registrarTentativa aplica o resultado de uma tentativa de envio. Uma falha
+// devolve a mensagem para a fila com espera crescente; depois de
+// maxTentativasOutbox falhas, ela fica como falhou e não é mais enviada.
+func registrarTentativa(mensagem *types.MensagemOutbox, envioErr error, agora time.Time) {
+	if envioErr != nil {
+		mensagem.Tentativas++
+		mensagem.UltimoErro = envioErr.Error()
+		if mensagem.Tentativas >= maxTentativasOutbox {
+			mensagem.Status = statusOutboxFalhou
+		} else {
+			mensagem.Status = statusOutboxPendente
+			mensagem.ProximaTentativa = agora.Add(esperaOutbox(mensagem.Tentativas))
+		}
+	}
+
+	if mensagem.Status != statusOutboxPendente {
+		processadaEm := agora
+		mensagem.ProcessadaEm = &processadaEm
+	}
+}
+
+func (s *OutboxService) enviarPush(ctx context.Context, mensagem *types.MensagemOutbox, agora time.Time) error {
+	dispositivos, err := s.outboxDAL.GetDispositivosAtivos(mensagem.UserID)
+	if err != nil {
+		return err
+	}
+
+	if len(dispositivos) == 0 {
+		mensagem.Status = statusOutboxDescartada
+		mensagem.UltimoErro = "usuário sem dispositivos ativos"
+		return nil
+	}
+
+	tokens := make([]string, len(dispositivos))
+	for i, dispositivo := range dispositivos {
+		tokens[i] = dispositivo.Token
+	}
+
+	entregues, invalidos, envioErr := entregarPush(ctx, s.pushProvider, mensagem, tokens)
+
+	if err := s.outboxDAL.DesativarDispositivos(invalidos); err != nil {
+		log.Printf("Falha ao desativar dispositivos inválidos: %v", err)
+	}
+	if err := s.outboxDAL.RegistrarUso(entregues, agora); err != nil {
+		log.Printf("Falha ao registrar uso dos dispositivos: %v", err)
+	}
+
+	return envioErr
+}
+
+// entregarPush envia a mensagem aos tokens e define o status dela. Retorna os
+// tokens que receberam e os inválidos, para que os dispositivos sejam
+// atualizados, e o erro que deve levar a uma nova tentativa.
+func entregarPush(ctx context.Context, pushProvider push.PushProvider, mensagem *types.MensagemOutbox, tokens []string) ([]string, []string, error) {
+	var dados map[string]interface{}
+	if mensagem.Dados != "" {
+		_ = json.Unmarshal([]byte(mensagem.Dados), &dados)
+	}
+	if mensagem.NotificacaoID != nil {
+		if dados == nil {
+			dados = make(map[string]interface{})
+		}
+		dados["notificacaoId"] = strconv.FormatUint(uint64(*mensagem.NotificacaoID), 10)
+	}
+
+	mensagensPush := make([]push.Mensagem, len(tokens))
+	for i, token := range tokens {
+		mensagensPush[i] = push.Mensagem{
+			Token:  token,
+			Titulo: mensagem.Titulo,
+			Corpo:  mensagem.Conteudo,
+			Dados:  dados,
+		}
+	}
+
+	resultados, err := pushProvider.Enviar(ctx, mensagensPush)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entregues, invalidos []string
+	ultimoErro := ""
+	for _, resultado := range resultados {
+		switch {
+		case resultado.Sucesso:
+			entregues = append(entregues, resultado.Token)
+		case resultado.TokenInvalido:
+			invalidos = append(invalidos, resultado.Token)
+		default:
+			ultimoErro = resultado.Erro
+		}
+	}
+
+	// Basta um dispositivo receber; se nenhum recebeu e algum falhou por
+	// motivo temporário, a mensagem volta para a fila
+	if len(entregues) > 0 {
+		mensagem.Status = statusOutboxEnviada
+		mensagem.UltimoErro = ultimoErro
+		return entregues, invalidos, nil
+	}
+	if ultimoErro != "" {
+		return entregues, invalidos, errors.New(ultimoErro)
+	}
+
+	mensagem.Status = statusOutboxDescartada
+	mensagem.UltimoErro = "todos os dispositivos estão inválidos"
+	return entregues, invalidos, nil
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/push"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+)
+
+func novaMensagemOutboxTeste(agora time.Time) *types.MensagemOutbox {
+	notificacaoID := uint(7)
+	mensagem := novaMensagemPush(&types.Notificacao{UserID: 1, Tipo: tipoNotificacaoLimite, Titulo: "Limite", Mensagem: "80% do limite"}, agora)
+	mensagem.NotificacaoID = &notificacaoID
+	return mensagem
+}
+
+func TestEntregarPush(t *testing.T) {
+	agora := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	casos := []struct {
+		nome      string
+		invalidos []string
+		falha     error
+		status    string
+		entregues []string
+		erro      bool
+	}{
+		{nome: "todos recebem", status: statusOutboxEnviada, entregues: []string{"a", "b"}},
+		{nome: "um dispositivo inválido", invalidos: []string{"b"}, status: statusOutboxEnviada, entregues: []string{"a"}},
+		{nome: "todos inválidos", invalidos: []string{"a", "b"}, status: statusOutboxDescartada},
+		{nome: "falha no envio", falha: errors.New("sem conexão"), status: statusOutboxPendente, erro: true},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			provedor := push.NewFakeProvider()
+			provedor.Falha = caso.falha
+			for _, token := range caso.invalidos {
+				provedor.Invalidos[token] = true
+			}
+
+			mensagem := novaMensagemOutboxTeste(agora)
+			entregues, invalidos, err := entregarPush(context.Background(), provedor, mensagem, []string{"a", "b"})
+			if (err != nil) != caso.erro {
+				t.Fatalf("erro %v, esperava erro: %v", err, caso.erro)
+			}
+			if mensagem.Status != caso.status {
+				t.Errorf("status %s, esperava %s", mensagem.Status, caso.status)
+			}
+			if !reflect.DeepEqual(entregues, caso.entregues) {
+				t.Errorf("entregues %v, esperava %v", entregues, caso.entregues)
+			}
+			if !reflect.DeepEqual(invalidos, caso.invalidos) {
+				t.Errorf("inválidos %v, esperava %v", invalidos, caso.invalidos)
+			}
+
+			enviadas := provedor.Enviadas()
+			if len(enviadas) != len(caso.entregues) {
+				t.Fatalf("provedor recebeu %d mensagens, esperava %d", len(enviadas), len(caso.entregues))
+			}
+			for _, enviada := range enviadas {
+				if enviada.Titulo != "Limite" || enviada.Dados["notificacaoId"] != "7" || enviada.Dados["tipo"] != tipoNotificacaoLimite {
+					t.Errorf("mensagem enviada %+v", enviada)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistrarTentativaReenviaComEspera(t *testing.T) {
+	agora := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	provedor := push.NewFakeProvider()
+	provedor.Falha = errors.New("serviço indisponível")
+	mensagem := novaMensagemOutboxTeste(agora)
+
+	esperas := []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute}
+	for i, espera := range esperas {
+		_, _, err := entregarPush(context.Background(), provedor, mensagem, []string{"a"})
+		registrarTentativa(mensagem, err, agora)
+
+		if mensagem.Status != statusOutboxPendente || mensagem.Tentativas != i+1 {
+			t.Fatalf("tentativa %d: status %s com %d tentativas", i+1, mensagem.Status, mensagem.Tentativas)
+		}
+		if !mensagem.ProximaTentativa.Equal(agora.Add(espera)) {
+			t.Errorf("tentativa %d: próxima em %v, esperava %v", i+1, mensagem.ProximaTentativa.Sub(agora), espera)
+		}
+		if mensagem.ProcessadaEm != nil || mensagem.UltimoErro != "serviço indisponível" {
+			t.Errorf("tentativa %d: processada em %v, último erro %q", i+1, mensagem.ProcessadaEm, mensagem.UltimoErro)
+		}
+	}
+
+	// O serviço volta e a mensagem é entregue na tentativa seguinte
+	provedor.Falha = nil
+	_, _, err := entregarPush(context.Background(), provedor, mensagem, []string{"a"})
+	registrarTentativa(mensagem, err, agora)
+
+	if mensagem.Status != statusOutboxEnviada || mensagem.ProcessadaEm == nil {
+		t.Errorf("status %s, processada em %v; esperava enviada", mensagem.Status, mensagem.ProcessadaEm)
+	}
+	if mensagem.Tentativas != len(esperas) {
+		t.Errorf("%d tentativas com falha, esperava %d", mensagem.Tentativas, len(esperas))
+	}
+	if len(provedor.Enviadas()) != 1 {
+		t.Errorf("provedor recebeu %d mensagens, esperava 1", len(provedor.Enviadas()))
+	}
+}
+
+func TestRegistrarTentativaDesisteDepoisDoMaximo(t *testing.T) {
+	agora := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+	provedor := push.NewFakeProvider()
+	provedor.Falha = errors.New("serviço indisponível")
+	mensagem := novaMensagemOutboxTeste(agora)
+
+	for i := 1; i <= maxTentativasOutbox; i++ {
+		_, _, err := entregarPush(context.Background(), provedor, mensagem, []string{"a"})
+		registrarTentativa(mensagem, err, agora)
+
+		if i < maxTentativasOutbox && mensagem.Status != statusOutboxPendente {
+			t.Fatalf("tentativa %d: status %s, esperava pendente", i, mensagem.Status)
+		}
+	}
+
+	if mensagem.Status != statusOutboxFalhou {
+		t.Fatalf("status %s depois de %d tentativas, esperava falhou", mensagem.Status, mensagem.Tentativas)
+	}
+	if mensagem.ProcessadaEm == nil || !mensagem.ProcessadaEm.Equal(agora) {
+		t.Errorf("processada em %v, esperava %v", mensagem.ProcessadaEm, agora)
+	}
+	if mensagem.Tentativas != maxTentativasOutbox {
+		t.Errorf("%d tentativas, esperava %d", mensagem.Tentativas, maxTentativasOutbox)
+	}
+	if len(provedor.Enviadas()) != 0 {
+		t.Errorf("provedor recebeu %d mensagens, esperava nenhuma", len(provedor.Enviadas()))
+	}
+}
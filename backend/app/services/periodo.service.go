@@ -0,0 +1,333 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const (
+	tipoPeriodoMensal    = "mensal"
+	tipoPeriodoSemanal   = "semanal"
+	tipoPeriodoQuinzenal = "quinzenal"
+
+	maxDiaInicioPeriodo     = 28
+	maxDiaUtilInicioPeriodo = 10
+
+	fusoHorarioPadrao = "America/Sao_Paulo"
+)
+
+// carregarFusoHorario aceita apenas nomes da base IANA, como
+// America/Sao_Paulo. Abreviações e deslocamentos fixos são recusados porque
+// não acompanham o horário de verão.
+func carregarFusoHorario(nome string) (*time.Location, error) {
+	if nome != "UTC" && !strings.Contains(nome, "/") {
+		return nil, i18n.NovoErro(i18n.FusoHorarioInvalido)
+	}
+	localizacao, err := time.LoadLocation(nome)
+	if err != nil {
+		return nil, i18n.NovoErro(i18n.FusoHorarioInvalido)
+	}
+	return localizacao, nil
+}
+
+// Periodo é um intervalo de datas fechado. MesReferencia é o mês ao qual o
+// período pertence: no ciclo mensal é o rótulo do ciclo (um ciclo que começa
+// em 05/01 e termina em 04/02 é o mês 2025-01); nos demais, o mês do início.
+type Periodo struct {
+	Inicio        time.Time
+	Fim           time.Time
+	MesReferencia time.Time
+}
+
+func inicioDoDia(data time.Time) time.Time {
+	return time.Date(data.Year(), data.Month(), data.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func inicioDoMes(data time.Time) time.Time {
+	return time.Date(data.Year(), data.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// diasDoPeriodo retorna quantos dias o período possui e quantos já se
+// passaram até `agora`, contando o dia atual.
+func diasDoPeriodo(periodo Periodo, agora time.Time) (int, int) {
+	total := int(periodo.Fim.Sub(periodo.Inicio).Hours()/24) + 1
+	hoje := inicioDoDia(agora)
+
+	switch {
+	case hoje.Before(periodo.Inicio):
+		return total, 0
+	case hoje.After(periodo.Fim):
+		return total, total
+	default:
+		return total, int(hoje.Sub(periodo.Inicio).Hours()/24) + 1
+	}
+}
+
+// resolvedorPeriodo traduz datas em períodos de orçamento conforme a
+// configuração do usuário. O valor zero equivale ao mês civil.
+type resolvedorPeriodo struct {
+	tipo        string
+	diaInicio   int
+	diaUtil     bool
+	diaSemana   time.Weekday
+	dataInicio  time.Time
+	valorPadrao float64
+}
+
+var resolvedorMesCivil = &resolvedorPeriodo{tipo: tipoPeriodoMensal, diaInicio: 1}
+
+func novoResolvedorPeriodo(configuracao *types.ConfiguracaoPeriodo) *resolvedorPeriodo {
+	resolvedor := &resolvedorPeriodo{
+		tipo:        configuracao.Tipo,
+		diaInicio:   configuracao.DiaInicio,
+		diaUtil:     configuracao.DiaUtil,
+		diaSemana:   time.Weekday(configuracao.DiaSemana),
+		valorPadrao: configuracao.ValorPadrao,
+	}
+	if resolvedor.diaInicio < 1 {
+		resolvedor.diaInicio = 1
+	}
+	if configuracao.DataInicio != nil {
+		resolvedor.dataInicio = inicioDoDia(*configuracao.DataInicio)
+	}
+	return resolvedor
+}
+
+// inicioDoCiclo retorna o primeiro dia do ciclo mensal rotulado com o mês
+// informado. Dias úteis são de segunda a sexta, sem considerar feriados.
+func (r *resolvedorPeriodo) inicioDoCiclo(mesReferencia time.Time) time.Time {
+	mes := inicioDoMes(mesReferencia)
+	if !r.diaUtil {
+		return mes.AddDate(0, 0, r.diaInicio-1)
+	}
+
+	diasUteis := 0
+	for dia := mes; ; dia = dia.AddDate(0, 0, 1) {
+		if dia.Weekday() != time.Saturday && dia.Weekday() != time.Sunday {
+			diasUteis++
+			if diasUteis == r.diaInicio {
+				return dia
+			}
+		}
+	}
+}
+
+// periodoDoMes retorna o período rotulado com o mês informado. Nos ciclos
+// semanal e quinzenal, corresponde ao mês civil.
+func (r *resolvedorPeriodo) periodoDoMes(mesReferencia time.Time) Periodo {
+	mes := inicioDoMes(mesReferencia)
+	if r.tipo != tipoPeriodoMensal {
+		return Periodo{Inicio: mes, Fim: mes.AddDate(0, 1, -1), MesReferencia: mes}
+	}
+
+	return Periodo{
+		Inicio:        r.inicioDoCiclo(mes),
+		Fim:           r.inicioDoCiclo(mes.AddDate(0, 1, 0)).AddDate(0, 0, -1),
+		MesReferencia: mes,
+	}
+}
+
+func (r *resolvedorPeriodo) periodoDe(data time.Time) Periodo {
+	data = inicioDoDia(data)
+
+	switch r.tipo {
+	case tipoPeriodoSemanal, tipoPeriodoQuinzenal:
+		recuo := (int(data.Weekday()) - int(r.diaSemana) + 7) % 7
+		inicio := data.AddDate(0, 0, -recuo)
+		duracao := 7
+
+		if r.tipo == tipoPeriodoQuinzenal {
+			duracao = 14
+			// As quinzenas são contadas a partir da data de início configurada
+			semanas := int(inicio.Sub(r.dataInicio).Hours() / 24 / 7)
+			if semanas%2 != 0 {
+				inicio = inicio.AddDate(0, 0, -7)
+			}
+		}
+
+		return Periodo{Inicio: inicio, Fim: inicio.AddDate(0, 0, duracao-1), MesReferencia: inicioDoMes(inicio)}
+	default:
+		mes := inicioDoMes(data)
+		if data.Before(r.inicioDoCiclo(mes)) {
+			mes = mes.AddDate(0, -1, 0)
+		}
+		return r.periodoDoMes(mes)
+	}
+}
+
+// mesReferenciaDe retorna o mês de referência de uma despesa feita na data.
+// Nos ciclos semanal e quinzenal é o próprio mês civil da data.
+func (r *resolvedorPeriodo) mesReferenciaDe(data time.Time) time.Time {
+	if r.tipo != tipoPeriodoMensal {
+		return inicioDoMes(data)
+	}
+	return r.periodoDe(data).MesReferencia
+}
+
+type PeriodoService struct {
+	periodoDAL         *dal.PeriodoDAL
+	preferenciaService *PreferenciaService
+	relogio            relogio.Relogio
+}
+
+func NewPeriodoService(periodoDAL *dal.PeriodoDAL, preferenciaService *PreferenciaService, relogio relogio.Relogio) *PeriodoService {
+	return &PeriodoService{periodoDAL: periodoDAL, preferenciaService: preferenciaService, relogio: relogio}
+}
+
+// localizacao nunca falha: se o fuso das preferências for inválido, vale o
+// fuso padrão.
+func (s *PeriodoService) localizacao(userID uint) *time.Location {
+	if localizacao, err := carregarFusoHorario(s.preferenciaService.preferencias(userID).FusoHorario); err == nil {
+		return localizacao
+	}
+
+	localizacao, err := carregarFusoHorario(fusoHorarioPadrao)
+	if err != nil {
+		return time.UTC
+	}
+	return localizacao
+}
+
+// noFuso converte um instante para o fuso do usuário. As funções de data
+// (inicioDoDia, inicioDoMes) usam o dia do próprio valor, então o
+// resultado já produz as datas locais corretas.
+func (s *PeriodoService) noFuso(userID uint, instante time.Time) time.Time {
+	return instante.In(s.localizacao(userID))
+}
+
+// agora é o instante atual no fuso do usuário. Toda conta de "hoje" e de
+// "mês atual" deve partir daqui, nunca de time.Now().
+func (s *PeriodoService) agora(userID uint) time.Time {
+	return s.noFuso(userID, s.relogio.Agora())
+}
+
+// resolvedorPadrao vale para quem não configurou o período: o mês começa no
+// dia de início das preferências, que normalmente é o mês civil.
+func (s *PeriodoService) resolvedorPadrao(userID uint) *resolvedorPeriodo {
+	diaInicio := s.preferenciaService.preferencias(userID).DiaInicioMes
+	if diaInicio <= 1 || diaInicio > maxDiaInicioPeriodo {
+		return resolvedorMesCivil
+	}
+	return &resolvedorPeriodo{tipo: tipoPeriodoMensal, diaInicio: diaInicio}
+}
+
+// resolvedor nunca falha: sem configuração, ou se ela não puder ser lida,
+// vale o resolvedor padrão.
+func (s *PeriodoService) resolvedor(userID uint) *resolvedorPeriodo {
+	configuracao, err := s.periodoDAL.GetConfiguracao(userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Falha ao carregar o período do usuário %d: %v", userID, err)
+		}
+		return s.resolvedorPadrao(userID)
+	}
+	return novoResolvedorPeriodo(configuracao)
+}
+
+func (s *PeriodoService) mesAtual(userID uint) time.Time {
+	return s.resolvedor(userID).mesReferenciaDe(s.agora(userID))
+}
+
+// anteriorAoMesAtual compara com o mês corrente do período do usuário: com
+// um ciclo que começa no dia 5, em 03/02 o mês corrente ainda é janeiro. Não
+// é um bloqueio de edição; para isso, veja FechamentoService.mesFechado.
+func (s *PeriodoService) anteriorAoMesAtual(userID uint, mesReferencia time.Time) bool {
+	return inicioDoMes(mesReferencia).Before(s.mesAtual(userID))
+}
+
+func toConfiguracaoPeriodoResponse(configuracao *types.ConfiguracaoPeriodo) *types.ConfiguracaoPeriodoResponse {
+	response := &types.ConfiguracaoPeriodoResponse{Tipo: configuracao.Tipo}
+
+	switch configuracao.Tipo {
+	case tipoPeriodoMensal:
+		response.DiaInicio = configuracao.DiaInicio
+		response.DiaUtil = configuracao.DiaUtil
+	default:
+		diaSemana := configuracao.DiaSemana
+		response.DiaSemana = &diaSemana
+		response.ValorPadrao = configuracao.ValorPadrao
+		if configuracao.DataInicio != nil {
+			response.DataInicio = configuracao.DataInicio.Format("2006-01-02")
+		}
+	}
+
+	return response
+}
+
+func (s *PeriodoService) GetConfiguracao(userID uint) (*types.ConfiguracaoPeriodoResponse, error) {
+	configuracao, err := s.periodoDAL.GetConfiguracao(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &types.ConfiguracaoPeriodoResponse{Tipo: tipoPeriodoMensal, DiaInicio: s.resolvedorPadrao(userID).diaInicio}, nil
+		}
+		return nil, err
+	}
+
+	return toConfiguracaoPeriodoResponse(configuracao), nil
+}
+
+func (s *PeriodoService) SalvarConfiguracao(userID uint, req *types.ConfiguracaoPeriodoRequest) (*types.ConfiguracaoPeriodoResponse, error) {
+	tipo := strings.ToLower(strings.TrimSpace(req.Tipo))
+
+	novo := types.ConfiguracaoPeriodo{UserID: userID, Tipo: tipo}
+
+	switch tipo {
+	case tipoPeriodoMensal:
+		limite := maxDiaInicioPeriodo
+		if req.DiaUtil {
+			limite = maxDiaUtilInicioPeriodo
+		}
+		if req.DiaInicio < 1 || req.DiaInicio > limite {
+			return nil, i18n.NovoErro(i18n.PeriodoDiaInicioInvalido)
+		}
+		novo.DiaInicio = req.DiaInicio
+		novo.DiaUtil = req.DiaUtil
+	case tipoPeriodoSemanal, tipoPeriodoQuinzenal:
+		if req.ValorPadrao < 0 {
+			return nil, i18n.NovoErro(i18n.ValorPadraoNegativo)
+		}
+		novo.ValorPadrao = req.ValorPadrao
+
+		if tipo == tipoPeriodoQuinzenal {
+			if req.DataInicio == "" {
+				return nil, i18n.NovoErro(i18n.PeriodoDataInicioObrigatoria)
+			}
+			dataInicio, err := time.Parse("2006-01-02", req.DataInicio)
+			if err != nil {
+				return nil, i18n.NovoErro(i18n.PeriodoDataInicioInvalida)
+			}
+			novo.DataInicio = &dataInicio
+			novo.DiaSemana = int(dataInicio.Weekday())
+		} else {
+			if req.DiaSemana < 0 || req.DiaSemana > 6 {
+				return nil, i18n.NovoErro(i18n.PeriodoDiaSemanaInvalido)
+			}
+			novo.DiaSemana = req.DiaSemana
+		}
+	default:
+		return nil, i18n.NovoErro(i18n.PeriodoTipoInvalido)
+	}
+
+	configuracao, err := s.periodoDAL.GetConfiguracao(userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		configuracao = &types.ConfiguracaoPeriodo{}
+	}
+	novo.Model = configuracao.Model
+
+	if err := s.periodoDAL.SaveConfiguracao(&novo); err != nil {
+		return nil, err
+	}
+
+	return toConfiguracaoPeriodoResponse(&novo), nil
+}
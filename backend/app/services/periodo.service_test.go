@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var errBancoIndisponivel = errors.New("banco indisponível nos testes")
+
+// bancoIndisponivel faz toda consulta falhar, então os serviços usam as
+// preferências e o período padrão, como quando a leitura falha em produção.
+type bancoIndisponivel struct{}
+
+func (bancoIndisponivel) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errBancoIndisponivel
+}
+
+func (bancoIndisponivel) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errBancoIndisponivel
+}
+
+func (bancoIndisponivel) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errBancoIndisponivel
+}
+
+func (bancoIndisponivel) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func novoPeriodoServiceSemBanco(t *testing.T, relogio relogio.Relogio) *PeriodoService {
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: bancoIndisponivel{}}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("erro ao abrir o banco: %v", err)
+	}
+	return NewPeriodoService(dal.NewPeriodoDAL(db), NewPreferenciaService(dal.NewPreferenciaDAL(db)), relogio)
+}
+
+func TestMesReferenciaNaViradaDoMes(t *testing.T) {
+	saoPaulo, err := carregarFusoHorario("America/Sao_Paulo")
+	if err != nil {
+		t.Fatalf("erro ao carregar o fuso: %v", err)
+	}
+	cicloDia5 := &resolvedorPeriodo{tipo: tipoPeriodoMensal, diaInicio: 5}
+
+	casos := []struct {
+		nome       string
+		agora      time.Time
+		fuso       *time.Location
+		resolvedor *resolvedorPeriodo
+		mes        string
+	}{
+		{
+			nome:       "último dia do mês às 23h30 local já é o mês seguinte em UTC",
+			agora:      time.Date(2025, 2, 1, 2, 30, 0, 0, time.UTC),
+			fuso:       saoPaulo,
+			resolvedor: resolvedorMesCivil,
+			mes:        "2025-01",
+		},
+		{
+			nome:       "meia-noite local do primeiro dia",
+			agora:      time.Date(2025, 2, 1, 3, 0, 0, 0, time.UTC),
+			fuso:       saoPaulo,
+			resolvedor: resolvedorMesCivil,
+			mes:        "2025-02",
+		},
+		{
+			nome:       "mesmo instante em UTC",
+			agora:      time.Date(2025, 2, 1, 2, 30, 0, 0, time.UTC),
+			fuso:       time.UTC,
+			resolvedor: resolvedorMesCivil,
+			mes:        "2025-02",
+		},
+		{
+			nome:       "virada de ano",
+			agora:      time.Date(2026, 1, 1, 2, 59, 59, 0, time.UTC),
+			fuso:       saoPaulo,
+			resolvedor: resolvedorMesCivil,
+			mes:        "2025-12",
+		},
+		{
+			nome:       "fevereiro bissexto",
+			agora:      time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC),
+			fuso:       saoPaulo,
+			resolvedor: resolvedorMesCivil,
+			mes:        "2024-02",
+		},
+		{
+			nome:       "ciclo do dia 5 na véspera do início",
+			agora:      time.Date(2025, 2, 5, 2, 30, 0, 0, time.UTC),
+			fuso:       saoPaulo,
+			resolvedor: cicloDia5,
+			mes:        "2025-01",
+		},
+		{
+			nome:       "ciclo do dia 5 no início",
+			agora:      time.Date(2025, 2, 5, 3, 0, 0, 0, time.UTC),
+			fuso:       saoPaulo,
+			resolvedor: cicloDia5,
+			mes:        "2025-02",
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			relogioFixo := relogio.NewFixoRelogio(caso.agora)
+			mes := caso.resolvedor.mesReferenciaDe(relogioFixo.Agora().In(caso.fuso))
+			if mes.Format("2006-01") != caso.mes {
+				t.Errorf("mês %s, esperava %s", mes.Format("2006-01"), caso.mes)
+			}
+		})
+	}
+}
+
+func TestDiasDoPeriodoNaViradaDoMes(t *testing.T) {
+	saoPaulo, err := carregarFusoHorario("America/Sao_Paulo")
+	if err != nil {
+		t.Fatalf("erro ao carregar o fuso: %v", err)
+	}
+
+	// 31/01 às 23h30 em São Paulo
+	relogioFixo := relogio.NewFixoRelogio(time.Date(2025, 2, 1, 2, 30, 0, 0, time.UTC))
+
+	agora := relogioFixo.Agora().In(saoPaulo)
+	periodo := resolvedorMesCivil.periodoDe(agora)
+	if periodo.MesReferencia.Format("2006-01") != "2025-01" {
+		t.Fatalf("período de %s, esperava 2025-01", periodo.MesReferencia.Format("2006-01"))
+	}
+	if total, passados := diasDoPeriodo(periodo, agora); total != 31 || passados != 31 {
+		t.Errorf("%d de %d dias, esperava 31 de 31", passados, total)
+	}
+
+	relogioFixo.Avancar(time.Hour)
+
+	agora = relogioFixo.Agora().In(saoPaulo)
+	if total, passados := diasDoPeriodo(periodo, agora); total != 31 || passados != 31 {
+		t.Errorf("depois da virada: %d de %d dias, esperava 31 de 31", passados, total)
+	}
+	periodo = resolvedorMesCivil.periodoDe(agora)
+	if total, passados := diasDoPeriodo(periodo, agora); total != 28 || passados != 1 {
+		t.Errorf("novo período: %d de %d dias, esperava 1 de 28", passados, total)
+	}
+}
+
+func TestMesInformadoEhRotuloDoPeriodo(t *testing.T) {
+	cicloDia5 := &resolvedorPeriodo{tipo: tipoPeriodoMensal, diaInicio: 5}
+	quintoDiaUtil := &resolvedorPeriodo{tipo: tipoPeriodoMensal, diaInicio: 5, diaUtil: true}
+	semanal := &resolvedorPeriodo{tipo: tipoPeriodoSemanal, diaSemana: time.Monday}
+
+	casos := []struct {
+		nome       string
+		resolvedor *resolvedorPeriodo
+		inicio     time.Time
+		fim        time.Time
+	}{
+		{
+			nome:       "mês civil",
+			resolvedor: resolvedorMesCivil,
+			inicio:     time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			fim:        time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			nome:       "ciclo do dia 5",
+			resolvedor: cicloDia5,
+			inicio:     time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+			fim:        time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			nome:       "quinto dia útil",
+			resolvedor: quintoDiaUtil,
+			inicio:     time.Date(2025, 2, 7, 0, 0, 0, 0, time.UTC),
+			fim:        time.Date(2025, 3, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			nome:       "ciclo semanal usa o mês civil",
+			resolvedor: semanal,
+			inicio:     time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			fim:        time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nome, func(t *testing.T) {
+			mes, err := parseMonthYear("2025-02")
+			if err != nil {
+				t.Fatalf("erro inesperado: %v", err)
+			}
+			if !mes.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+				t.Fatalf("rótulo %v, esperava 01/02/2025", mes)
+			}
+
+			periodo := caso.resolvedor.periodoDoMes(mes)
+			if !periodo.Inicio.Equal(caso.inicio) || !periodo.Fim.Equal(caso.fim) {
+				t.Errorf("período de %s a %s, esperava de %s a %s", periodo.Inicio.Format("2006-01-02"), periodo.Fim.Format("2006-01-02"), caso.inicio.Format("2006-01-02"), caso.fim.Format("2006-01-02"))
+			}
+			if !periodo.MesReferencia.Equal(mes) {
+				t.Errorf("período rotulado com %s, esperava 2025-02", formatMonthYear(periodo.MesReferencia))
+			}
+		})
+	}
+
+	// Já uma data dentro de fevereiro, antes do início do ciclo, pertence ao
+	// período de janeiro
+	if mes := cicloDia5.mesReferenciaDe(time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)); formatMonthYear(mes) != "2025-01" {
+		t.Errorf("03/02/2025 no ciclo do dia 5 ficou em %s, esperava 2025-01", formatMonthYear(mes))
+	}
+}
+
+func TestPeriodoServiceNaViradaDoMes(t *testing.T) {
+	// 31/01 às 23h30 em São Paulo, o fuso padrão
+	relogioFixo := relogio.NewFixoRelogio(time.Date(2025, 2, 1, 2, 30, 0, 0, time.UTC))
+	periodoService := novoPeriodoServiceSemBanco(t, relogioFixo)
+	janeiro := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if mes := periodoService.mesAtual(1); !mes.Equal(janeiro) {
+		t.Fatalf("mês atual %s, esperava 2025-01", formatMonthYear(mes))
+	}
+	if dia := periodoService.agora(1).Day(); dia != 31 {
+		t.Errorf("hoje é dia %d, esperava 31", dia)
+	}
+	if periodoService.anteriorAoMesAtual(1, janeiro) {
+		t.Error("janeiro ainda é o mês atual")
+	}
+
+	relogioFixo.Avancar(time.Hour)
+
+	if mes := periodoService.mesAtual(1); formatMonthYear(mes) != "2025-02" {
+		t.Fatalf("depois da virada: mês atual %s, esperava 2025-02", formatMonthYear(mes))
+	}
+	if dia := periodoService.agora(1).Day(); dia != 1 {
+		t.Errorf("depois da virada: hoje é dia %d, esperava 1", dia)
+	}
+	if !periodoService.anteriorAoMesAtual(1, janeiro) {
+		t.Error("depois da virada, janeiro deveria ser anterior ao mês atual")
+	}
+}
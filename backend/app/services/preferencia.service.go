@@ -0,0 +1,195 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const moedaPadrao = "BRL"
+
+var simbolosMoeda = map[string]string{
+	"BRL": "R$",
+	"USD": "US$",
+	"EUR": "€",
+}
+
+// novaPreferenciaPadrao tem os valores usados no cadastro e para quem ainda
+// não salvou preferências.
+func novaPreferenciaPadrao(userID uint) *types.Preferencia {
+	return &types.Preferencia{
+		UserID:           userID,
+		Idioma:           i18n.IdiomaPadrao,
+		Moeda:            moedaPadrao,
+		FusoHorario:      fusoHorarioPadrao,
+		DiaInicioMes:     1,
+		NotificacoesPush: true,
+	}
+}
+
+// normalizarIdioma aceita apenas os idiomas do catálogo de mensagens.
+func normalizarIdioma(idioma string) (string, error) {
+	normalizado, ok := i18n.NormalizarIdioma(idioma)
+	if !ok {
+		return "", i18n.NovoErro(i18n.IdiomaInvalido)
+	}
+	return normalizado, nil
+}
+
+func normalizarMoeda(moeda string) (string, error) {
+	moeda = strings.ToUpper(strings.TrimSpace(moeda))
+	if _, ok := simbolosMoeda[moeda]; !ok {
+		return "", i18n.NovoErro(i18n.MoedaInvalida)
+	}
+	return moeda, nil
+}
+
+// formatarMoeda escreve o valor com o símbolo da moeda e os separadores do
+// idioma do usuário: R$ 1.234,56 em pt-BR e R$ 1,234.56 em en-US.
+func formatarMoeda(valor float64, preferencia *types.Preferencia) string {
+	milhar, decimal := ".", ","
+	if preferencia.Idioma == i18n.InglesEUA {
+		milhar, decimal = ",", "."
+	}
+
+	simbolo, ok := simbolosMoeda[preferencia.Moeda]
+	if !ok {
+		simbolo = simbolosMoeda[moedaPadrao]
+	}
+
+	sinal := ""
+	total := centavos(valor)
+	if total < 0 {
+		sinal = "-"
+		total = -total
+	}
+
+	inteiro := strconv.FormatInt(total/100, 10)
+	var agrupado strings.Builder
+	for i, digito := range inteiro {
+		if i > 0 && (len(inteiro)-i)%3 == 0 {
+			agrupado.WriteString(milhar)
+		}
+		agrupado.WriteRune(digito)
+	}
+
+	return fmt.Sprintf("%s%s %s%s%02d", sinal, simbolo, agrupado.String(), decimal, total%100)
+}
+
+type PreferenciaService struct {
+	preferenciaDAL *dal.PreferenciaDAL
+}
+
+func NewPreferenciaService(preferenciaDAL *dal.PreferenciaDAL) *PreferenciaService {
+	return &PreferenciaService{preferenciaDAL: preferenciaDAL}
+}
+
+// preferencias nunca falha: sem registro, ou se ele não puder ser lido,
+// valem as preferências padrão.
+func (s *PreferenciaService) preferencias(userID uint) *types.Preferencia {
+	preferencia, err := s.preferenciaDAL.GetPreferencia(userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Falha ao carregar as preferências do usuário %d: %v", userID, err)
+		}
+		return novaPreferenciaPadrao(userID)
+	}
+	return preferencia
+}
+
+// IdiomaDoUsuario é o idioma das respostas do usuário quando a requisição
+// não informa um Accept-Language suportado.
+func (s *PreferenciaService) IdiomaDoUsuario(userID uint) string {
+	return s.preferencias(userID).Idioma
+}
+
+// mensagemPush retorna a mensagem de saída da notificação, ou nil se o
+// usuário desativou o push. A notificação continua na caixa do app.
+func (s *PreferenciaService) mensagemPush(notificacao *types.Notificacao, agora time.Time) *types.MensagemOutbox {
+	if !s.preferencias(notificacao.UserID).NotificacoesPush {
+		return nil
+	}
+	return novaMensagemPush(notificacao, agora)
+}
+
+func toPreferenciaResponse(preferencia *types.Preferencia) *types.PreferenciaResponse {
+	return &types.PreferenciaResponse{
+		Idioma:           preferencia.Idioma,
+		Moeda:            preferencia.Moeda,
+		FusoHorario:      preferencia.FusoHorario,
+		DiaInicioMes:     preferencia.DiaInicioMes,
+		NotificacoesPush: preferencia.NotificacoesPush,
+		CategoriaPadrao:  preferencia.CategoriaPadrao,
+	}
+}
+
+func (s *PreferenciaService) getPreferencia(userID uint) (*types.Preferencia, error) {
+	preferencia, err := s.preferenciaDAL.GetPreferencia(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return novaPreferenciaPadrao(userID), nil
+		}
+		return nil, err
+	}
+	return preferencia, nil
+}
+
+func (s *PreferenciaService) GetPreferencias(userID uint) (*types.PreferenciaResponse, error) {
+	preferencia, err := s.getPreferencia(userID)
+	if err != nil {
+		return nil, err
+	}
+	return toPreferenciaResponse(preferencia), nil
+}
+
+// AtualizarPreferencias altera apenas os campos informados.
+func (s *PreferenciaService) AtualizarPreferencias(userID uint, req *types.PreferenciaRequest) (*types.PreferenciaResponse, error) {
+	preferencia, err := s.getPreferencia(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Idioma != nil {
+		if preferencia.Idioma, err = normalizarIdioma(*req.Idioma); err != nil {
+			return nil, err
+		}
+	}
+	if req.Moeda != nil {
+		if preferencia.Moeda, err = normalizarMoeda(*req.Moeda); err != nil {
+			return nil, err
+		}
+	}
+	if req.FusoHorario != nil {
+		nome := strings.TrimSpace(*req.FusoHorario)
+		if _, err := carregarFusoHorario(nome); err != nil {
+			return nil, err
+		}
+		preferencia.FusoHorario = nome
+	}
+	if req.DiaInicioMes != nil {
+		if *req.DiaInicioMes < 1 || *req.DiaInicioMes > maxDiaInicioPeriodo {
+			return nil, i18n.NovoErro(i18n.DiaInicioMesInvalido)
+		}
+		preferencia.DiaInicioMes = *req.DiaInicioMes
+	}
+	if req.NotificacoesPush != nil {
+		preferencia.NotificacoesPush = *req.NotificacoesPush
+	}
+	if req.CategoriaPadrao != nil {
+		preferencia.CategoriaPadrao = strings.TrimSpace(*req.CategoriaPadrao)
+	}
+
+	if err := s.preferenciaDAL.SavePreferencia(preferencia); err != nil {
+		return nil, err
+	}
+
+	return toPreferenciaResponse(preferencia), nil
+}
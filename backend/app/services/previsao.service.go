@@ -0,0 +1,284 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+)
+
+const (
+	anosHistoricoPrevisao     = 3
+	mesesVariacaoPrevisao     = 6
+	mesesRecorrencia          = 4
+	minMesesRecorrencia       = 3
+	maxOcorrenciasRecorrente  = 1.5
+	maxVariacaoRecorrente     = 0.25
+	incertezaMinimaPrevisao   = 0.15
+	incertezaSemHistorico     = 0.35
+	diasMinimosAlertaPrevisao = 5
+)
+
+type PrevisaoService struct {
+	despesaDAL    *dal.DespesaDAL
+	relatorioDAL  *dal.RelatorioDAL
+	limiteService *LimiteService
+}
+
+func NewPrevisaoService(despesaDAL *dal.DespesaDAL, relatorioDAL *dal.RelatorioDAL, limiteService *LimiteService) *PrevisaoService {
+	return &PrevisaoService{
+		despesaDAL:    despesaDAL,
+		relatorioDAL:  relatorioDAL,
+		limiteService: limiteService,
+	}
+}
+
+func media(valores []float64) float64 {
+	if len(valores) == 0 {
+		return 0
+	}
+	soma := 0.0
+	for _, valor := range valores {
+		soma += valor
+	}
+	return soma / float64(len(valores))
+}
+
+func mediana(valores []float64) float64 {
+	if len(valores) == 0 {
+		return 0
+	}
+	ordenados := append([]float64(nil), valores...)
+	sort.Float64s(ordenados)
+	meio := len(ordenados) / 2
+	if len(ordenados)%2 == 0 {
+		return (ordenados[meio-1] + ordenados[meio]) / 2
+	}
+	return ordenados[meio]
+}
+
+// coeficienteVariacao retorna o desvio padrão relativo à média, ou -1 quando
+// não há dados suficientes.
+func coeficienteVariacao(valores []float64) float64 {
+	m := media(valores)
+	if len(valores) < 2 || m <= 0 {
+		return -1
+	}
+	soma := 0.0
+	for _, valor := range valores {
+		soma += (valor - m) * (valor - m)
+	}
+	return math.Sqrt(soma/float64(len(valores))) / m
+}
+
+type despesaRecorrente struct {
+	descricao   string
+	valores     []float64
+	quantidades []int64
+	valorPago   float64
+}
+
+// detectarRecorrentes considera recorrente a despesa que aparece, com valor
+// parecido e no máximo uma ou duas vezes por mês, em pelo menos 3 dos 4 meses
+// anteriores ao mês previsto.
+func detectarRecorrentes(totais []types.TotalDescricaoMes, mesReferencia time.Time) []types.DespesaRecorrenteResponse {
+	porDescricao := make(map[string]*despesaRecorrente)
+	var chaves []string
+
+	for _, total := range totais {
+		chave := normalizarTexto(total.Descricao)
+		item, ok := porDescricao[chave]
+		if !ok {
+			item = &despesaRecorrente{descricao: total.Descricao}
+			porDescricao[chave] = item
+			chaves = append(chaves, chave)
+		}
+
+		if total.MesReferencia.Before(mesReferencia) {
+			item.valores = append(item.valores, total.Total)
+			item.quantidades = append(item.quantidades, total.Quantidade)
+		} else {
+			item.valorPago += total.Total
+		}
+	}
+	sort.Strings(chaves)
+
+	recorrentes := []types.DespesaRecorrenteResponse{}
+	for _, chave := range chaves {
+		item := porDescricao[chave]
+		if len(item.valores) < minMesesRecorrencia {
+			continue
+		}
+
+		ocorrencias := 0.0
+		for _, quantidade := range item.quantidades {
+			ocorrencias += float64(quantidade)
+		}
+		if ocorrencias/float64(len(item.quantidades)) > maxOcorrenciasRecorrente {
+			continue
+		}
+		if coeficienteVariacao(item.valores) > maxVariacaoRecorrente {
+			continue
+		}
+
+		// Depois de paga, vale o valor efetivamente lançado no mês
+		valor := mediana(item.valores)
+		if item.valorPago > 0 {
+			valor = item.valorPago
+		}
+
+		recorrentes = append(recorrentes, types.DespesaRecorrenteResponse{
+			Descricao:     item.descricao,
+			ValorEsperado: arredondar(valor),
+			Paga:          item.valorPago > 0,
+		})
+	}
+
+	return recorrentes
+}
+
+func (s *PrevisaoService) GetPrevisaoDoMes(userID uint, monthYear string) (*types.PrevisaoMesResponse, error) {
+	mesReferencia, err := parseMonthYear(monthYear)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.prever(userID, mesReferencia, s.limiteService.periodoService.agora(userID))
+}
+
+// prever combina três fontes: o ritmo de gasto variável do mês, as despesas
+// recorrentes que ainda não apareceram e a média histórica (o mesmo mês em
+// anos anteriores ou, na falta dele, os últimos meses). Quanto mais o mês
+// avança, mais peso o ritmo atual recebe.
+func (s *PrevisaoService) prever(userID uint, mesReferencia time.Time, agora time.Time) (*types.PrevisaoMesResponse, error) {
+	periodo := s.limiteService.periodoService.resolvedor(userID).periodoDoMes(mesReferencia)
+	diasNoMes, diasDecorridos := diasDoPeriodo(periodo, agora)
+
+	gastoAtual, err := s.despesaDAL.GetTotalByUserAndMonth(userID, mesReferencia, "")
+	if err != nil {
+		return nil, err
+	}
+
+	limites, err := s.limiteService.GetLimitesDoMes(userID, formatMonthYear(mesReferencia))
+	if err != nil {
+		return nil, err
+	}
+
+	previsao := &types.PrevisaoMesResponse{
+		MesReferencia:  formatMonthYear(mesReferencia),
+		GastoAtual:     arredondar(gastoAtual),
+		DiasDecorridos: diasDecorridos,
+		DiasNoMes:      diasNoMes,
+		Recorrentes:    []types.DespesaRecorrenteResponse{},
+	}
+	if limites.Geral != nil {
+		limite := limites.Geral.ValorEfetivo
+		previsao.Limite = &limite
+	}
+
+	if periodo.Fim.Before(inicioDoDia(agora)) {
+		// Meses encerrados não têm o que prever
+		if diasDecorridos > 0 {
+			previsao.RitmoDiario = arredondar(gastoAtual / float64(diasDecorridos))
+		}
+		previsao.Previsao = previsao.GastoAtual
+		previsao.PrevisaoMinima = previsao.GastoAtual
+		previsao.PrevisaoMaxima = previsao.GastoAtual
+		compararPrevisaoComLimite(previsao)
+		return previsao, nil
+	}
+
+	historico, err := s.relatorioDAL.GetTotaisPorMes(userID, mesReferencia.AddDate(-anosHistoricoPrevisao, 0, 0), mesReferencia.AddDate(0, -1, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	descricoes, err := s.relatorioDAL.GetTotaisPorDescricao(userID, mesReferencia.AddDate(0, -mesesRecorrencia, 0), mesReferencia)
+	if err != nil {
+		return nil, err
+	}
+
+	previsao.Recorrentes = detectarRecorrentes(descricoes, mesReferencia)
+	totalRecorrentes := 0.0
+	for _, recorrente := range previsao.Recorrentes {
+		totalRecorrentes += recorrente.ValorEsperado
+		if recorrente.Paga {
+			previsao.RecorrentesPagas += recorrente.ValorEsperado
+		} else {
+			previsao.RecorrentesPendentes += recorrente.ValorEsperado
+		}
+	}
+	previsao.RecorrentesPagas = arredondar(previsao.RecorrentesPagas)
+	previsao.RecorrentesPendentes = arredondar(previsao.RecorrentesPendentes)
+
+	var mesmoMes, recentes []float64
+	inicioRecentes := mesReferencia.AddDate(0, -mesesVariacaoPrevisao, 0)
+	for _, total := range historico {
+		if total.MesReferencia.Month() == mesReferencia.Month() {
+			mesmoMes = append(mesmoMes, total.Total)
+		}
+		if !total.MesReferencia.Before(inicioRecentes) {
+			recentes = append(recentes, total.Total)
+		}
+	}
+
+	base := mesmoMes
+	if len(base) == 0 {
+		base = recentes
+	}
+	possuiHistorico := len(base) > 0
+	if possuiHistorico {
+		mediaHistorica := arredondar(media(base))
+		previsao.MediaHistorica = &mediaHistorica
+		previsao.MesesHistorico = len(base)
+	}
+
+	variavelAtual := math.Max(0, gastoAtual-previsao.RecorrentesPagas)
+	variavelHistorica := math.Max(0, media(base)-totalRecorrentes)
+	peso := float64(diasDecorridos) / float64(diasNoMes)
+
+	variavelPrevista := variavelAtual
+	projecaoRitmo := 0.0
+	if diasDecorridos > 0 {
+		previsao.RitmoDiario = arredondar(variavelAtual / float64(diasDecorridos))
+		projecaoRitmo = variavelAtual / float64(diasDecorridos) * float64(diasNoMes)
+	}
+	switch {
+	case possuiHistorico && diasDecorridos > 0:
+		variavelPrevista = peso*projecaoRitmo + (1-peso)*variavelHistorica
+	case possuiHistorico:
+		variavelPrevista = variavelHistorica
+	case diasDecorridos > 0:
+		variavelPrevista = projecaoRitmo
+	}
+	variavelPrevista = math.Max(variavelPrevista, variavelAtual)
+
+	total := previsao.RecorrentesPagas + previsao.RecorrentesPendentes + variavelPrevista
+	// Despesas lançadas que não são recorrentes nem variáveis (ex.: acima do
+	// valor esperado) não podem ser perdidas
+	total = math.Max(total, gastoAtual+previsao.RecorrentesPendentes)
+
+	incerteza := math.Max(coeficienteVariacao(recentes), incertezaMinimaPrevisao)
+	if !possuiHistorico {
+		incerteza += incertezaSemHistorico * (1 - peso)
+	}
+	margem := (variavelPrevista - variavelAtual) * incerteza
+
+	previsao.Previsao = arredondar(total)
+	previsao.PrevisaoMinima = arredondar(math.Max(gastoAtual+previsao.RecorrentesPendentes, total-margem))
+	previsao.PrevisaoMaxima = arredondar(total + margem)
+	compararPrevisaoComLimite(previsao)
+
+	return previsao, nil
+}
+
+func compararPrevisaoComLimite(previsao *types.PrevisaoMesResponse) {
+	if previsao.Limite == nil || previsao.Previsao <= *previsao.Limite {
+		return
+	}
+	excesso := arredondar(previsao.Previsao - *previsao.Limite)
+	previsao.ExcedeLimite = true
+	previsao.ExcessoPrevisto = &excesso
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+)
+
+type ReceitaService struct {
+	receitaDAL *dal.ReceitaDAL
+}
+
+func NewReceitaService(receitaDAL *dal.ReceitaDAL) *ReceitaService {
+	return &ReceitaService{receitaDAL: receitaDAL}
+}
+
+func toReceitaSimpleResponse(receita *types.Receita) *types.ReceitaSimpleResponse {
+	response := &types.ReceitaSimpleResponse{
+		ID:            receita.ID,
+		Descricao:     receita.Descricao,
+		Valor:         receita.Valor,
+		MesReferencia: formatMonthYear(receita.MesReferencia),
+		Conta:         receita.Conta,
+	}
+	if receita.Data != nil {
+		response.Data = receita.Data.Format("2006-01-02")
+	}
+	return response
+}
+
+func (s *ReceitaService) GetReceitasByMonth(userID uint, monthYear string) ([]types.ReceitaSimpleResponse, error) {
+	mesReferencia, err := parseMonthYear(monthYear)
+	if err != nil {
+		return nil, err
+	}
+
+	receitas, err := s.receitaDAL.GetReceitasByUserAndMonth(userID, mesReferencia)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.ReceitaSimpleResponse
+	for _, receita := range receitas {
+		response = append(response, *toReceitaSimpleResponse(&receita))
+	}
+
+	return response, nil
+}
+
+func (s *ReceitaService) GetReceitasByUser(userID uint) ([]types.ReceitaSimpleResponse, error) {
+	receitas, err := s.receitaDAL.GetReceitasByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.ReceitaSimpleResponse
+	for _, receita := range receitas {
+		response = append(response, *toReceitaSimpleResponse(&receita))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,434 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+type RegraService struct {
+	regraDAL           *dal.RegraDAL
+	despesaDAL         *dal.DespesaDAL
+	fechamentoService  *FechamentoService
+	preferenciaService *PreferenciaService
+}
+
+func NewRegraService(regraDAL *dal.RegraDAL, despesaDAL *dal.DespesaDAL, fechamentoService *FechamentoService, preferenciaService *PreferenciaService) *RegraService {
+	return &RegraService{regraDAL: regraDAL, despesaDAL: despesaDAL, fechamentoService: fechamentoService, preferenciaService: preferenciaService}
+}
+
+type regraCompilada struct {
+	regra  types.RegraCategorizacao
+	contem string
+	regex  *regexp.Regexp
+}
+
+func juntarTags(tags []string) string {
+	vistas := make(map[string]bool)
+	var resultado []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, ",", " ")))
+		if tag == "" || vistas[tag] {
+			continue
+		}
+		vistas[tag] = true
+		resultado = append(resultado, tag)
+	}
+	return strings.Join(resultado, ",")
+}
+
+func separarTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	return strings.Split(tags, ",")
+}
+
+func compilarRegra(regra types.RegraCategorizacao) (*regraCompilada, error) {
+	compilada := &regraCompilada{
+		regra:  regra,
+		contem: normalizarTexto(regra.DescricaoContem),
+	}
+
+	if regra.DescricaoRegex != "" {
+		regex, err := regexp.Compile("(?i)" + regra.DescricaoRegex)
+		if err != nil {
+			return nil, i18n.NovoErro(i18n.RegraRegexInvalida)
+		}
+		compilada.regex = regex
+	}
+
+	return compilada, nil
+}
+
+func (r *regraCompilada) corresponde(despesa *types.Despesa) bool {
+	if r.contem != "" && !strings.Contains(normalizarTexto(despesa.Descricao), r.contem) {
+		return false
+	}
+	if r.regex != nil && !r.regex.MatchString(despesa.Descricao) {
+		return false
+	}
+	if r.regra.ValorMinimo != nil && despesa.Valor < *r.regra.ValorMinimo {
+		return false
+	}
+	if r.regra.ValorMaximo != nil && despesa.Valor > *r.regra.ValorMaximo {
+		return false
+	}
+	if r.regra.Conta != "" && !strings.EqualFold(strings.TrimSpace(despesa.Conta), r.regra.Conta) {
+		return false
+	}
+	return true
+}
+
+func (r *regraCompilada) aplicar(despesa *types.Despesa, sobrescreverCategoria bool) bool {
+	alterada := false
+
+	if r.regra.Categoria != "" && (despesa.Categoria == "" || sobrescreverCategoria) && despesa.Categoria != r.regra.Categoria {
+		despesa.Categoria = r.regra.Categoria
+		alterada = true
+	}
+
+	if r.regra.Tags != "" {
+		tags := juntarTags(append(separarTags(despesa.Tags), separarTags(r.regra.Tags)...))
+		if tags != despesa.Tags {
+			despesa.Tags = tags
+			alterada = true
+		}
+	}
+
+	if r.regra.DescricaoNormalizada != "" && despesa.Descricao != r.regra.DescricaoNormalizada {
+		despesa.Descricao = r.regra.DescricaoNormalizada
+		alterada = true
+	}
+
+	return alterada
+}
+
+// aplicarRegras usa a primeira regra que corresponder à despesa, seguindo a
+// ordem definida pelo usuário.
+func aplicarRegras(regras []*regraCompilada, despesa *types.Despesa, sobrescreverCategoria bool) bool {
+	for _, regra := range regras {
+		if regra.corresponde(despesa) {
+			return regra.aplicar(despesa, sobrescreverCategoria)
+		}
+	}
+	return false
+}
+
+func (s *RegraService) carregarRegras(userID uint) ([]*regraCompilada, error) {
+	regras, err := s.regraDAL.GetRegrasAtivasByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var compiladas []*regraCompilada
+	for _, regra := range regras {
+		compilada, err := compilarRegra(regra)
+		if err != nil {
+			continue
+		}
+		compiladas = append(compiladas, compilada)
+	}
+
+	return compiladas, nil
+}
+
+// AplicarRegras categoriza a despesa nova pelas regras do usuário. Se
+// nenhuma regra definir a categoria, vale a categoria padrão das preferências.
+func (s *RegraService) AplicarRegras(userID uint, despesa *types.Despesa) error {
+	regras, err := s.carregarRegras(userID)
+	if err != nil {
+		return err
+	}
+
+	aplicarRegras(regras, despesa, false)
+	if despesa.Categoria == "" {
+		despesa.Categoria = s.preferenciaService.preferencias(userID).CategoriaPadrao
+	}
+	return nil
+}
+
+// AplicarRegrasEmLote faz o mesmo que AplicarRegras para as despesas de uma
+// importação.
+func (s *RegraService) AplicarRegrasEmLote(userID uint, despesas []types.Despesa) error {
+	regras, err := s.carregarRegras(userID)
+	if err != nil {
+		return err
+	}
+
+	categoriaPadrao := s.preferenciaService.preferencias(userID).CategoriaPadrao
+	for i := range despesas {
+		aplicarRegras(regras, &despesas[i], false)
+		if despesas[i].Categoria == "" {
+			despesas[i].Categoria = categoriaPadrao
+		}
+	}
+	return nil
+}
+
+func validarRegra(req *types.RegraRequest) error {
+	if strings.TrimSpace(req.Nome) == "" {
+		return i18n.NovoErro(i18n.NomeObrigatorio)
+	}
+
+	if strings.TrimSpace(req.DescricaoContem) == "" && strings.TrimSpace(req.DescricaoRegex) == "" &&
+		req.ValorMinimo == nil && req.ValorMaximo == nil && strings.TrimSpace(req.Conta) == "" {
+		return i18n.NovoErro(i18n.RegraSemCondicao)
+	}
+
+	if strings.TrimSpace(req.Categoria) == "" && len(req.Tags) == 0 && strings.TrimSpace(req.DescricaoNormalizada) == "" {
+		return i18n.NovoErro(i18n.RegraSemAcao)
+	}
+
+	if req.ValorMinimo != nil && req.ValorMaximo != nil && *req.ValorMinimo > *req.ValorMaximo {
+		return i18n.NovoErro(i18n.RegraValoresInvertidos)
+	}
+
+	return nil
+}
+
+func preencherRegra(regra *types.RegraCategorizacao, req *types.RegraRequest) {
+	regra.Nome = strings.TrimSpace(req.Nome)
+	regra.DescricaoContem = strings.TrimSpace(req.DescricaoContem)
+	regra.DescricaoRegex = strings.TrimSpace(req.DescricaoRegex)
+	regra.ValorMinimo = req.ValorMinimo
+	regra.ValorMaximo = req.ValorMaximo
+	regra.Conta = strings.TrimSpace(req.Conta)
+	regra.Categoria = strings.TrimSpace(req.Categoria)
+	regra.Tags = juntarTags(req.Tags)
+	regra.DescricaoNormalizada = strings.TrimSpace(req.DescricaoNormalizada)
+	if req.Ativa != nil {
+		regra.Ativa = *req.Ativa
+	}
+}
+
+func toRegraSimpleResponse(regra *types.RegraCategorizacao) *types.RegraSimpleResponse {
+	return &types.RegraSimpleResponse{
+		ID:                   regra.ID,
+		Nome:                 regra.Nome,
+		Ordem:                regra.Ordem,
+		Ativa:                regra.Ativa,
+		DescricaoContem:      regra.DescricaoContem,
+		DescricaoRegex:       regra.DescricaoRegex,
+		ValorMinimo:          regra.ValorMinimo,
+		ValorMaximo:          regra.ValorMaximo,
+		Conta:                regra.Conta,
+		Categoria:            regra.Categoria,
+		Tags:                 separarTags(regra.Tags),
+		DescricaoNormalizada: regra.DescricaoNormalizada,
+	}
+}
+
+func (s *RegraService) CreateRegra(userID uint, req *types.RegraRequest) (*types.RegraSimpleResponse, error) {
+	if err := validarRegra(req); err != nil {
+		return nil, err
+	}
+
+	regra := &types.RegraCategorizacao{UserID: userID, Ativa: true}
+	preencherRegra(regra, req)
+
+	if _, err := compilarRegra(*regra); err != nil {
+		return nil, err
+	}
+
+	ordem, err := s.regraDAL.GetProximaOrdem(userID)
+	if err != nil {
+		return nil, err
+	}
+	regra.Ordem = ordem
+
+	if err := s.regraDAL.CreateRegra(regra); err != nil {
+		return nil, err
+	}
+
+	return toRegraSimpleResponse(regra), nil
+}
+
+func (s *RegraService) GetRegrasByUser(userID uint) ([]types.RegraSimpleResponse, error) {
+	regras, err := s.regraDAL.GetRegrasByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.RegraSimpleResponse
+	for _, regra := range regras {
+		response = append(response, *toRegraSimpleResponse(&regra))
+	}
+
+	return response, nil
+}
+
+func (s *RegraService) getRegra(userID uint, regraID uint) (*types.RegraCategorizacao, error) {
+	regra, err := s.regraDAL.GetRegraByID(regraID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, i18n.NovoErro(i18n.RegraNaoEncontrada)
+		}
+		return nil, err
+	}
+	return regra, nil
+}
+
+func (s *RegraService) UpdateRegra(userID uint, regraID uint, req *types.RegraRequest) (*types.RegraSimpleResponse, error) {
+	if err := validarRegra(req); err != nil {
+		return nil, err
+	}
+
+	regra, err := s.getRegra(userID, regraID)
+	if err != nil {
+		return nil, err
+	}
+
+	preencherRegra(regra, req)
+
+	if _, err := compilarRegra(*regra); err != nil {
+		return nil, err
+	}
+
+	if err := s.regraDAL.UpdateRegra(regra); err != nil {
+		return nil, err
+	}
+
+	return toRegraSimpleResponse(regra), nil
+}
+
+func (s *RegraService) DeleteRegra(userID uint, regraID uint) error {
+	if _, err := s.getRegra(userID, regraID); err != nil {
+		return err
+	}
+
+	return s.regraDAL.DeleteRegra(regraID, userID)
+}
+
+func (s *RegraService) OrdenarRegras(userID uint, req *types.OrdenarRegrasRequest) ([]types.RegraSimpleResponse, error) {
+	regras, err := s.regraDAL.GetRegrasByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.IDs) != len(regras) {
+		return nil, i18n.NovoErro(i18n.RegrasOrdemIncompleta)
+	}
+
+	existentes := make(map[uint]bool)
+	for _, regra := range regras {
+		existentes[regra.ID] = true
+	}
+	for _, id := range req.IDs {
+		if !existentes[id] {
+			return nil, i18n.NovoErro(i18n.RegraNaoEncontrada)
+		}
+		delete(existentes, id)
+	}
+
+	if err := s.regraDAL.UpdateOrdem(userID, req.IDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetRegrasByUser(userID)
+}
+
+func (s *RegraService) testar(userID uint, regra *types.RegraCategorizacao) (*types.TesteRegraResponse, error) {
+	compilada, err := compilarRegra(*regra)
+	if err != nil {
+		return nil, err
+	}
+
+	despesas, err := s.despesaDAL.GetDespesasByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.TesteRegraResponse{Despesas: []types.DespesaSimpleResponse{}}
+	for i := range despesas {
+		if compilada.corresponde(&despesas[i]) {
+			response.Despesas = append(response.Despesas, *toDespesaSimpleResponse(&despesas[i]))
+		}
+	}
+	response.Total = len(response.Despesas)
+
+	return response, nil
+}
+
+func (s *RegraService) TestarRegra(userID uint, req *types.RegraRequest) (*types.TesteRegraResponse, error) {
+	if err := validarRegra(req); err != nil {
+		return nil, err
+	}
+
+	regra := &types.RegraCategorizacao{UserID: userID}
+	preencherRegra(regra, req)
+
+	return s.testar(userID, regra)
+}
+
+func (s *RegraService) TestarRegraExistente(userID uint, regraID uint) (*types.TesteRegraResponse, error) {
+	regra, err := s.getRegra(userID, regraID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.testar(userID, regra)
+}
+
+func (s *RegraService) AplicarRetroativamente(userID uint, req *types.AplicarRegrasRequest) (*types.AplicacaoRegrasResponse, error) {
+	var regras []*regraCompilada
+	if req.RegraID != 0 {
+		regra, err := s.getRegra(userID, req.RegraID)
+		if err != nil {
+			return nil, err
+		}
+		compilada, err := compilarRegra(*regra)
+		if err != nil {
+			return nil, err
+		}
+		regras = append(regras, compilada)
+	} else {
+		var err error
+		if regras, err = s.carregarRegras(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	despesas, err := s.despesaDAL.GetDespesasByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.AplicacaoRegrasResponse{Analisadas: len(despesas)}
+	var alteradas []*types.Despesa
+	for i := range despesas {
+		despesa := &despesas[i]
+		if !aplicarRegras(regras, despesa, req.SobrescreverCategoria) {
+			continue
+		}
+		fechado, err := s.fechamentoService.mesFechado(userID, despesa.MesReferencia)
+		if err != nil {
+			return nil, err
+		}
+		if fechado {
+			response.Bloqueadas++
+			continue
+		}
+		alteradas = append(alteradas, despesa)
+	}
+
+	err = s.despesaDAL.Transaction(func(txDAL *dal.DespesaDAL) error {
+		for _, despesa := range alteradas {
+			if err := txDAL.UpdateDespesa(despesa); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.Atualizadas = len(alteradas)
+
+	return response, nil
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+)
+
+const (
+	maxMesesRelatorio      = 60
+	topCategoriasPadrao    = 5
+	maxCategoriasRelatorio = 50
+)
+
+type RelatorioService struct {
+	relatorioDAL  *dal.RelatorioDAL
+	limiteService *LimiteService
+}
+
+func NewRelatorioService(relatorioDAL *dal.RelatorioDAL, limiteService *LimiteService) *RelatorioService {
+	return &RelatorioService{
+		relatorioDAL:  relatorioDAL,
+		limiteService: limiteService,
+	}
+}
+
+func (s *RelatorioService) GetRelatorioAnual(userID uint, ano string, topCategorias int) (*types.RelatorioResponse, error) {
+	year, err := strconv.Atoi(ano)
+	if err != nil || year < 1900 || year > 9999 {
+		return nil, i18n.NovoErro(i18n.AnoInvalido)
+	}
+
+	inicio := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fim := time.Date(year, time.December, 1, 0, 0, 0, 0, time.UTC)
+	return s.gerarRelatorio(userID, inicio, fim, topCategorias)
+}
+
+func (s *RelatorioService) GetRelatorioPeriodo(userID uint, mesInicio string, mesFim string, topCategorias int) (*types.RelatorioResponse, error) {
+	if mesInicio == "" || mesFim == "" {
+		return nil, i18n.NovoErro(i18n.PeriodoMesesObrigatorio)
+	}
+
+	inicio, err := parseMonthYear(mesInicio)
+	if err != nil {
+		return nil, err
+	}
+
+	fim, err := parseMonthYear(mesFim)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.gerarRelatorio(userID, inicio, fim, topCategorias)
+}
+
+func (s *RelatorioService) gerarRelatorio(userID uint, inicio time.Time, fim time.Time, topCategorias int) (*types.RelatorioResponse, error) {
+	if fim.Before(inicio) {
+		return nil, i18n.NovoErro(i18n.PeriodoMesesInvertido)
+	}
+
+	quantidadeMeses := (fim.Year()-inicio.Year())*12 + int(fim.Month()-inicio.Month()) + 1
+	if quantidadeMeses > maxMesesRelatorio {
+		return nil, i18n.NovoErro(i18n.PeriodoRelatorioLongo)
+	}
+
+	if topCategorias <= 0 {
+		topCategorias = topCategoriasPadrao
+	}
+	if topCategorias > maxCategoriasRelatorio {
+		topCategorias = maxCategoriasRelatorio
+	}
+
+	totais, err := s.relatorioDAL.GetTotaisPorMes(userID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+
+	limites, err := s.relatorioDAL.GetLimitesGeraisBetweenMonths(userID, inicio, fim)
+	if err != nil {
+		return nil, err
+	}
+
+	categorias, err := s.relatorioDAL.GetTopCategorias(userID, inicio, fim, topCategorias)
+	if err != nil {
+		return nil, err
+	}
+
+	totaisPorMes := make(map[string]types.TotalMes)
+	for _, total := range totais {
+		totaisPorMes[formatMonthYear(total.MesReferencia)] = total
+	}
+
+	// O limite do relatório é o valor efetivo, já considerando o rollover
+	calc, err := s.limiteService.novaCalculadoraTransporte(userID)
+	if err != nil {
+		return nil, err
+	}
+	limitesPorMes := make(map[string]float64)
+	for i := range limites {
+		limite, err := calc.response(&limites[i])
+		if err != nil {
+			return nil, err
+		}
+		limitesPorMes[limite.MesReferencia] = limite.ValorEfetivo
+	}
+
+	mesAtual := inicioDoMes(s.limiteService.periodoService.agora(userID))
+
+	// Os totais cobrem o período inteiro, mas as médias só somam os meses até
+	// o atual; despesas já lançadas em meses futuros não podem inflá-las
+	categoriasAteMesAtual := make(map[string]float64)
+	if fim.After(mesAtual) && !inicio.After(mesAtual) {
+		totaisCategoria, err := s.relatorioDAL.GetTotaisPorCategoriaMes(userID, inicio, mesAtual)
+		if err != nil {
+			return nil, err
+		}
+		for _, total := range totaisCategoria {
+			categoriasAteMesAtual[strings.ToLower(total.Categoria)] += total.Total
+		}
+	}
+
+	relatorio := &types.RelatorioResponse{
+		Inicio:        formatMonthYear(inicio),
+		Fim:           formatMonthYear(fim),
+		Meses:         make([]types.RelatorioMesResponse, 0, quantidadeMeses),
+		TopCategorias: []types.RelatorioCategoriaResponse{},
+	}
+
+	somaLimites := 0.0
+	mesesComLimite := 0
+	totalAteMesAtual := 0.0
+	var anterior *types.RelatorioMesResponse
+
+	for mes := inicio; !mes.After(fim); mes = mes.AddDate(0, 1, 0) {
+		chave := formatMonthYear(mes)
+		total := totaisPorMes[chave]
+
+		item := types.RelatorioMesResponse{
+			MesReferencia: chave,
+			Total:         arredondar(total.Total),
+			Quantidade:    total.Quantidade,
+		}
+
+		if limite, ok := limitesPorMes[chave]; ok {
+			diferenca := arredondar(limite - total.Total)
+			dentro := diferenca >= 0
+			item.Limite = &limite
+			item.Diferenca = &diferenca
+			item.DentroDoLimite = &dentro
+
+			somaLimites += limite
+			mesesComLimite++
+			if dentro {
+				relatorio.MesesDentroDoLimite++
+			} else {
+				relatorio.MesesAcimaDoLimite++
+			}
+		}
+
+		if anterior != nil {
+			variacao := arredondar(item.Total - anterior.Total)
+			item.VariacaoValor = &variacao
+			if anterior.Total > 0 {
+				percentual := math.Round(variacao/anterior.Total*10000) / 100
+				item.VariacaoPercentual = &percentual
+			}
+		}
+
+		relatorio.TotalGasto += total.Total
+		relatorio.QuantidadeDespesas += total.Quantidade
+		// Meses futuros não entram nas médias
+		if !mes.After(mesAtual) {
+			relatorio.MesesConsiderados++
+			totalAteMesAtual += total.Total
+		}
+
+		relatorio.Meses = append(relatorio.Meses, item)
+		anterior = &relatorio.Meses[len(relatorio.Meses)-1]
+	}
+
+	relatorio.TotalGasto = arredondar(relatorio.TotalGasto)
+	if relatorio.MesesConsiderados > 0 {
+		relatorio.MediaMensal = arredondar(totalAteMesAtual / float64(relatorio.MesesConsiderados))
+	}
+	if mesesComLimite > 0 {
+		media := arredondar(somaLimites / float64(mesesComLimite))
+		relatorio.MediaLimite = &media
+	}
+
+	for _, categoria := range categorias {
+		item := types.RelatorioCategoriaResponse{
+			Categoria:  categoria.Categoria,
+			Total:      arredondar(categoria.Total),
+			Quantidade: categoria.Quantidade,
+		}
+		if relatorio.TotalGasto > 0 {
+			item.Participacao = arredondar(categoria.Total / relatorio.TotalGasto * 100)
+		}
+		if relatorio.MesesConsiderados > 0 {
+			totalConsiderado := categoria.Total
+			if fim.After(mesAtual) {
+				totalConsiderado = categoriasAteMesAtual[strings.ToLower(categoria.Categoria)]
+			}
+			item.MediaMensal = arredondar(totalConsiderado / float64(relatorio.MesesConsiderados))
+		}
+		relatorio.TopCategorias = append(relatorio.TopCategorias, item)
+	}
+
+	return relatorio, nil
+}
@@ -0,0 +1,242 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+)
+
+type ResumoService struct {
+	limiteService   *LimiteService
+	despesaDAL      *dal.DespesaDAL
+	periodoService  *PeriodoService
+	envelopeService *EnvelopeService
+}
+
+func NewResumoService(limiteService *LimiteService, despesaDAL *dal.DespesaDAL, periodoService *PeriodoService, envelopeService *EnvelopeService) *ResumoService {
+	return &ResumoService{
+		limiteService:   limiteService,
+		despesaDAL:      despesaDAL,
+		periodoService:  periodoService,
+		envelopeService: envelopeService,
+	}
+}
+
+func (s *ResumoService) GetResumoDoMes(userID uint, monthYear string) (*types.ResumoMesResponse, error) {
+	mesReferencia, err := parseMonthYear(monthYear)
+	if err != nil {
+		return nil, err
+	}
+
+	totais, err := s.despesaDAL.GetTotaisPorCategoria(userID, mesReferencia)
+	if err != nil {
+		return nil, err
+	}
+
+	limites, err := s.limiteService.GetLimitesDoMes(userID, monthYear)
+	if err != nil {
+		return nil, err
+	}
+
+	limitesPorCategoria := make(map[string]types.LimiteConsumoResponse)
+	for _, limite := range limites.Categorias {
+		limitesPorCategoria[strings.ToLower(limite.Categoria)] = limite
+	}
+
+	// Categorias que diferem apenas em maiúsculas/minúsculas são somadas juntas
+	indices := make(map[string]int)
+	resumo := &types.ResumoMesResponse{
+		MesReferencia: formatMonthYear(mesReferencia),
+		Categorias:    []types.ResumoCategoriaResponse{},
+	}
+	for _, total := range totais {
+		resumo.TotalGasto += total.Total
+		resumo.QuantidadeDespesas += total.Quantidade
+
+		chave := strings.ToLower(total.Categoria)
+		if i, ok := indices[chave]; ok {
+			resumo.Categorias[i].Total += total.Total
+			resumo.Categorias[i].Quantidade += total.Quantidade
+			continue
+		}
+		indices[chave] = len(resumo.Categorias)
+		resumo.Categorias = append(resumo.Categorias, types.ResumoCategoriaResponse{
+			Categoria:  total.Categoria,
+			Total:      total.Total,
+			Quantidade: total.Quantidade,
+		})
+	}
+
+	// Limites por categoria sem nenhum gasto também aparecem no detalhamento
+	for _, limite := range limites.Categorias {
+		chave := strings.ToLower(limite.Categoria)
+		if _, ok := indices[chave]; !ok {
+			indices[chave] = len(resumo.Categorias)
+			resumo.Categorias = append(resumo.Categorias, types.ResumoCategoriaResponse{Categoria: limite.Categoria})
+		}
+	}
+
+	for i := range resumo.Categorias {
+		categoria := &resumo.Categorias[i]
+		categoria.Total = arredondar(categoria.Total)
+		if resumo.TotalGasto > 0 {
+			categoria.Participacao = arredondar(categoria.Total / resumo.TotalGasto * 100)
+		}
+		if limite, ok := limitesPorCategoria[strings.ToLower(categoria.Categoria)]; ok {
+			valor, restante, percentual := limite.ValorEfetivo, limite.Restante, limite.PercentualUsado
+			categoria.Limite = &valor
+			categoria.Restante = &restante
+			categoria.PercentualUsado = &percentual
+		}
+	}
+
+	sort.SliceStable(resumo.Categorias, func(i, j int) bool {
+		return resumo.Categorias[i].Total > resumo.Categorias[j].Total
+	})
+
+	resumo.TotalGasto = arredondar(resumo.TotalGasto)
+
+	if limites.Geral != nil {
+		valor, restante, percentual := limites.Geral.ValorEfetivo, limites.Geral.Restante, limites.Geral.PercentualUsado
+		resumo.Limite = &valor
+		resumo.Restante = &restante
+		resumo.PercentualUsado = &percentual
+	}
+
+	periodo := s.periodoService.resolvedor(userID).periodoDoMes(mesReferencia)
+	diasNoMes, diasDecorridos := diasDoPeriodo(periodo, s.periodoService.agora(userID))
+	resumo.DiasConsiderados = diasDecorridos
+	resumo.ProjecaoFimMes = resumo.TotalGasto
+	if diasDecorridos > 0 {
+		resumo.MediaDiaria = arredondar(resumo.TotalGasto / float64(diasDecorridos))
+		if diasDecorridos < diasNoMes {
+			resumo.ProjecaoFimMes = arredondar(resumo.TotalGasto / float64(diasDecorridos) * float64(diasNoMes))
+		}
+	}
+
+	envelopes, err := s.envelopeService.resumoDoMes(userID, mesReferencia)
+	if err != nil {
+		return nil, err
+	}
+	resumo.Envelopes = envelopes
+	for _, envelope := range envelopes {
+		resumo.ContribuicaoEnvelopes += envelope.Contribuicao
+	}
+	resumo.ContribuicaoEnvelopes = arredondar(resumo.ContribuicaoEnvelopes)
+
+	return resumo, nil
+}
+
+const maxPeriodosHistorico = 24
+
+// resumirPeriodo calcula o consumo de um período. No ciclo mensal, gasto e
+// limite vêm do mês de referência; nos ciclos semanal e quinzenal, o gasto é
+// somado pela data da despesa e o limite é o valor padrão configurado.
+func (s *ResumoService) resumirPeriodo(userID uint, resolvedor *resolvedorPeriodo, periodo Periodo, agora time.Time) (*types.ResumoPeriodoResponse, error) {
+	resumo := &types.ResumoPeriodoResponse{
+		Tipo:   resolvedor.tipo,
+		Inicio: periodo.Inicio.Format("2006-01-02"),
+		Fim:    periodo.Fim.Format("2006-01-02"),
+	}
+
+	var limite *float64
+	if resolvedor.tipo == tipoPeriodoMensal {
+		resumo.MesReferencia = formatMonthYear(periodo.MesReferencia)
+
+		totais, err := s.despesaDAL.GetTotaisPorCategoria(userID, periodo.MesReferencia)
+		if err != nil {
+			return nil, err
+		}
+		for _, total := range totais {
+			resumo.Gasto += total.Total
+			resumo.QuantidadeDespesas += total.Quantidade
+		}
+
+		limites, err := s.limiteService.GetLimitesDoMes(userID, resumo.MesReferencia)
+		if err != nil {
+			return nil, err
+		}
+		if limites.Geral != nil {
+			valor := limites.Geral.ValorEfetivo
+			limite = &valor
+		}
+	} else {
+		gasto, quantidade, err := s.despesaDAL.GetTotalEntreDatas(userID, periodo.Inicio, periodo.Fim)
+		if err != nil {
+			return nil, err
+		}
+		resumo.Gasto = gasto
+		resumo.QuantidadeDespesas = quantidade
+
+		if resolvedor.valorPadrao > 0 {
+			valor := resolvedor.valorPadrao
+			limite = &valor
+		}
+	}
+
+	resumo.Gasto = arredondar(resumo.Gasto)
+	if limite != nil {
+		restante := arredondar(*limite - resumo.Gasto)
+		resumo.Limite = limite
+		resumo.Restante = &restante
+		if *limite > 0 {
+			percentual := arredondar(resumo.Gasto / *limite * 100)
+			resumo.PercentualUsado = &percentual
+		}
+	}
+
+	resumo.DiasNoPeriodo, resumo.DiasDecorridos = diasDoPeriodo(periodo, agora)
+	resumo.Projecao = resumo.Gasto
+	if resumo.DiasDecorridos > 0 {
+		resumo.MediaDiaria = arredondar(resumo.Gasto / float64(resumo.DiasDecorridos))
+		if resumo.DiasDecorridos < resumo.DiasNoPeriodo {
+			resumo.Projecao = arredondar(resumo.Gasto / float64(resumo.DiasDecorridos) * float64(resumo.DiasNoPeriodo))
+		}
+	}
+
+	return resumo, nil
+}
+
+// GetResumoPeriodo resume o período de orçamento que contém a data informada
+// (YYYY-MM-DD). Sem data, usa o período corrente.
+func (s *ResumoService) GetResumoPeriodo(userID uint, data string) (*types.ResumoPeriodoResponse, error) {
+	agora := s.periodoService.agora(userID)
+	referencia := agora
+	if data != "" {
+		parsed, err := time.Parse("2006-01-02", data)
+		if err != nil {
+			return nil, i18n.NovoErro(i18n.DataInvalida)
+		}
+		referencia = parsed
+	}
+
+	resolvedor := s.periodoService.resolvedor(userID)
+	return s.resumirPeriodo(userID, resolvedor, resolvedor.periodoDe(referencia), agora)
+}
+
+// GetHistoricoPeriodos resume os últimos períodos, do corrente para trás.
+func (s *ResumoService) GetHistoricoPeriodos(userID uint, quantidade int) ([]types.ResumoPeriodoResponse, error) {
+	if quantidade < 1 || quantidade > maxPeriodosHistorico {
+		return nil, i18n.NovoErro(i18n.QuantidadePeriodosInvalida)
+	}
+
+	agora := s.periodoService.agora(userID)
+	resolvedor := s.periodoService.resolvedor(userID)
+	periodo := resolvedor.periodoDe(agora)
+
+	historico := make([]types.ResumoPeriodoResponse, 0, quantidade)
+	for i := 0; i < quantidade; i++ {
+		resumo, err := s.resumirPeriodo(userID, resolvedor, periodo, agora)
+		if err != nil {
+			return nil, err
+		}
+		historico = append(historico, *resumo)
+		periodo = resolvedor.periodoDe(periodo.Inicio.AddDate(0, 0, -1))
+	}
+
+	return historico, nil
+}
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+)
+
+const (
+	mesesSugestaoPadrao    = 6
+	minMesesSugestao       = 3
+	maxMesesSugestao       = 24
+	mesesSazonalidade      = 24
+	fatorSazonalMinimo     = 0.7
+	fatorSazonalMaximo     = 1.5
+	arredondamentoSugestao = 10
+)
+
+type SugestaoService struct {
+	relatorioDAL  *dal.RelatorioDAL
+	limiteDAL     *dal.LimiteDAL
+	limiteService *LimiteService
+}
+
+func NewSugestaoService(relatorioDAL *dal.RelatorioDAL, limiteDAL *dal.LimiteDAL, limiteService *LimiteService) *SugestaoService {
+	return &SugestaoService{
+		relatorioDAL:  relatorioDAL,
+		limiteDAL:     limiteDAL,
+		limiteService: limiteService,
+	}
+}
+
+// mediaAparada descarta 10% dos valores em cada extremo (ao menos um de cada
+// lado a partir de 5 valores), para que um mês atípico não distorça a média.
+func mediaAparada(valores []float64) float64 {
+	ordenados := append([]float64(nil), valores...)
+	sort.Float64s(ordenados)
+
+	corte := len(ordenados) / 10
+	if corte == 0 && len(ordenados) >= 5 {
+		corte = 1
+	}
+	return media(ordenados[corte : len(ordenados)-corte])
+}
+
+// fatorSazonal compara o mesmo mês de anos anteriores com a média de todos
+// os meses disponíveis. Sem histórico suficiente, não há ajuste.
+func fatorSazonal(serie map[string]float64, mesReferencia time.Time, inicio time.Time) float64 {
+	var mesmoMes, todos []float64
+	for mes := inicio; mes.Before(mesReferencia); mes = mes.AddDate(0, 1, 0) {
+		valor := serie[formatMonthYear(mes)]
+		todos = append(todos, valor)
+		if mes.Month() == mesReferencia.Month() {
+			mesmoMes = append(mesmoMes, valor)
+		}
+	}
+
+	mediaGeral := media(todos)
+	if len(mesmoMes) == 0 || len(todos) < 12 || mediaGeral <= 0 {
+		return 1
+	}
+	return math.Max(fatorSazonalMinimo, math.Min(fatorSazonalMaximo, media(mesmoMes)/mediaGeral))
+}
+
+func arredondarSugestao(valor float64) float64 {
+	return math.Ceil(valor/arredondamentoSugestao) * arredondamentoSugestao
+}
+
+func calcularSugestao(serie map[string]float64, inicioAnalise time.Time, inicioSazonalidade time.Time, mesReferencia time.Time) *types.SugestaoLimite {
+	var valores []float64
+	for mes := inicioAnalise; mes.Before(mesReferencia); mes = mes.AddDate(0, 1, 0) {
+		valores = append(valores, serie[formatMonthYear(mes)])
+	}
+
+	sugestao := &types.SugestaoLimite{
+		Mediana:         arredondar(mediana(valores)),
+		MediaAparada:    arredondar(mediaAparada(valores)),
+		FatorSazonal:    math.Round(fatorSazonal(serie, mesReferencia, inicioSazonalidade)*100) / 100,
+		MesesAnalisados: len(valores),
+	}
+	sugestao.ValorSugerido = arredondarSugestao(math.Max(sugestao.Mediana, sugestao.MediaAparada) * sugestao.FatorSazonal)
+	return sugestao
+}
+
+func (s *SugestaoService) GetSugestoes(userID uint, monthYear string, meses int) (*types.SugestaoLimitesResponse, error) {
+	if meses == 0 {
+		meses = mesesSugestaoPadrao
+	}
+	if meses < minMesesSugestao || meses > maxMesesSugestao {
+		return nil, i18n.NovoErro(i18n.SugestaoMesesInvalidos)
+	}
+
+	mesReferencia, err := parseMonthYear(monthYear)
+	if err != nil {
+		return nil, err
+	}
+
+	inicioSazonalidade := mesReferencia.AddDate(0, -mesesSazonalidade, 0)
+	ultimoMes := mesReferencia.AddDate(0, -1, 0)
+
+	totaisMes, err := s.relatorioDAL.GetTotaisPorMes(userID, inicioSazonalidade, ultimoMes)
+	if err != nil {
+		return nil, err
+	}
+	totaisCategoria, err := s.relatorioDAL.GetTotaisPorCategoriaMes(userID, inicioSazonalidade, ultimoMes)
+	if err != nil {
+		return nil, err
+	}
+
+	// A análise começa no primeiro mês com despesas dentro da janela, para
+	// que usuários novos não recebam sugestões puxadas para baixo por meses
+	// em que ainda não usavam o app
+	inicioAnalise := mesReferencia.AddDate(0, -meses, 0)
+	primeiroMes := time.Time{}
+	geral := make(map[string]float64)
+	for _, total := range totaisMes {
+		geral[formatMonthYear(total.MesReferencia)] = total.Total
+		if !total.MesReferencia.Before(inicioAnalise) && (primeiroMes.IsZero() || total.MesReferencia.Before(primeiroMes)) {
+			primeiroMes = total.MesReferencia
+		}
+	}
+	if primeiroMes.IsZero() {
+		return nil, i18n.NovoErro(i18n.SugestaoSemDespesas)
+	}
+	inicioAnalise = time.Date(primeiroMes.Year(), primeiroMes.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	nomes := make(map[string]string)
+	porCategoria := make(map[string]map[string]float64)
+	for _, total := range totaisCategoria {
+		chave := strings.ToLower(total.Categoria)
+		if chave == "" {
+			continue
+		}
+		if _, ok := porCategoria[chave]; !ok {
+			porCategoria[chave] = make(map[string]float64)
+			nomes[chave] = total.Categoria
+		}
+		porCategoria[chave][formatMonthYear(total.MesReferencia)] += total.Total
+	}
+
+	limitesAtuais, err := s.limiteDAL.GetLimitesByUserAndMonth(userID, mesReferencia)
+	if err != nil {
+		return nil, err
+	}
+	atuais := make(map[string]float64)
+	for _, limite := range limitesAtuais {
+		atuais[strings.ToLower(limite.Categoria)] = limite.Valor
+	}
+
+	response := &types.SugestaoLimitesResponse{
+		MesReferencia: formatMonthYear(mesReferencia),
+		Inicio:        formatMonthYear(inicioAnalise),
+		Fim:           formatMonthYear(ultimoMes),
+		Geral:         calcularSugestao(geral, inicioAnalise, inicioSazonalidade, mesReferencia),
+		Categorias:    []types.SugestaoLimite{},
+	}
+	if valor, ok := atuais[""]; ok {
+		response.Geral.LimiteAtual = &valor
+	}
+
+	for chave, serie := range porCategoria {
+		sugestao := calcularSugestao(serie, inicioAnalise, inicioSazonalidade, mesReferencia)
+		// Categorias sem gasto no período analisado não recebem sugestão
+		if sugestao.ValorSugerido <= 0 {
+			continue
+		}
+		sugestao.Categoria = nomes[chave]
+		if valor, ok := atuais[chave]; ok {
+			sugestao.LimiteAtual = &valor
+		}
+		response.Categorias = append(response.Categorias, *sugestao)
+	}
+
+	sort.Slice(response.Categorias, func(i, j int) bool {
+		return response.Categorias[i].ValorSugerido > response.Categorias[j].ValorSugerido
+	})
+
+	return response, nil
+}
+
+// AplicarSugestoes cria os limites sugeridos para o mês. Limites que já
+// existem só são alterados quando o pedido pede para sobrescrever. Tudo é
+// gravado em uma única transação: se um limite falhar, nenhum é aplicado.
+func (s *SugestaoService) AplicarSugestoes(userID uint, monthYear string, req *types.AplicarSugestaoRequest) (*types.AplicarSugestaoResponse, error) {
+	sugestoes, err := s.GetSugestoes(userID, monthYear, req.Meses)
+	if err != nil {
+		return nil, err
+	}
+
+	mesReferencia, err := parseMonthYear(monthYear)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.limiteService.fechamentoService.verificarMesAberto(userID, mesReferencia, i18n.MesFechadoAplicarSugestoes); err != nil {
+		return nil, err
+	}
+
+	if err := s.limiteService.verificarPermissao(userID); err != nil {
+		return nil, err
+	}
+
+	selecionadas := []types.SugestaoLimite{}
+	if req.IncluirGeral == nil || *req.IncluirGeral {
+		selecionadas = append(selecionadas, *sugestoes.Geral)
+	}
+
+	filtro := make(map[string]bool)
+	for _, categoria := range req.Categorias {
+		filtro[strings.ToLower(strings.TrimSpace(categoria))] = true
+	}
+	for _, sugestao := range sugestoes.Categorias {
+		if len(filtro) == 0 || filtro[strings.ToLower(sugestao.Categoria)] {
+			selecionadas = append(selecionadas, sugestao)
+		}
+	}
+
+	var criados, atualizados []*types.Limite
+	ignorados := []string{}
+
+	err = s.limiteDAL.Transaction(func(txDAL *dal.LimiteDAL) error {
+		limitesAtuais, err := txDAL.GetLimitesByUserAndMonth(userID, mesReferencia)
+		if err != nil {
+			return err
+		}
+		existentes := make(map[string]*types.Limite)
+		for i := range limitesAtuais {
+			existentes[strings.ToLower(limitesAtuais[i].Categoria)] = &limitesAtuais[i]
+		}
+
+		for _, sugestao := range selecionadas {
+			if sugestao.ValorSugerido <= 0 {
+				continue
+			}
+
+			nome := sugestao.Categoria
+			if nome == "" {
+				nome = "geral"
+			}
+
+			if limite, ok := existentes[strings.ToLower(sugestao.Categoria)]; ok {
+				if !req.Sobrescrever {
+					ignorados = append(ignorados, nome)
+					continue
+				}
+				limite.Valor = sugestao.ValorSugerido
+				if err := txDAL.UpdateLimite(limite); err != nil {
+					return err
+				}
+				atualizados = append(atualizados, limite)
+				continue
+			}
+
+			limite := &types.Limite{
+				Valor:         sugestao.ValorSugerido,
+				MesReferencia: mesReferencia,
+				Categoria:     sugestao.Categoria,
+				UserID:        userID,
+			}
+			if err := txDAL.CreateLimite(limite); err != nil {
+				return err
+			}
+			criados = append(criados, limite)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.AplicarSugestaoResponse{
+		Criados:     []types.LimiteSimpleResponse{},
+		Atualizados: []types.LimiteSimpleResponse{},
+		Ignorados:   ignorados,
+	}
+
+	// O transporte só é calculado depois da gravação, com todos os limites
+	// do mês já aplicados
+	calc, err := s.limiteService.novaCalculadoraTransporte(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, limite := range criados {
+		item, err := calc.response(limite)
+		if err != nil {
+			return nil, err
+		}
+		response.Criados = append(response.Criados, *item)
+	}
+	for _, limite := range atualizados {
+		item, err := calc.response(limite)
+		if err != nil {
+			return nil, err
+		}
+		response.Atualizados = append(response.Atualizados, *item)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,510 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
+	"github.com/Vicente/Password-Mobile-App/backend/app/types"
+	"gorm.io/gorm"
+)
+
+const (
+	eventoDespesaCriada   = "despesa.created"
+	eventoDespesaAlterada = "despesa.updated"
+	eventoDespesaExcluida = "despesa.deleted"
+	eventoLimiteExcedido  = "limite.exceeded"
+	eventoWebhookTeste    = "webhook.test"
+	eventoWebhookTodos    = "*"
+	maxWebhooksPorUsuario = 10
+	maxEntregasListadas   = 100
+	maxRespostaWebhook    = 1024
+	timeoutEntregaWebhook = 10 * time.Second
+	cabecalhoAssinatura   = "X-Webhook-Signature"
+	cabecalhoTimestamp    = "X-Webhook-Timestamp"
+	cabecalhoEvento       = "X-Webhook-Event"
+	cabecalhoEntrega      = "X-Webhook-Delivery"
+)
+
+var eventosWebhook = map[string]bool{
+	eventoDespesaCriada:   true,
+	eventoDespesaAlterada: true,
+	eventoDespesaExcluida: true,
+	eventoLimiteExcedido:  true,
+}
+
+type eventoWebhook struct {
+	ID       string      `json:"id"`
+	Evento   string      `json:"evento"`
+	CriadoEm string      `json:"criadoEm"`
+	Dados    interface{} `json:"dados"`
+}
+
+var errDestinoWebhookProibido = errors.New("destino resolve para um endereço local ou de rede privada")
+
+type WebhookService struct {
+	webhookDAL *dal.WebhookDAL
+	client     *http.Client
+	relogio    relogio.Relogio
+}
+
+func NewWebhookService(webhookDAL *dal.WebhookDAL, relogio relogio.Relogio) *WebhookService {
+	return &WebhookService{
+		webhookDAL: webhookDAL,
+		client:     novoClienteWebhook(),
+		relogio:    relogio,
+	}
+}
+
+// novoClienteWebhook confere o IP no momento da conexão, depois da resolução
+// do DNS, para que um domínio apontando para a rede interna também seja
+// recusado. Redirecionamentos não são seguidos e proxies do ambiente são
+// ignorados, senão a verificação valeria para o proxy e não para o destino.
+func novoClienteWebhook() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeoutEntregaWebhook,
+		Control: func(_, endereco string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(endereco)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || enderecoProibido(ip) {
+				return errDestinoWebhookProibido
+			}
+			return nil
+		},
+	}
+
+	transporte := http.DefaultTransport.(*http.Transport).Clone()
+	transporte.Proxy = nil
+	transporte.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeoutEntregaWebhook,
+		Transport: transporte,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func enderecoProibido(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsMulticast() || ip.IsUnspecified()
+}
+
+func gerarTokenAleatorio(tamanho int) string {
+	bytes := make([]byte, tamanho)
+	if _, err := rand.Read(bytes); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(bytes)
+}
+
+// AssinarWebhook calcula a assinatura enviada no cabeçalho X-Webhook-Signature:
+// HMAC-SHA256 do timestamp, um ponto e o corpo da requisição.
+func AssinarWebhook(segredo string, timestamp string, corpo []byte) string {
+	mac := hmac.New(sha256.New, []byte(segredo))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(corpo)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// validarURLWebhook aceita apenas http(s) e recusa endereços locais ou de
+// rede privada já no cadastro. Domínios são conferidos a cada entrega, pelo
+// cliente de novoClienteWebhook.
+func validarURLWebhook(endereco string) (string, error) {
+	endereco = strings.TrimSpace(endereco)
+	destino, err := url.Parse(endereco)
+	if err != nil || (destino.Scheme != "http" && destino.Scheme != "https") || destino.Hostname() == "" {
+		return "", i18n.NovoErro(i18n.WebhookURLInvalida)
+	}
+
+	host := strings.ToLower(destino.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return "", i18n.NovoErro(i18n.WebhookURLLocal)
+	}
+	if ip := net.ParseIP(host); ip != nil && enderecoProibido(ip) {
+		return "", i18n.NovoErro(i18n.WebhookURLLocal)
+	}
+
+	return endereco, nil
+}
+
+func normalizarEventos(eventos []string) ([]string, error) {
+	if len(eventos) == 0 {
+		return nil, i18n.NovoErro(i18n.WebhookEventosObrigatorios)
+	}
+
+	vistos := make(map[string]bool)
+	resultado := []string{}
+	for _, evento := range eventos {
+		evento = strings.ToLower(strings.TrimSpace(evento))
+		if evento != eventoWebhookTodos && !eventosWebhook[evento] {
+			return nil, i18n.NovoErro(i18n.WebhookEventoInvalido, evento)
+		}
+		if !vistos[evento] {
+			vistos[evento] = true
+			resultado = append(resultado, evento)
+		}
+	}
+	return resultado, nil
+}
+
+func webhookAssina(webhook *types.Webhook, evento string) bool {
+	for _, assinado := range strings.Split(webhook.Eventos, ",") {
+		if assinado == eventoWebhookTodos || assinado == evento {
+			return true
+		}
+	}
+	return false
+}
+
+func toWebhookSimpleResponse(webhook *types.Webhook) *types.WebhookSimpleResponse {
+	return &types.WebhookSimpleResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Descricao: webhook.Descricao,
+		Eventos:   strings.Split(webhook.Eventos, ","),
+		Ativo:     webhook.Ativo,
+		CriadoEm:  webhook.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toEntregaWebhookResponse(entrega *types.EntregaWebhook) *types.EntregaWebhookResponse {
+	response := &types.EntregaWebhookResponse{
+		ID:         entrega.ID,
+		EventoID:   entrega.EventoID,
+		Evento:     entrega.Evento,
+		Status:     entrega.Status,
+		Tentativas: entrega.Tentativas,
+		StatusHTTP: entrega.StatusHTTP,
+		Resposta:   entrega.Resposta,
+		UltimoErro: entrega.UltimoErro,
+		CriadaEm:   entrega.CreatedAt.Format(time.RFC3339),
+	}
+	if entrega.Status == statusOutboxPendente {
+		response.ProximaTentativa = entrega.ProximaTentativa.Format(time.RFC3339)
+	}
+	if entrega.EntregueEm != nil {
+		response.EntregueEm = entrega.EntregueEm.Format(time.RFC3339)
+	}
+	return response
+}
+
+func (s *WebhookService) getWebhook(userID uint, webhookID uint) (*types.Webhook, error) {
+	webhook, err := s.webhookDAL.GetWebhookByID(webhookID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, i18n.NovoErro(i18n.WebhookNaoEncontrado)
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// CreateWebhook retorna o segredo de assinatura apenas nesta resposta.
+func (s *WebhookService) CreateWebhook(userID uint, req *types.WebhookRequest) (*types.WebhookSimpleResponse, error) {
+	endereco, err := validarURLWebhook(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	eventos, err := normalizarEventos(req.Eventos)
+	if err != nil {
+		return nil, err
+	}
+
+	existentes, err := s.webhookDAL.GetWebhooksByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existentes) >= maxWebhooksPorUsuario {
+		return nil, i18n.NovoErro(i18n.WebhookExcesso, maxWebhooksPorUsuario)
+	}
+
+	webhook := &types.Webhook{
+		UserID:    userID,
+		URL:       endereco,
+		Descricao: strings.TrimSpace(req.Descricao),
+		Segredo:   gerarTokenAleatorio(32),
+		Eventos:   strings.Join(eventos, ","),
+		Ativo:     req.Ativo == nil || *req.Ativo,
+	}
+
+	if err := s.webhookDAL.CreateWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	response := toWebhookSimpleResponse(webhook)
+	response.Segredo = webhook.Segredo
+	return response, nil
+}
+
+func (s *WebhookService) GetWebhooksByUser(userID uint) ([]types.WebhookSimpleResponse, error) {
+	webhooks, err := s.webhookDAL.GetWebhooksByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.WebhookSimpleResponse
+	for i := range webhooks {
+		response = append(response, *toWebhookSimpleResponse(&webhooks[i]))
+	}
+
+	return response, nil
+}
+
+func (s *WebhookService) UpdateWebhook(userID uint, webhookID uint, req *types.WebhookRequest) (*types.WebhookSimpleResponse, error) {
+	webhook, err := s.getWebhook(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	endereco, err := validarURLWebhook(req.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	eventos, err := normalizarEventos(req.Eventos)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.URL = endereco
+	webhook.Descricao = strings.TrimSpace(req.Descricao)
+	webhook.Eventos = strings.Join(eventos, ",")
+	if req.Ativo != nil {
+		webhook.Ativo = *req.Ativo
+	}
+	if req.RegenerarSegredo {
+		webhook.Segredo = gerarTokenAleatorio(32)
+	}
+
+	if err := s.webhookDAL.UpdateWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	response := toWebhookSimpleResponse(webhook)
+	if req.RegenerarSegredo {
+		response.Segredo = webhook.Segredo
+	}
+	return response, nil
+}
+
+func (s *WebhookService) DeleteWebhook(userID uint, webhookID uint) error {
+	if _, err := s.getWebhook(userID, webhookID); err != nil {
+		return err
+	}
+
+	return s.webhookDAL.DeleteWebhook(webhookID, userID)
+}
+
+func (s *WebhookService) GetEntregas(userID uint, webhookID uint) ([]types.EntregaWebhookResponse, error) {
+	if _, err := s.getWebhook(userID, webhookID); err != nil {
+		return nil, err
+	}
+
+	entregas, err := s.webhookDAL.GetEntregasByWebhook(webhookID, userID, maxEntregasListadas)
+	if err != nil {
+		return nil, err
+	}
+
+	var response []types.EntregaWebhookResponse
+	for i := range entregas {
+		response = append(response, *toEntregaWebhookResponse(&entregas[i]))
+	}
+
+	return response, nil
+}
+
+func novaEntregaWebhook(webhook *types.Webhook, eventoID string, evento string, dados interface{}, agora time.Time) (*types.EntregaWebhook, error) {
+	payload, err := json.Marshal(eventoWebhook{
+		ID:       eventoID,
+		Evento:   evento,
+		CriadoEm: agora.Format(time.RFC3339),
+		Dados:    dados,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.EntregaWebhook{
+		WebhookID:        webhook.ID,
+		EventoID:         eventoID,
+		UserID:           webhook.UserID,
+		Evento:           evento,
+		Payload:          string(payload),
+		Status:           statusOutboxPendente,
+		ProximaTentativa: agora,
+	}, nil
+}
+
+// Publicar enfileira o evento para todos os webhooks ativos do usuário que o
+// assinam. Quando eventoID é informado, o mesmo evento nunca é entregue duas
+// vezes ao mesmo webhook; caso contrário um identificador novo é gerado.
+func (s *WebhookService) Publicar(userID uint, evento string, eventoID string, dados interface{}) error {
+	webhooks, err := s.webhookDAL.GetWebhooksAtivosByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if eventoID == "" {
+		eventoID = gerarTokenAleatorio(16)
+	}
+
+	agora := s.relogio.Agora().UTC()
+	var entregas []types.EntregaWebhook
+	for i := range webhooks {
+		if !webhookAssina(&webhooks[i], evento) {
+			continue
+		}
+		entrega, err := novaEntregaWebhook(&webhooks[i], eventoID, evento, dados, agora)
+		if err != nil {
+			return err
+		}
+		entregas = append(entregas, *entrega)
+	}
+
+	return s.webhookDAL.CreateEntregas(entregas)
+}
+
+// publicarEvento é usado pelos outros serviços: falhas ao enfileirar não
+// interrompem a operação que gerou o evento.
+func (s *WebhookService) publicarEvento(userID uint, evento string, eventoID string, dados interface{}) {
+	if err := s.Publicar(userID, evento, eventoID, dados); err != nil {
+		log.Printf("Falha ao publicar o evento %s do usuário %d: %v", evento, userID, err)
+	}
+}
+
+func (s *WebhookService) EnviarTeste(userID uint, webhookID uint) (*types.EntregaWebhookResponse, error) {
+	webhook, err := s.getWebhook(userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	entrega, err := novaEntregaWebhook(webhook, gerarTokenAleatorio(16), eventoWebhookTeste, dadosEventoTeste(webhook), s.relogio.Agora().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	entregas := []types.EntregaWebhook{*entrega}
+	if err := s.webhookDAL.CreateEntregas(entregas); err != nil {
+		return nil, err
+	}
+
+	return toEntregaWebhookResponse(&entregas[0]), nil
+}
+
+func dadosEventoTeste(webhook *types.Webhook) map[string]interface{} {
+	return map[string]interface{}{
+		"webhookId": webhook.ID,
+		"mensagem":  "Evento de teste",
+	}
+}
+
+// ProcessarPendentes entrega um lote de eventos prontos para envio e retorna
+// quantos foram processados. Usa a mesma política de novas tentativas da
+// outbox de notificações.
+func (s *WebhookService) ProcessarPendentes(ctx context.Context, agora time.Time) (int, error) {
+	entregas, err := s.webhookDAL.ReservarPendentes(agora, loteOutbox, reservaOutbox, statusOutboxPendente)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range entregas {
+		entrega := &entregas[i]
+
+		webhook, err := s.webhookDAL.GetWebhookParaEntrega(entrega.WebhookID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Falha ao buscar o webhook %d: %v", entrega.WebhookID, err)
+			continue
+		}
+
+		switch {
+		case webhook == nil || webhook.DeletedAt.Valid || !webhook.Ativo:
+			entrega.Status = statusOutboxDescartada
+			entrega.UltimoErro = "webhook excluído ou desativado"
+		default:
+			s.entregar(ctx, webhook, entrega, agora)
+		}
+
+		if err := s.webhookDAL.UpdateEntrega(entrega); err != nil {
+			log.Printf("Falha ao atualizar a entrega %d do webhook: %v", entrega.ID, err)
+		}
+	}
+
+	return len(entregas), nil
+}
+
+func (s *WebhookService) entregar(ctx context.Context, webhook *types.Webhook, entrega *types.EntregaWebhook, agora time.Time) {
+	entrega.Tentativas++
+	entrega.StatusHTTP = 0
+	entrega.Resposta = ""
+
+	err := s.enviarRequisicao(ctx, webhook, entrega)
+	if err == nil {
+		entregueEm := agora
+		entrega.Status = statusOutboxEnviada
+		entrega.UltimoErro = ""
+		entrega.EntregueEm = &entregueEm
+		return
+	}
+
+	entrega.UltimoErro = err.Error()
+	if entrega.Tentativas >= maxTentativasOutbox {
+		entrega.Status = statusOutboxFalhou
+		return
+	}
+	entrega.Status = statusOutboxPendente
+	entrega.ProximaTentativa = agora.Add(esperaOutbox(entrega.Tentativas))
+}
+
+func (s *WebhookService) enviarRequisicao(ctx context.Context, webhook *types.Webhook, entrega *types.EntregaWebhook) error {
+	corpo := []byte(entrega.Payload)
+	timestamp := strconv.FormatInt(s.relogio.Agora().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(corpo))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "FinancialApp-Webhooks/1.0")
+	req.Header.Set(cabecalhoEvento, entrega.Evento)
+	req.Header.Set(cabecalhoEntrega, entrega.EventoID)
+	req.Header.Set(cabecalhoTimestamp, timestamp)
+	req.Header.Set(cabecalhoAssinatura, AssinarWebhook(webhook.Segredo, timestamp, corpo))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	resposta, _ := io.ReadAll(io.LimitReader(resp.Body, maxRespostaWebhook))
+	entrega.StatusHTTP = resp.StatusCode
+	entrega.Resposta = string(resposta)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("destino respondeu com status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package types
+
+import "gorm.io/gorm"
+
+// ConfiguracaoAlerta guarda os percentuais de alerta de uma categoria. A
+// configuração sem categoria vale para o limite geral e para as categorias
+// que não possuem configuração própria.
+type ConfiguracaoAlerta struct {
+	gorm.Model
+	UserID      uint   `json:"userId" gorm:"not null;uniqueIndex:idx_configuracao_alerta_categoria"`
+	Categoria   string `json:"categoria" gorm:"uniqueIndex:idx_configuracao_alerta_categoria"`
+	Percentuais string `json:"percentuais"`
+}
+
+type ConfiguracaoAlertaRequest struct {
+	Categoria   string `json:"categoria"`
+	Percentuais []int  `json:"percentuais"`
+}
+
+type ConfiguracaoAlertaResponse struct {
+	ID          uint   `json:"id,omitempty"`
+	Categoria   string `json:"categoria"`
+	Percentuais []int  `json:"percentuais"`
+	Padrao      bool   `json:"padrao,omitempty"`
+}
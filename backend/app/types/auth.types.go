@@ -60,6 +60,9 @@ type User struct {
 	Email          string `json:"email" binding:"required,email" gorm:"unique"`
 	Senha          string `json:"senha" binding:"required" gorm:"-"`
 	SenhaHash      string `json:"-"`
+
+	// Criada junto com o usuário no cadastro
+	Preferencia *Preferencia `json:"-" gorm:"foreignKey:UserID"`
 }
 
 type SignupRequest struct {
@@ -68,6 +71,8 @@ type SignupRequest struct {
 	Email            string `json:"email" binding:"required,email"`
 	Senha            string `json:"senha" binding:"required,min=6"`
 	ConfirmacaoSenha string `json:"confirmacaoSenha" binding:"required"`
+	Idioma           string `json:"idioma"`
+	FusoHorario      string `json:"fusoHorario"`
 }
 
 type LoginRequest struct {
@@ -2,31 +2,108 @@ package types
 
 import (
 	"time"
+
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
 	"gorm.io/gorm"
 )
 
 type Despesa struct {
 	gorm.Model
-	Descricao     string    `json:"descricao" binding:"required"`
-	Valor         float64   `json:"valor" binding:"required,gt=0"`
-	MesReferencia time.Time `json:"mesReferencia" binding:"required" gorm:"type:date"`
-	UserID        uint      `json:"userId" gorm:"not null"`
-	User          User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Descricao     string     `json:"descricao" binding:"required"`
+	Valor         float64    `json:"valor" binding:"required,gt=0"`
+	MesReferencia time.Time  `json:"mesReferencia" binding:"required" gorm:"type:date"`
+	Data          *time.Time `json:"data,omitempty" gorm:"type:date"`
+	Categoria     string     `json:"categoria,omitempty" gorm:"index"`
+	Tags          string     `json:"tags,omitempty"`
+	Conta         string     `json:"conta,omitempty" gorm:"uniqueIndex:idx_despesas_conta_fitid"`
+	FITID         string     `json:"fitid,omitempty" gorm:"index;uniqueIndex:idx_despesas_conta_fitid,where:fit_id <> '' AND deleted_at IS NULL"`
+	ImportacaoID  *uint      `json:"importacaoId,omitempty" gorm:"index"`
+	FamiliaID     *uint      `json:"familiaId,omitempty" gorm:"index"`
+	UserID        uint       `json:"userId" gorm:"not null;uniqueIndex:idx_despesas_conta_fitid,priority:1"`
+	User          User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
 
 type CreateDespesaRequest struct {
-	Descricao     string  `json:"descricao" binding:"required"`
-	Valor         float64 `json:"valor" binding:"required,gt=0"`
-	MesReferencia string  `json:"mesReferencia" binding:"required"`
+	Descricao         string   `json:"descricao" binding:"required"`
+	Valor             float64  `json:"valor" binding:"required,gt=0"`
+	MesReferencia     string   `json:"mesReferencia"`
+	Data              string   `json:"data"`
+	Categoria         string   `json:"categoria"`
+	Tags              []string `json:"tags"`
+	Conta             string   `json:"conta"`
+	IgnorarDuplicatas bool     `json:"ignorarDuplicatas"`
 }
 
+// Categoria e Tags só são alteradas quando enviadas; "" e [] as limpam.
 type UpdateDespesaRequest struct {
-	Descricao string  `json:"descricao" binding:"required"`
-	Valor     float64 `json:"valor" binding:"required,gt=0"`
+	Descricao string    `json:"descricao" binding:"required"`
+	Valor     float64   `json:"valor" binding:"required,gt=0"`
+	Categoria *string   `json:"categoria"`
+	Tags      *[]string `json:"tags"`
 }
 
 type DespesaSimpleResponse struct {
-	Descricao     string  `json:"descricao"`
-	Valor         float64 `json:"valor"`
-	MesReferencia string  `json:"mesReferencia"`
-} 
\ No newline at end of file
+	ID            uint     `json:"id"`
+	Descricao     string   `json:"descricao"`
+	Valor         float64  `json:"valor"`
+	MesReferencia string   `json:"mesReferencia"`
+	Data          string   `json:"data,omitempty"`
+	Categoria     string   `json:"categoria,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	Conta         string   `json:"conta,omitempty"`
+	UserID        uint     `json:"userId"`
+}
+
+// Na operação "atualizar", Categoria e Tags seguem a regra de UpdateDespesaRequest.
+type BatchDespesaOperacao struct {
+	Operacao          string    `json:"operacao"`
+	ID                uint      `json:"id"`
+	Descricao         string    `json:"descricao"`
+	Valor             float64   `json:"valor"`
+	MesReferencia     string    `json:"mesReferencia"`
+	Data              string    `json:"data"`
+	Categoria         *string   `json:"categoria"`
+	Tags              *[]string `json:"tags"`
+	Conta             string    `json:"conta"`
+	IgnorarDuplicatas bool      `json:"ignorarDuplicatas"`
+}
+
+type BatchDespesaRequest struct {
+	Modo      string                 `json:"modo"`
+	Operacoes []BatchDespesaOperacao `json:"operacoes"`
+}
+
+type BatchDespesaResultado struct {
+	Indice     int                     `json:"indice"`
+	Operacao   string                  `json:"operacao"`
+	ID         uint                    `json:"id,omitempty"`
+	Sucesso    bool                    `json:"sucesso"`
+	Erro       *i18n.ErroItem          `json:"erro,omitempty"`
+	Despesa    *DespesaSimpleResponse  `json:"despesa,omitempty"`
+	Duplicatas []DespesaSimpleResponse `json:"duplicatas,omitempty"`
+}
+
+type BatchDespesaResponse struct {
+	Modo       string                  `json:"modo"`
+	Sucesso    bool                    `json:"sucesso"`
+	Total      int                     `json:"total"`
+	Aplicadas  int                     `json:"aplicadas"`
+	Falhas     int                     `json:"falhas"`
+	Resultados []BatchDespesaResultado `json:"resultados"`
+}
+
+type GrupoDuplicatas struct {
+	Valor    float64                 `json:"valor"`
+	Despesas []DespesaSimpleResponse `json:"despesas"`
+}
+
+type MesclarDuplicatasRequest struct {
+	ManterID   uint   `json:"manterId"`
+	RemoverIDs []uint `json:"removerIds"`
+}
+
+type TotalCategoria struct {
+	Categoria  string  `json:"categoria"`
+	Total      float64 `json:"total"`
+	Quantidade int64   `json:"quantidade"`
+}
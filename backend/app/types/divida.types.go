@@ -0,0 +1,111 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Divida é um empréstimo ou financiamento (Papel "devedor") ou um valor que
+// alguém deve ao usuário (Papel "credor"). A TaxaJuros é mensal, em
+// percentual, e a primeira parcela vence um mês depois da DataInicio.
+type Divida struct {
+	gorm.Model
+	Descricao   string    `json:"descricao" gorm:"not null"`
+	Papel       string    `json:"papel" gorm:"not null"`
+	Contraparte string    `json:"contraparte"`
+	Principal   float64   `json:"principal" gorm:"not null"`
+	TaxaJuros   float64   `json:"taxaJuros"`
+	Parcelas    int       `json:"parcelas" gorm:"not null"`
+	Sistema     string    `json:"sistema" gorm:"not null"`
+	DataInicio  time.Time `json:"dataInicio" gorm:"type:date;not null"`
+	Categoria   string    `json:"categoria"`
+	UserID      uint      `json:"userId" gorm:"not null;index"`
+	User        User      `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// PagamentoDivida é um valor pago (ou recebido, quando o usuário é o credor).
+// Pagamentos geram uma despesa e recebimentos, uma receita.
+type PagamentoDivida struct {
+	gorm.Model
+	DividaID  uint      `json:"dividaId" gorm:"not null;index"`
+	UserID    uint      `json:"userId" gorm:"not null;index"`
+	Valor     float64   `json:"valor" gorm:"not null"`
+	Data      time.Time `json:"data" gorm:"type:date;not null"`
+	DespesaID *uint     `json:"despesaId"`
+	ReceitaID *uint     `json:"receitaId"`
+}
+
+type DividaRequest struct {
+	Descricao   string  `json:"descricao"`
+	Papel       string  `json:"papel"`
+	Contraparte string  `json:"contraparte"`
+	Principal   float64 `json:"principal"`
+	TaxaJuros   float64 `json:"taxaJuros"`
+	Parcelas    int     `json:"parcelas"`
+	Sistema     string  `json:"sistema"`
+	DataInicio  string  `json:"dataInicio"`
+	Categoria   string  `json:"categoria"`
+}
+
+type PagamentoDividaRequest struct {
+	Valor float64 `json:"valor"`
+	Data  string  `json:"data"`
+}
+
+type ParcelaDividaResponse struct {
+	Numero       int     `json:"numero"`
+	Vencimento   string  `json:"vencimento"`
+	Valor        float64 `json:"valor"`
+	Juros        float64 `json:"juros"`
+	Amortizacao  float64 `json:"amortizacao"`
+	SaldoDevedor float64 `json:"saldoDevedor"`
+	Paga         bool    `json:"paga"`
+}
+
+type PagamentoDividaResponse struct {
+	ID        uint    `json:"id"`
+	Valor     float64 `json:"valor"`
+	Data      string  `json:"data"`
+	DespesaID *uint   `json:"despesaId,omitempty"`
+	ReceitaID *uint   `json:"receitaId,omitempty"`
+}
+
+type DividaResponse struct {
+	ID             uint                   `json:"id"`
+	Descricao      string                 `json:"descricao"`
+	Papel          string                 `json:"papel"`
+	Contraparte    string                 `json:"contraparte,omitempty"`
+	Principal      float64                `json:"principal"`
+	TaxaJuros      float64                `json:"taxaJuros"`
+	Parcelas       int                    `json:"parcelas"`
+	Sistema        string                 `json:"sistema"`
+	DataInicio     string                 `json:"dataInicio"`
+	Categoria      string                 `json:"categoria,omitempty"`
+	TotalPago      float64                `json:"totalPago"`
+	SaldoDevedor   float64                `json:"saldoDevedor"`
+	ParcelasPagas  int                    `json:"parcelasPagas"`
+	ProximaParcela *ParcelaDividaResponse `json:"proximaParcela"`
+	Status         string                 `json:"status"`
+}
+
+type DividaDetalheResponse struct {
+	DividaResponse
+	Pagamentos []PagamentoDividaResponse `json:"pagamentos"`
+}
+
+type SaldoDividaMesResponse struct {
+	MesReferencia string  `json:"mesReferencia"`
+	SaldoPrevisto float64 `json:"saldoPrevisto"`
+	SaldoReal     float64 `json:"saldoReal"`
+	Pago          float64 `json:"pago"`
+}
+
+type ProjecaoQuitacaoResponse struct {
+	SaldoAtual  float64 `json:"saldoAtual"`
+	ValorMensal float64 `json:"valorMensal"`
+	Meses       int     `json:"meses"`
+	MesQuitacao string  `json:"mesQuitacao"`
+	TotalJuros  float64 `json:"totalJuros"`
+	TotalAPagar float64 `json:"totalAPagar"`
+}
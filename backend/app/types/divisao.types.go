@@ -0,0 +1,148 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GrupoDivisao reúne pessoas que dividem gastos, como uma viagem ou uma
+// república. Saldos e acertos são sempre calculados dentro do grupo.
+type GrupoDivisao struct {
+	gorm.Model
+	Nome        string `json:"nome" gorm:"not null"`
+	CriadoPorID uint   `json:"criadoPorId" gorm:"not null"`
+}
+
+type MembroGrupoDivisao struct {
+	gorm.Model
+	GrupoID uint `json:"grupoId" gorm:"not null;uniqueIndex:idx_membro_grupo_divisao"`
+	UserID  uint `json:"userId" gorm:"not null;uniqueIndex:idx_membro_grupo_divisao"`
+	User    User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// Divisao é um gasto pago por um membro (PagadorID) e dividido entre os
+// participantes. A soma das partes é sempre igual ao Valor.
+type Divisao struct {
+	gorm.Model
+	GrupoID       uint                  `json:"grupoId" gorm:"not null;index"`
+	PagadorID     uint                  `json:"pagadorId" gorm:"not null"`
+	CriadaPorID   uint                  `json:"criadaPorId" gorm:"not null"`
+	Descricao     string                `json:"descricao" gorm:"not null"`
+	Valor         float64               `json:"valor" gorm:"not null"`
+	Tipo          string                `json:"tipo" gorm:"not null"`
+	Data          time.Time             `json:"data" gorm:"type:date;not null"`
+	Participantes []ParticipanteDivisao `json:"participantes" gorm:"foreignKey:DivisaoID"`
+}
+
+// ParticipanteDivisao é a parte de um membro em uma divisão. Percentual só é
+// preenchido nas divisões por percentual.
+type ParticipanteDivisao struct {
+	gorm.Model
+	DivisaoID  uint     `json:"divisaoId" gorm:"not null;index"`
+	UserID     uint     `json:"userId" gorm:"not null"`
+	Valor      float64  `json:"valor" gorm:"not null"`
+	Percentual *float64 `json:"percentual"`
+}
+
+// AcertoDivisao é um pagamento de DeID para ParaID fora do app (Pix,
+// dinheiro) que abate o saldo entre os dois no grupo.
+type AcertoDivisao struct {
+	gorm.Model
+	GrupoID     uint      `json:"grupoId" gorm:"not null;index"`
+	DeID        uint      `json:"deId" gorm:"not null"`
+	ParaID      uint      `json:"paraId" gorm:"not null"`
+	Valor       float64   `json:"valor" gorm:"not null"`
+	Data        time.Time `json:"data" gorm:"type:date;not null"`
+	Observacao  string    `json:"observacao"`
+	CriadoPorID uint      `json:"criadoPorId" gorm:"not null"`
+}
+
+type GrupoDivisaoRequest struct {
+	Nome   string   `json:"nome"`
+	Emails []string `json:"emails"`
+}
+
+type MembroGrupoDivisaoRequest struct {
+	Email string `json:"email"`
+}
+
+type ParticipanteDivisaoRequest struct {
+	UserID     uint    `json:"userId"`
+	Valor      float64 `json:"valor"`
+	Percentual float64 `json:"percentual"`
+}
+
+type DivisaoRequest struct {
+	Descricao     string                       `json:"descricao"`
+	Valor         float64                      `json:"valor"`
+	PagadorID     uint                         `json:"pagadorId"`
+	Tipo          string                       `json:"tipo"`
+	Data          string                       `json:"data"`
+	Participantes []ParticipanteDivisaoRequest `json:"participantes"`
+}
+
+type AcertoDivisaoRequest struct {
+	DeID       uint    `json:"deId"`
+	ParaID     uint    `json:"paraId"`
+	Valor      float64 `json:"valor"`
+	Data       string  `json:"data"`
+	Observacao string  `json:"observacao"`
+}
+
+type MembroGrupoDivisaoResponse struct {
+	UserID uint    `json:"userId"`
+	Nome   string  `json:"nome"`
+	Email  string  `json:"email"`
+	Saldo  float64 `json:"saldo"`
+}
+
+type GrupoDivisaoResponse struct {
+	ID       uint                         `json:"id"`
+	Nome     string                       `json:"nome"`
+	MeuSaldo float64                      `json:"meuSaldo"`
+	Membros  []MembroGrupoDivisaoResponse `json:"membros"`
+}
+
+type ParticipanteDivisaoResponse struct {
+	UserID     uint     `json:"userId"`
+	Nome       string   `json:"nome"`
+	Valor      float64  `json:"valor"`
+	Percentual *float64 `json:"percentual,omitempty"`
+}
+
+type DivisaoResponse struct {
+	ID            uint                          `json:"id"`
+	Descricao     string                        `json:"descricao"`
+	Valor         float64                       `json:"valor"`
+	Tipo          string                        `json:"tipo"`
+	Data          string                        `json:"data"`
+	PagadorID     uint                          `json:"pagadorId"`
+	Pagador       string                        `json:"pagador"`
+	Participantes []ParticipanteDivisaoResponse `json:"participantes"`
+}
+
+type AcertoDivisaoResponse struct {
+	ID         uint    `json:"id"`
+	DeID       uint    `json:"deId"`
+	De         string  `json:"de"`
+	ParaID     uint    `json:"paraId"`
+	Para       string  `json:"para"`
+	Valor      float64 `json:"valor"`
+	Data       string  `json:"data"`
+	Observacao string  `json:"observacao,omitempty"`
+}
+
+// TransferenciaResponse é uma transferência sugerida para zerar os saldos.
+type TransferenciaResponse struct {
+	DeID   uint    `json:"deId"`
+	De     string  `json:"de"`
+	ParaID uint    `json:"paraId"`
+	Para   string  `json:"para"`
+	Valor  float64 `json:"valor"`
+}
+
+type SaldosGrupoResponse struct {
+	Membros        []MembroGrupoDivisaoResponse `json:"membros"`
+	Transferencias []TransferenciaResponse      `json:"transferencias"`
+}
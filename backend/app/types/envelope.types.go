@@ -0,0 +1,90 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Envelope é um orçamento anual: uma reserva mensal para uma despesa que vence
+// uma vez por ano (IPVA, IPTU, material escolar). MesVencimento é o primeiro
+// dia do mês em que a despesa vence; nos envelopes recorrentes, o vencimento
+// se repete a cada ano.
+type Envelope struct {
+	gorm.Model
+	Nome          string    `json:"nome" gorm:"not null"`
+	Categoria     string    `json:"categoria"`
+	ValorAlvo     float64   `json:"valorAlvo" gorm:"not null"`
+	MesVencimento time.Time `json:"mesVencimento" gorm:"not null"`
+	Recorrente    bool      `json:"recorrente"`
+	UserID        uint      `json:"userId" gorm:"not null;index"`
+	User          User      `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// MovimentoEnvelope é um aporte no envelope ou um gasto pago com ele. Gastos
+// podem apontar para a despesa correspondente.
+type MovimentoEnvelope struct {
+	gorm.Model
+	EnvelopeID    uint      `json:"envelopeId" gorm:"not null;index"`
+	UserID        uint      `json:"userId" gorm:"not null;index"`
+	Tipo          string    `json:"tipo" gorm:"not null"`
+	Valor         float64   `json:"valor" gorm:"not null"`
+	MesReferencia time.Time `json:"mesReferencia" gorm:"not null"`
+	Descricao     string    `json:"descricao"`
+	DespesaID     *uint     `json:"despesaId"`
+}
+
+type EnvelopeRequest struct {
+	Nome          string  `json:"nome"`
+	Categoria     string  `json:"categoria"`
+	ValorAlvo     float64 `json:"valorAlvo"`
+	MesVencimento string  `json:"mesVencimento"`
+	Recorrente    bool    `json:"recorrente"`
+}
+
+type MovimentoEnvelopeRequest struct {
+	Valor         float64 `json:"valor"`
+	MesReferencia string  `json:"mesReferencia"`
+	Descricao     string  `json:"descricao"`
+	DespesaID     *uint   `json:"despesaId"`
+}
+
+type MovimentoEnvelopeResponse struct {
+	ID            uint      `json:"id"`
+	Tipo          string    `json:"tipo"`
+	Valor         float64   `json:"valor"`
+	MesReferencia string    `json:"mesReferencia"`
+	Descricao     string    `json:"descricao,omitempty"`
+	DespesaID     *uint     `json:"despesaId,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type EnvelopeResponse struct {
+	ID                 uint    `json:"id"`
+	Nome               string  `json:"nome"`
+	Categoria          string  `json:"categoria,omitempty"`
+	ValorAlvo          float64 `json:"valorAlvo"`
+	Recorrente         bool    `json:"recorrente"`
+	ProximoVencimento  string  `json:"proximoVencimento"`
+	MesesRestantes     int     `json:"mesesRestantes"`
+	AportadoNoCiclo    float64 `json:"aportadoNoCiclo"`
+	GastoNoCiclo       float64 `json:"gastoNoCiclo"`
+	Saldo              float64 `json:"saldo"`
+	Progresso          float64 `json:"progresso"`
+	ContribuicaoMensal float64 `json:"contribuicaoMensal"`
+	AportadoNoMes      float64 `json:"aportadoNoMes"`
+	Status             string  `json:"status"`
+}
+
+type EnvelopeDetalheResponse struct {
+	EnvelopeResponse
+	Movimentos []MovimentoEnvelopeResponse `json:"movimentos"`
+}
+
+type ResumoEnvelopeResponse struct {
+	EnvelopeID    uint    `json:"envelopeId"`
+	Nome          string  `json:"nome"`
+	Contribuicao  float64 `json:"contribuicao"`
+	AportadoNoMes float64 `json:"aportadoNoMes"`
+	GastoNoMes    float64 `json:"gastoNoMes"`
+}
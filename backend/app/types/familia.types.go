@@ -0,0 +1,105 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Familia agrupa usuários que dividem o mesmo orçamento. Despesas e limites
+// criados por um membro pertencem à família e são visíveis a todos os
+// membros; o UserID de cada despesa continua indicando quem a lançou.
+type Familia struct {
+	gorm.Model
+	Nome        string `json:"nome" gorm:"not null"`
+	CriadaPorID uint   `json:"criadaPorId" gorm:"not null"`
+}
+
+// MembroFamilia liga um usuário a uma família. Cada usuário participa de no
+// máximo uma família.
+type MembroFamilia struct {
+	gorm.Model
+	FamiliaID uint   `json:"familiaId" gorm:"not null;index"`
+	UserID    uint   `json:"userId" gorm:"not null;uniqueIndex"`
+	Papel     string `json:"papel" gorm:"not null"`
+	User      User   `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// ConviteFamilia é um convite enviado a um e-mail. Só o usuário com esse
+// e-mail pode aceitá-lo ou recusá-lo.
+type ConviteFamilia struct {
+	gorm.Model
+	FamiliaID      uint       `json:"familiaId" gorm:"not null;index"`
+	Email          string     `json:"email" gorm:"not null;index"`
+	Papel          string     `json:"papel" gorm:"not null"`
+	ConvidadoPorID uint       `json:"convidadoPorId" gorm:"not null"`
+	Status         string     `json:"status" gorm:"not null"`
+	ExpiraEm       time.Time  `json:"expiraEm"`
+	RespondidoEm   *time.Time `json:"respondidoEm"`
+}
+
+type FamiliaRequest struct {
+	Nome string `json:"nome"`
+}
+
+type ConviteFamiliaRequest struct {
+	Email string `json:"email"`
+	Papel string `json:"papel"`
+}
+
+type AceitarConviteRequest struct {
+	ImportarDespesas bool `json:"importarDespesas"`
+}
+
+type PapelMembroRequest struct {
+	Papel string `json:"papel"`
+}
+
+type MembroFamiliaResponse struct {
+	UserID      uint      `json:"userId"`
+	Nome        string    `json:"nome"`
+	Email       string    `json:"email"`
+	Papel       string    `json:"papel"`
+	MembroDesde time.Time `json:"membroDesde"`
+}
+
+type FamiliaResponse struct {
+	ID       uint                    `json:"id"`
+	Nome     string                  `json:"nome"`
+	MeuPapel string                  `json:"meuPapel"`
+	Membros  []MembroFamiliaResponse `json:"membros"`
+}
+
+type ConviteFamiliaResponse struct {
+	ID           uint      `json:"id"`
+	FamiliaID    uint      `json:"familiaId"`
+	Familia      string    `json:"familia,omitempty"`
+	Email        string    `json:"email"`
+	Papel        string    `json:"papel"`
+	Status       string    `json:"status"`
+	ConvidadoPor string    `json:"convidadoPor,omitempty"`
+	ExpiraEm     time.Time `json:"expiraEm"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// TotalMembro é o total de despesas lançadas por um membro no mês.
+type TotalMembro struct {
+	UserID     uint
+	Nome       string
+	Total      float64
+	Quantidade int64
+}
+
+type ResumoMembroResponse struct {
+	UserID       uint    `json:"userId"`
+	Nome         string  `json:"nome"`
+	Total        float64 `json:"total"`
+	Quantidade   int64   `json:"quantidade"`
+	Participacao float64 `json:"participacao"`
+}
+
+type ResumoFamiliaResponse struct {
+	MesReferencia string                 `json:"mesReferencia"`
+	TotalGasto    float64                `json:"totalGasto"`
+	Membros       []ResumoMembroResponse `json:"membros"`
+}
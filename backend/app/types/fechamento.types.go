@@ -0,0 +1,91 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FechamentoMes registra que um mês foi fechado: despesas e limites dele não
+// podem mais ser alterados até que seja reaberto. Os totais são uma foto do
+// mês no último fechamento. Na família, o fechamento vale para todos, por
+// isso há um único registro por família e mês (ou por usuário e mês, fora de
+// uma família).
+type FechamentoMes struct {
+	gorm.Model
+	MesReferencia      time.Time  `json:"mesReferencia" gorm:"type:date;not null;index;uniqueIndex:idx_fechamentos_familia_mes,where:familia_id IS NOT NULL AND deleted_at IS NULL;uniqueIndex:idx_fechamentos_usuario_mes,where:familia_id IS NULL AND deleted_at IS NULL"`
+	Status             string     `json:"status" gorm:"not null"`
+	FechadoEm          *time.Time `json:"fechadoEm"`
+	Automatico         bool       `json:"automatico"`
+	TotalDespesas      float64    `json:"totalDespesas"`
+	QuantidadeDespesas int64      `json:"quantidadeDespesas"`
+	LimiteGeral        *float64   `json:"limiteGeral"`
+	Categorias         string     `json:"categorias" gorm:"type:text"`
+	FamiliaID          *uint      `json:"familiaId,omitempty" gorm:"index;uniqueIndex:idx_fechamentos_familia_mes,priority:1"`
+	UserID             uint       `json:"userId" gorm:"not null;index;uniqueIndex:idx_fechamentos_usuario_mes,priority:1"`
+}
+
+// AuditoriaFechamento guarda cada fechamento e reabertura, com quem fez, o
+// motivo e o total de despesas naquele momento. Motivo é o texto informado
+// pelo usuário; CodigoMotivo, o motivo das ações do sistema, traduzido na
+// leitura.
+type AuditoriaFechamento struct {
+	gorm.Model
+	FechamentoID  uint    `json:"fechamentoId" gorm:"not null;index"`
+	UserID        uint    `json:"userId" gorm:"not null"`
+	Acao          string  `json:"acao" gorm:"not null"`
+	Motivo        string  `json:"motivo"`
+	CodigoMotivo  string  `json:"codigoMotivo,omitempty"`
+	TotalDespesas float64 `json:"totalDespesas"`
+}
+
+// ConfiguracaoFechamento ativa o fechamento automático: o mês é fechado
+// DiasCarencia dias depois do fim do período.
+type ConfiguracaoFechamento struct {
+	gorm.Model
+	UserID       uint `json:"userId" gorm:"not null;uniqueIndex"`
+	Automatico   bool `json:"automatico"`
+	DiasCarencia int  `json:"diasCarencia"`
+}
+
+type ConfiguracaoFechamentoRequest struct {
+	Automatico   bool `json:"automatico"`
+	DiasCarencia int  `json:"diasCarencia"`
+}
+
+type ConfiguracaoFechamentoResponse struct {
+	Automatico   bool `json:"automatico"`
+	DiasCarencia int  `json:"diasCarencia"`
+}
+
+type ReabrirMesRequest struct {
+	Motivo string `json:"motivo"`
+}
+
+type CategoriaFechamento struct {
+	Categoria  string   `json:"categoria"`
+	Total      float64  `json:"total"`
+	Quantidade int64    `json:"quantidade"`
+	Limite     *float64 `json:"limite,omitempty"`
+}
+
+type AuditoriaFechamentoResponse struct {
+	Acao          string    `json:"acao"`
+	Motivo        string    `json:"motivo,omitempty"`
+	CodigoMotivo  string    `json:"codigoMotivo,omitempty"`
+	UserID        uint      `json:"userId"`
+	TotalDespesas float64   `json:"totalDespesas"`
+	Data          time.Time `json:"data"`
+}
+
+type FechamentoMesResponse struct {
+	MesReferencia      string                        `json:"mesReferencia"`
+	Status             string                        `json:"status"`
+	FechadoEm          *time.Time                    `json:"fechadoEm,omitempty"`
+	Automatico         bool                          `json:"automatico"`
+	TotalDespesas      float64                       `json:"totalDespesas"`
+	QuantidadeDespesas int64                         `json:"quantidadeDespesas"`
+	LimiteGeral        *float64                      `json:"limiteGeral,omitempty"`
+	Categorias         []CategoriaFechamento         `json:"categorias,omitempty"`
+	Historico          []AuditoriaFechamentoResponse `json:"historico,omitempty"`
+}
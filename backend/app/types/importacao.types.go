@@ -0,0 +1,103 @@
+package types
+
+import (
+	"github.com/Vicente/Password-Mobile-App/backend/app/i18n"
+	"gorm.io/gorm"
+)
+
+type Importacao struct {
+	gorm.Model
+	UserID      uint    `json:"userId" gorm:"not null;index"`
+	FamiliaID   *uint   `json:"familiaId,omitempty" gorm:"index"`
+	Origem      string  `json:"origem"`
+	NomeArquivo string  `json:"nomeArquivo"`
+	Status      string  `json:"status"`
+	Quantidade  int     `json:"quantidade"`
+	ValorTotal  float64 `json:"valorTotal"`
+}
+
+type MapeamentoCSV struct {
+	Descricao          string `json:"descricao"`
+	Valor              string `json:"valor"`
+	Data               string `json:"data"`
+	FormatoData        string `json:"formatoData"`
+	Delimitador        string `json:"delimitador"`
+	Encoding           string `json:"encoding"`
+	SeparadorDecimal   string `json:"separadorDecimal"`
+	SemCabecalho       bool   `json:"semCabecalho"`
+	ApenasNegativos    bool   `json:"apenasNegativos"`
+	IgnorarErros       bool   `json:"ignorarErros"`
+	ImportarDuplicatas bool   `json:"importarDuplicatas"`
+}
+
+type LinhaImportacao struct {
+	Linha             int            `json:"linha"`
+	Tipo              string         `json:"tipo,omitempty"`
+	Descricao         string         `json:"descricao"`
+	Valor             float64        `json:"valor"`
+	Data              string         `json:"data,omitempty"`
+	MesReferencia     string         `json:"mesReferencia,omitempty"`
+	Categoria         string         `json:"categoria,omitempty"`
+	Ignorada          bool           `json:"ignorada,omitempty"`
+	PossivelDuplicata bool           `json:"possivelDuplicata,omitempty"`
+	Duplicatas        []uint         `json:"duplicatas,omitempty"`
+	Erro              *i18n.ErroItem `json:"erro,omitempty"`
+}
+
+type PreviewImportacaoResponse struct {
+	Encoding         string            `json:"encoding"`
+	Delimitador      string            `json:"delimitador"`
+	SeparadorDecimal string            `json:"separadorDecimal"`
+	FormatoData      string            `json:"formatoData"`
+	Colunas          []string          `json:"colunas"`
+	Mapeamento       MapeamentoCSV     `json:"mapeamento"`
+	TotalLinhas      int               `json:"totalLinhas"`
+	LinhasValidas    int               `json:"linhasValidas"`
+	LinhasComErro    int               `json:"linhasComErro"`
+	LinhasIgnoradas  int               `json:"linhasIgnoradas"`
+	LinhasDuplicadas int               `json:"linhasDuplicadas"`
+	ValorTotal       float64           `json:"valorTotal"`
+	Receitas         int               `json:"receitas"`
+	ValorReceitas    float64           `json:"valorReceitas"`
+	Linhas           []LinhaImportacao `json:"linhas"`
+}
+
+type TransacaoImportacaoOFX struct {
+	FITID             string  `json:"fitid"`
+	Tipo              string  `json:"tipo"`
+	Descricao         string  `json:"descricao"`
+	Valor             float64 `json:"valor"`
+	Data              string  `json:"data"`
+	MesReferencia     string  `json:"mesReferencia"`
+	Categoria         string  `json:"categoria,omitempty"`
+	Duplicada         bool    `json:"duplicada,omitempty"`
+	PossivelDuplicata bool    `json:"possivelDuplicata,omitempty"`
+	Duplicatas        []uint  `json:"duplicatas,omitempty"`
+	MesFechado        bool    `json:"mesFechado,omitempty"`
+}
+
+type PreviewOFXResponse struct {
+	Versao              string                   `json:"versao"`
+	BancoID             string                   `json:"bancoId,omitempty"`
+	ContaID             string                   `json:"contaId,omitempty"`
+	Moeda               string                   `json:"moeda,omitempty"`
+	Total               int                      `json:"total"`
+	Despesas            int                      `json:"despesas"`
+	Receitas            int                      `json:"receitas"`
+	Duplicadas          int                      `json:"duplicadas"`
+	PossiveisDuplicatas int                      `json:"possiveisDuplicatas"`
+	EmMesesFechados     int                      `json:"emMesesFechados"`
+	ValorDespesas       float64                  `json:"valorDespesas"`
+	ValorReceitas       float64                  `json:"valorReceitas"`
+	Transacoes          []TransacaoImportacaoOFX `json:"transacoes"`
+}
+
+type ImportacaoSimpleResponse struct {
+	ID          uint    `json:"id"`
+	Origem      string  `json:"origem"`
+	NomeArquivo string  `json:"nomeArquivo"`
+	Status      string  `json:"status"`
+	Quantidade  int     `json:"quantidade"`
+	ValorTotal  float64 `json:"valorTotal"`
+	CriadaEm    string  `json:"criadaEm"`
+}
@@ -9,21 +9,75 @@ type Limite struct {
 	gorm.Model
 	Valor         float64   `json:"valor" binding:"required,gt=0"`
 	MesReferencia time.Time `json:"mesReferencia" binding:"required" gorm:"type:date"`
+	Categoria     string    `json:"categoria,omitempty" gorm:"index"`
+	Rollover      bool      `json:"rollover"`
+	RolloverTeto  *float64  `json:"rolloverTeto"`
+	FamiliaID     *uint     `json:"familiaId,omitempty" gorm:"index"`
 	UserID        uint      `json:"userId" gorm:"not null"`
 	User          User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
 
 type CreateLimiteRequest struct {
-	Valor         float64 `json:"valor" binding:"required,gt=0"`
-	MesReferencia string  `json:"mesReferencia" binding:"required"`
+	Valor         float64  `json:"valor" binding:"required,gt=0"`
+	MesReferencia string   `json:"mesReferencia" binding:"required"`
+	Categoria     string   `json:"categoria"`
+	Rollover      bool     `json:"rollover"`
+	RolloverTeto  *float64 `json:"rolloverTeto"`
 }
 
 type UpdateLimiteRequest struct {
-	Valor float64 `json:"valor" binding:"required,gt=0"`
+	Valor        float64  `json:"valor" binding:"required,gt=0"`
+	Rollover     *bool    `json:"rollover"`
+	RolloverTeto *float64 `json:"rolloverTeto"`
 }
 
 type LimiteSimpleResponse struct {
-	ID            uint    `json:"id"`
-	Valor         float64 `json:"valor"`
-	MesReferencia string  `json:"mesReferencia"`
-} 
\ No newline at end of file
+	ID                uint     `json:"id"`
+	Valor             float64  `json:"valor"`
+	MesReferencia     string   `json:"mesReferencia"`
+	Categoria         string   `json:"categoria,omitempty"`
+	Rollover          bool     `json:"rollover"`
+	RolloverTeto      *float64 `json:"rolloverTeto,omitempty"`
+	ValorTransportado float64  `json:"valorTransportado"`
+	ValorEfetivo      float64  `json:"valorEfetivo"`
+}
+
+type LimiteConsumoResponse struct {
+	ID                uint    `json:"id"`
+	Categoria         string  `json:"categoria,omitempty"`
+	Valor             float64 `json:"valor"`
+	ValorTransportado float64 `json:"valorTransportado"`
+	ValorEfetivo      float64 `json:"valorEfetivo"`
+	Gasto             float64 `json:"gasto"`
+	Restante          float64 `json:"restante"`
+	PercentualUsado   float64 `json:"percentualUsado"`
+}
+
+type LimitesMesResponse struct {
+	MesReferencia string                  `json:"mesReferencia"`
+	Geral         *LimiteConsumoResponse  `json:"geral"`
+	Categorias    []LimiteConsumoResponse `json:"categorias"`
+}
+
+type ConfiguracaoLimite struct {
+	gorm.Model
+	UserID       uint     `json:"userId" gorm:"not null;uniqueIndex"`
+	Modo         string   `json:"modo"`
+	ValorPadrao  float64  `json:"valorPadrao"`
+	Rollover     bool     `json:"rollover"`
+	RolloverTeto *float64 `json:"rolloverTeto"`
+}
+
+type ConfiguracaoLimiteRequest struct {
+	Modo         string   `json:"modo"`
+	ValorPadrao  float64  `json:"valorPadrao"`
+	Rollover     bool     `json:"rollover"`
+	RolloverTeto *float64 `json:"rolloverTeto"`
+}
+
+type ConfiguracaoLimiteResponse struct {
+	Modo         string   `json:"modo"`
+	ValorPadrao  float64  `json:"valorPadrao,omitempty"`
+	Rollover     bool     `json:"rollover"`
+	RolloverTeto *float64 `json:"rolloverTeto,omitempty"`
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Meta é um objetivo de economia. Com ContribuicaoAutomatica, um aporte desse
+// valor é registrado uma vez por mês até a meta ser atingida.
+type Meta struct {
+	gorm.Model
+	Nome                   string     `json:"nome" gorm:"not null"`
+	ValorAlvo              float64    `json:"valorAlvo" gorm:"not null"`
+	DataAlvo               time.Time  `json:"dataAlvo" gorm:"type:date;not null"`
+	Conta                  string     `json:"conta"`
+	ContribuicaoAutomatica float64    `json:"contribuicaoAutomatica"`
+	ConcluidaEm            *time.Time `json:"concluidaEm"`
+	UserID                 uint       `json:"userId" gorm:"not null;index"`
+	User                   User       `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// AporteMeta guarda dinheiro em uma meta. Aportes automáticos têm uma Chave
+// com o mês, para que cada mês receba apenas um; nos manuais ela é nula.
+type AporteMeta struct {
+	gorm.Model
+	MetaID     uint      `json:"metaId" gorm:"not null;uniqueIndex:idx_aporte_meta_chave"`
+	UserID     uint      `json:"userId" gorm:"not null;index"`
+	Valor      float64   `json:"valor" gorm:"not null"`
+	Data       time.Time `json:"data" gorm:"type:date;not null"`
+	Descricao  string    `json:"descricao"`
+	Automatico bool      `json:"automatico"`
+	Chave      *string   `json:"-" gorm:"uniqueIndex:idx_aporte_meta_chave"`
+}
+
+type MetaRequest struct {
+	Nome                   string  `json:"nome"`
+	ValorAlvo              float64 `json:"valorAlvo"`
+	DataAlvo               string  `json:"dataAlvo"`
+	Conta                  string  `json:"conta"`
+	ContribuicaoAutomatica float64 `json:"contribuicaoAutomatica"`
+}
+
+type AporteMetaRequest struct {
+	Valor     float64 `json:"valor"`
+	Data      string  `json:"data"`
+	Descricao string  `json:"descricao"`
+}
+
+type AporteMetaResponse struct {
+	ID         uint    `json:"id"`
+	Valor      float64 `json:"valor"`
+	Data       string  `json:"data"`
+	Descricao  string  `json:"descricao,omitempty"`
+	Automatico bool    `json:"automatico"`
+}
+
+type MetaResponse struct {
+	ID                       uint       `json:"id"`
+	Nome                     string     `json:"nome"`
+	ValorAlvo                float64    `json:"valorAlvo"`
+	DataAlvo                 string     `json:"dataAlvo"`
+	Conta                    string     `json:"conta,omitempty"`
+	ContribuicaoAutomatica   float64    `json:"contribuicaoAutomatica"`
+	TotalAportado            float64    `json:"totalAportado"`
+	Restante                 float64    `json:"restante"`
+	Progresso                float64    `json:"progresso"`
+	MesesRestantes           int        `json:"mesesRestantes"`
+	EconomiaMensalNecessaria float64    `json:"economiaMensalNecessaria"`
+	Status                   string     `json:"status"`
+	ConcluidaEm              *time.Time `json:"concluidaEm,omitempty"`
+	CreatedAt                time.Time  `json:"createdAt"`
+}
+
+type MetaDetalheResponse struct {
+	MetaResponse
+	Aportes []AporteMetaResponse `json:"aportes"`
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Notificacao struct {
+	gorm.Model
+	UserID        uint       `json:"userId" gorm:"not null;uniqueIndex:idx_notificacao_chave"`
+	Chave         string     `json:"chave" gorm:"not null;uniqueIndex:idx_notificacao_chave"`
+	Tipo          string     `json:"tipo"`
+	Titulo        string     `json:"titulo"`
+	Mensagem      string     `json:"mensagem"`
+	Categoria     string     `json:"categoria,omitempty"`
+	MesReferencia *time.Time `json:"mesReferencia,omitempty" gorm:"type:date"`
+	Percentual    int        `json:"percentual,omitempty"`
+	Lida          bool       `json:"lida" gorm:"index"`
+	LidaEm        *time.Time `json:"lidaEm,omitempty"`
+	User          User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+type NotificacaoSimpleResponse struct {
+	ID            uint   `json:"id"`
+	Tipo          string `json:"tipo"`
+	Titulo        string `json:"titulo"`
+	Mensagem      string `json:"mensagem"`
+	Categoria     string `json:"categoria,omitempty"`
+	MesReferencia string `json:"mesReferencia,omitempty"`
+	Percentual    int    `json:"percentual,omitempty"`
+	Lida          bool   `json:"lida"`
+	LidaEm        string `json:"lidaEm,omitempty"`
+	CriadaEm      string `json:"criadaEm"`
+}
+
+type NotificacoesResponse struct {
+	NaoLidas     int64                       `json:"naoLidas"`
+	Notificacoes []NotificacaoSimpleResponse `json:"notificacoes"`
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MensagemOutbox é gravada na mesma transação que originou o evento e
+// depois entregue pelo despachante em segundo plano.
+type MensagemOutbox struct {
+	gorm.Model
+	UserID           uint       `json:"userId" gorm:"not null;index"`
+	Canal            string     `json:"canal" gorm:"not null"`
+	NotificacaoID    *uint      `json:"notificacaoId,omitempty" gorm:"index"`
+	Titulo           string     `json:"titulo"`
+	Conteudo         string     `json:"conteudo"`
+	Dados            string     `json:"dados,omitempty"`
+	Status           string     `json:"status" gorm:"not null;index:idx_outbox_pendentes"`
+	Tentativas       int        `json:"tentativas"`
+	ProximaTentativa time.Time  `json:"proximaTentativa" gorm:"index:idx_outbox_pendentes"`
+	UltimoErro       string     `json:"ultimoErro,omitempty"`
+	ProcessadaEm     *time.Time `json:"processadaEm,omitempty"`
+}
+
+type DispositivoPush struct {
+	gorm.Model
+	UserID     uint       `json:"userId" gorm:"not null;index"`
+	Token      string     `json:"token" gorm:"not null;uniqueIndex"`
+	Plataforma string     `json:"plataforma"`
+	Ativo      bool       `json:"ativo"`
+	UltimoUso  *time.Time `json:"ultimoUso,omitempty"`
+	User       User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+type RegistrarDispositivoRequest struct {
+	Token      string `json:"token"`
+	Plataforma string `json:"plataforma"`
+}
+
+type DispositivoSimpleResponse struct {
+	ID         uint   `json:"id"`
+	Token      string `json:"token"`
+	Plataforma string `json:"plataforma"`
+	Ativo      bool   `json:"ativo"`
+	CriadoEm   string `json:"criadoEm"`
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ConfiguracaoPeriodo define como o usuário divide o tempo do orçamento. No
+// tipo mensal, o mês de referência passa a começar no DiaInicio (ou no
+// DiaInicio-ésimo dia útil); nos tipos semanal e quinzenal, o orçamento de
+// cada período é o ValorPadrao.
+type ConfiguracaoPeriodo struct {
+	gorm.Model
+	UserID      uint       `json:"userId" gorm:"not null;uniqueIndex"`
+	Tipo        string     `json:"tipo"`
+	DiaInicio   int        `json:"diaInicio"`
+	DiaUtil     bool       `json:"diaUtil"`
+	DiaSemana   int        `json:"diaSemana"`
+	DataInicio  *time.Time `json:"dataInicio" gorm:"type:date"`
+	ValorPadrao float64    `json:"valorPadrao"`
+}
+
+type ConfiguracaoPeriodoRequest struct {
+	Tipo        string  `json:"tipo"`
+	DiaInicio   int     `json:"diaInicio"`
+	DiaUtil     bool    `json:"diaUtil"`
+	DiaSemana   int     `json:"diaSemana"`
+	DataInicio  string  `json:"dataInicio"`
+	ValorPadrao float64 `json:"valorPadrao"`
+}
+
+type ConfiguracaoPeriodoResponse struct {
+	Tipo        string  `json:"tipo"`
+	DiaInicio   int     `json:"diaInicio,omitempty"`
+	DiaUtil     bool    `json:"diaUtil,omitempty"`
+	DiaSemana   *int    `json:"diaSemana,omitempty"`
+	DataInicio  string  `json:"dataInicio,omitempty"`
+	ValorPadrao float64 `json:"valorPadrao,omitempty"`
+}
+
+type ResumoPeriodoResponse struct {
+	Tipo               string   `json:"tipo"`
+	MesReferencia      string   `json:"mesReferencia,omitempty"`
+	Inicio             string   `json:"inicio"`
+	Fim                string   `json:"fim"`
+	DiasNoPeriodo      int      `json:"diasNoPeriodo"`
+	DiasDecorridos     int      `json:"diasDecorridos"`
+	Limite             *float64 `json:"limite"`
+	Gasto              float64  `json:"gasto"`
+	Restante           *float64 `json:"restante"`
+	PercentualUsado    *float64 `json:"percentualUsado"`
+	QuantidadeDespesas int64    `json:"quantidadeDespesas"`
+	MediaDiaria        float64  `json:"mediaDiaria"`
+	Projecao           float64  `json:"projecao"`
+}
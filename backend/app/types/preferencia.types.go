@@ -0,0 +1,37 @@
+package types
+
+import "gorm.io/gorm"
+
+// Preferencia guarda as escolhas do usuário que mudam o comportamento padrão
+// dos serviços. É criada no cadastro; usuários antigos, sem registro, usam
+// os mesmos valores padrão. NotificacoesPush não tem default na coluna
+// porque o GORM trocaria o false informado pelo default ao criar.
+type Preferencia struct {
+	gorm.Model
+	UserID           uint   `json:"userId" gorm:"not null;uniqueIndex"`
+	Idioma           string `json:"idioma" gorm:"not null;default:pt-BR"`
+	Moeda            string `json:"moeda" gorm:"not null;default:BRL"`
+	FusoHorario      string `json:"fusoHorario" gorm:"not null;default:America/Sao_Paulo"`
+	DiaInicioMes     int    `json:"diaInicioMes" gorm:"not null;default:1"`
+	NotificacoesPush bool   `json:"notificacoesPush" gorm:"not null"`
+	CategoriaPadrao  string `json:"categoriaPadrao"`
+}
+
+// PreferenciaRequest é parcial: só os campos informados são alterados.
+type PreferenciaRequest struct {
+	Idioma           *string `json:"idioma"`
+	Moeda            *string `json:"moeda"`
+	FusoHorario      *string `json:"fusoHorario"`
+	DiaInicioMes     *int    `json:"diaInicioMes"`
+	NotificacoesPush *bool   `json:"notificacoesPush"`
+	CategoriaPadrao  *string `json:"categoriaPadrao"`
+}
+
+type PreferenciaResponse struct {
+	Idioma           string `json:"idioma"`
+	Moeda            string `json:"moeda"`
+	FusoHorario      string `json:"fusoHorario"`
+	DiaInicioMes     int    `json:"diaInicioMes"`
+	NotificacoesPush bool   `json:"notificacoesPush"`
+	CategoriaPadrao  string `json:"categoriaPadrao"`
+}
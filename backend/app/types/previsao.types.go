@@ -0,0 +1,26 @@
+package types
+
+type DespesaRecorrenteResponse struct {
+	Descricao     string  `json:"descricao"`
+	ValorEsperado float64 `json:"valorEsperado"`
+	Paga          bool    `json:"paga"`
+}
+
+type PrevisaoMesResponse struct {
+	MesReferencia        string                      `json:"mesReferencia"`
+	GastoAtual           float64                     `json:"gastoAtual"`
+	DiasDecorridos       int                         `json:"diasDecorridos"`
+	DiasNoMes            int                         `json:"diasNoMes"`
+	RitmoDiario          float64                     `json:"ritmoDiario"`
+	RecorrentesPagas     float64                     `json:"recorrentesPagas"`
+	RecorrentesPendentes float64                     `json:"recorrentesPendentes"`
+	MediaHistorica       *float64                    `json:"mediaHistorica"`
+	MesesHistorico       int                         `json:"mesesHistorico"`
+	Previsao             float64                     `json:"previsao"`
+	PrevisaoMinima       float64                     `json:"previsaoMinima"`
+	PrevisaoMaxima       float64                     `json:"previsaoMaxima"`
+	Limite               *float64                    `json:"limite"`
+	ExcedeLimite         bool                        `json:"excedeLimite"`
+	ExcessoPrevisto      *float64                    `json:"excessoPrevisto,omitempty"`
+	Recorrentes          []DespesaRecorrenteResponse `json:"recorrentes"`
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Receita struct {
+	gorm.Model
+	Descricao     string     `json:"descricao"`
+	Valor         float64    `json:"valor"`
+	MesReferencia time.Time  `json:"mesReferencia" gorm:"type:date"`
+	Data          *time.Time `json:"data,omitempty" gorm:"type:date"`
+	Conta         string     `json:"conta,omitempty" gorm:"uniqueIndex:idx_receitas_conta_fitid"`
+	FITID         string     `json:"fitid,omitempty" gorm:"index;uniqueIndex:idx_receitas_conta_fitid,where:fit_id <> '' AND deleted_at IS NULL"`
+	ImportacaoID  *uint      `json:"importacaoId,omitempty" gorm:"index"`
+	FamiliaID     *uint      `json:"familiaId,omitempty" gorm:"index"`
+	UserID        uint       `json:"userId" gorm:"not null;uniqueIndex:idx_receitas_conta_fitid,priority:1"`
+	User          User       `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+type ReceitaSimpleResponse struct {
+	ID            uint    `json:"id"`
+	Descricao     string  `json:"descricao"`
+	Valor         float64 `json:"valor"`
+	MesReferencia string  `json:"mesReferencia"`
+	Data          string  `json:"data,omitempty"`
+	Conta         string  `json:"conta,omitempty"`
+}
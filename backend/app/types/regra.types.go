@@ -0,0 +1,69 @@
+package types
+
+import (
+	"gorm.io/gorm"
+)
+
+type RegraCategorizacao struct {
+	gorm.Model
+	UserID               uint     `json:"userId" gorm:"not null;index"`
+	Nome                 string   `json:"nome"`
+	Ordem                int      `json:"ordem"`
+	Ativa                bool     `json:"ativa"`
+	DescricaoContem      string   `json:"descricaoContem"`
+	DescricaoRegex       string   `json:"descricaoRegex"`
+	ValorMinimo          *float64 `json:"valorMinimo"`
+	ValorMaximo          *float64 `json:"valorMaximo"`
+	Conta                string   `json:"conta"`
+	Categoria            string   `json:"categoria"`
+	Tags                 string   `json:"tags"`
+	DescricaoNormalizada string   `json:"descricaoNormalizada"`
+}
+
+type RegraRequest struct {
+	Nome                 string   `json:"nome"`
+	Ativa                *bool    `json:"ativa"`
+	DescricaoContem      string   `json:"descricaoContem"`
+	DescricaoRegex       string   `json:"descricaoRegex"`
+	ValorMinimo          *float64 `json:"valorMinimo"`
+	ValorMaximo          *float64 `json:"valorMaximo"`
+	Conta                string   `json:"conta"`
+	Categoria            string   `json:"categoria"`
+	Tags                 []string `json:"tags"`
+	DescricaoNormalizada string   `json:"descricaoNormalizada"`
+}
+
+type OrdenarRegrasRequest struct {
+	IDs []uint `json:"ids"`
+}
+
+type AplicarRegrasRequest struct {
+	RegraID               uint `json:"regraId"`
+	SobrescreverCategoria bool `json:"sobrescreverCategoria"`
+}
+
+type RegraSimpleResponse struct {
+	ID                   uint     `json:"id"`
+	Nome                 string   `json:"nome"`
+	Ordem                int      `json:"ordem"`
+	Ativa                bool     `json:"ativa"`
+	DescricaoContem      string   `json:"descricaoContem,omitempty"`
+	DescricaoRegex       string   `json:"descricaoRegex,omitempty"`
+	ValorMinimo          *float64 `json:"valorMinimo,omitempty"`
+	ValorMaximo          *float64 `json:"valorMaximo,omitempty"`
+	Conta                string   `json:"conta,omitempty"`
+	Categoria            string   `json:"categoria,omitempty"`
+	Tags                 []string `json:"tags,omitempty"`
+	DescricaoNormalizada string   `json:"descricaoNormalizada,omitempty"`
+}
+
+type TesteRegraResponse struct {
+	Total    int                     `json:"total"`
+	Despesas []DespesaSimpleResponse `json:"despesas"`
+}
+
+type AplicacaoRegrasResponse struct {
+	Analisadas  int `json:"analisadas"`
+	Atualizadas int `json:"atualizadas"`
+	Bloqueadas  int `json:"bloqueadas"`
+}
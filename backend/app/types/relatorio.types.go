@@ -0,0 +1,55 @@
+package types
+
+import "time"
+
+type TotalMes struct {
+	MesReferencia time.Time `json:"mesReferencia"`
+	Total         float64   `json:"total"`
+	Quantidade    int64     `json:"quantidade"`
+}
+
+type TotalDescricaoMes struct {
+	Descricao     string    `json:"descricao"`
+	MesReferencia time.Time `json:"mesReferencia"`
+	Total         float64   `json:"total"`
+	Quantidade    int64     `json:"quantidade"`
+}
+
+type RelatorioMesResponse struct {
+	MesReferencia      string   `json:"mesReferencia"`
+	Total              float64  `json:"total"`
+	Quantidade         int64    `json:"quantidade"`
+	Limite             *float64 `json:"limite"`
+	Diferenca          *float64 `json:"diferenca"`
+	DentroDoLimite     *bool    `json:"dentroDoLimite"`
+	VariacaoValor      *float64 `json:"variacaoValor"`
+	VariacaoPercentual *float64 `json:"variacaoPercentual"`
+}
+
+type RelatorioCategoriaResponse struct {
+	Categoria    string  `json:"categoria"`
+	Total        float64 `json:"total"`
+	Quantidade   int64   `json:"quantidade"`
+	Participacao float64 `json:"participacao"`
+	MediaMensal  float64 `json:"mediaMensal"`
+}
+
+type RelatorioResponse struct {
+	Inicio              string                       `json:"inicio"`
+	Fim                 string                       `json:"fim"`
+	TotalGasto          float64                      `json:"totalGasto"`
+	QuantidadeDespesas  int64                        `json:"quantidadeDespesas"`
+	MesesConsiderados   int                          `json:"mesesConsiderados"`
+	MediaMensal         float64                      `json:"mediaMensal"`
+	MediaLimite         *float64                     `json:"mediaLimite"`
+	MesesDentroDoLimite int                          `json:"mesesDentroDoLimite"`
+	MesesAcimaDoLimite  int                          `json:"mesesAcimaDoLimite"`
+	Meses               []RelatorioMesResponse       `json:"meses"`
+	TopCategorias       []RelatorioCategoriaResponse `json:"topCategorias"`
+}
+
+type TotalCategoriaMes struct {
+	Categoria     string    `json:"categoria"`
+	MesReferencia time.Time `json:"mesReferencia"`
+	Total         float64   `json:"total"`
+}
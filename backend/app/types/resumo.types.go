@@ -0,0 +1,26 @@
+package types
+
+type ResumoCategoriaResponse struct {
+	Categoria       string   `json:"categoria"`
+	Total           float64  `json:"total"`
+	Quantidade      int64    `json:"quantidade"`
+	Participacao    float64  `json:"participacao"`
+	Limite          *float64 `json:"limite,omitempty"`
+	Restante        *float64 `json:"restante,omitempty"`
+	PercentualUsado *float64 `json:"percentualUsado,omitempty"`
+}
+
+type ResumoMesResponse struct {
+	MesReferencia         string                    `json:"mesReferencia"`
+	Limite                *float64                  `json:"limite"`
+	TotalGasto            float64                   `json:"totalGasto"`
+	Restante              *float64                  `json:"restante"`
+	PercentualUsado       *float64                  `json:"percentualUsado"`
+	QuantidadeDespesas    int64                     `json:"quantidadeDespesas"`
+	DiasConsiderados      int                       `json:"diasConsiderados"`
+	MediaDiaria           float64                   `json:"mediaDiaria"`
+	ProjecaoFimMes        float64                   `json:"projecaoFimMes"`
+	Categorias            []ResumoCategoriaResponse `json:"categorias"`
+	ContribuicaoEnvelopes float64                   `json:"contribuicaoEnvelopes"`
+	Envelopes             []ResumoEnvelopeResponse  `json:"envelopes"`
+}
@@ -0,0 +1,32 @@
+package types
+
+type SugestaoLimite struct {
+	Categoria       string   `json:"categoria,omitempty"`
+	ValorSugerido   float64  `json:"valorSugerido"`
+	Mediana         float64  `json:"mediana"`
+	MediaAparada    float64  `json:"mediaAparada"`
+	FatorSazonal    float64  `json:"fatorSazonal"`
+	MesesAnalisados int      `json:"mesesAnalisados"`
+	LimiteAtual     *float64 `json:"limiteAtual,omitempty"`
+}
+
+type SugestaoLimitesResponse struct {
+	MesReferencia string           `json:"mesReferencia"`
+	Inicio        string           `json:"inicio"`
+	Fim           string           `json:"fim"`
+	Geral         *SugestaoLimite  `json:"geral"`
+	Categorias    []SugestaoLimite `json:"categorias"`
+}
+
+type AplicarSugestaoRequest struct {
+	Meses        int      `json:"meses"`
+	IncluirGeral *bool    `json:"incluirGeral"`
+	Categorias   []string `json:"categorias"`
+	Sobrescrever bool     `json:"sobrescrever"`
+}
+
+type AplicarSugestaoResponse struct {
+	Criados     []LimiteSimpleResponse `json:"criados"`
+	Atualizados []LimiteSimpleResponse `json:"atualizados"`
+	Ignorados   []string               `json:"ignorados"`
+}
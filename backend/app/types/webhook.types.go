@@ -0,0 +1,69 @@
+package types
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Webhook struct {
+	gorm.Model
+	UserID    uint   `json:"userId" gorm:"not null;index"`
+	URL       string `json:"url" gorm:"not null"`
+	Descricao string `json:"descricao"`
+	Segredo   string `json:"-" gorm:"not null"`
+	Eventos   string `json:"eventos"`
+	Ativo     bool   `json:"ativo"`
+	User      User   `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// EntregaWebhook é ao mesmo tempo a fila de envio e o histórico de entregas
+// de um webhook. O EventoID se repete em todas as tentativas e permite ao
+// destinatário descartar eventos já recebidos.
+type EntregaWebhook struct {
+	gorm.Model
+	WebhookID        uint       `json:"webhookId" gorm:"not null;uniqueIndex:idx_entrega_webhook_evento"`
+	EventoID         string     `json:"eventoId" gorm:"not null;uniqueIndex:idx_entrega_webhook_evento"`
+	UserID           uint       `json:"userId" gorm:"not null;index"`
+	Evento           string     `json:"evento"`
+	Payload          string     `json:"payload"`
+	Status           string     `json:"status" gorm:"not null;index:idx_entrega_webhook_pendentes"`
+	Tentativas       int        `json:"tentativas"`
+	ProximaTentativa time.Time  `json:"proximaTentativa" gorm:"index:idx_entrega_webhook_pendentes"`
+	StatusHTTP       int        `json:"statusHttp"`
+	Resposta         string     `json:"resposta,omitempty"`
+	UltimoErro       string     `json:"ultimoErro,omitempty"`
+	EntregueEm       *time.Time `json:"entregueEm,omitempty"`
+}
+
+type WebhookRequest struct {
+	URL              string   `json:"url"`
+	Descricao        string   `json:"descricao"`
+	Eventos          []string `json:"eventos"`
+	Ativo            *bool    `json:"ativo"`
+	RegenerarSegredo bool     `json:"regenerarSegredo"`
+}
+
+type WebhookSimpleResponse struct {
+	ID        uint     `json:"id"`
+	URL       string   `json:"url"`
+	Descricao string   `json:"descricao,omitempty"`
+	Eventos   []string `json:"eventos"`
+	Ativo     bool     `json:"ativo"`
+	Segredo   string   `json:"segredo,omitempty"`
+	CriadoEm  string   `json:"criadoEm"`
+}
+
+type EntregaWebhookResponse struct {
+	ID               uint   `json:"id"`
+	EventoID         string `json:"eventoId"`
+	Evento           string `json:"evento"`
+	Status           string `json:"status"`
+	Tentativas       int    `json:"tentativas"`
+	ProximaTentativa string `json:"proximaTentativa,omitempty"`
+	StatusHTTP       int    `json:"statusHttp,omitempty"`
+	Resposta         string `json:"resposta,omitempty"`
+	UltimoErro       string `json:"ultimoErro,omitempty"`
+	EntregueEm       string `json:"entregueEm,omitempty"`
+	CriadaEm         string `json:"criadaEm"`
+}
@@ -6,17 +6,27 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/Vicente/Password-Mobile-App/backend/app/controllers"
 	"github.com/Vicente/Password-Mobile-App/backend/app/dal"
+	"github.com/Vicente/Password-Mobile-App/backend/app/jobs"
+	"github.com/Vicente/Password-Mobile-App/backend/app/middleware"
+	"github.com/Vicente/Password-Mobile-App/backend/app/push"
+	"github.com/Vicente/Password-Mobile-App/backend/app/relogio"
 	"github.com/Vicente/Password-Mobile-App/backend/app/routes"
 	"github.com/Vicente/Password-Mobile-App/backend/app/services"
 	"github.com/Vicente/Password-Mobile-App/backend/app/types"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+
+	// Embute a base de fusos horários para que time.LoadLocation funcione
+	// mesmo em imagens sem /usr/share/zoneinfo
+	_ "time/tzdata"
 )
 
 func generateRandomSecret() string {
@@ -27,6 +37,28 @@ func generateRandomSecret() string {
 	return hex.EncodeToString(bytes)
 }
 
+func newPushProvider() push.PushProvider {
+	if os.Getenv("PUSH_PROVIDER") == "fake" {
+		log.Println("Usando provedor de push falso: as notificações não serão enviadas")
+		return push.NewFakeProvider()
+	}
+	return push.NewExpoProvider(os.Getenv("EXPO_ACCESS_TOKEN"))
+}
+
+// newRelogio permite fixar a data do servidor com DATA_FIXA (RFC3339), útil
+// para testar viradas de mês sem mexer no relógio da máquina.
+func newRelogio() relogio.Relogio {
+	if dataFixa := os.Getenv("DATA_FIXA"); dataFixa != "" {
+		agora, err := time.Parse(time.RFC3339, dataFixa)
+		if err != nil {
+			log.Fatalf("DATA_FIXA inválida, use RFC3339: %v", err)
+		}
+		log.Printf("Usando relógio fixo em %s", agora.Format(time.RFC3339))
+		return relogio.NewFixoRelogio(agora)
+	}
+	return relogio.NewSistemaRelogio()
+}
+
 func main() {
 	if err := godotenv.Load(); err != nil {
 		log.Println("Arquivo .env não encontrado, usando variáveis de ambiente do sistema")
@@ -52,34 +84,144 @@ func main() {
 		log.Fatalf("Falha ao conectar ao banco de dados: %v", err)
 	}
 
-	if err := db.AutoMigrate(&types.User{}, &types.Limite{}, &types.Despesa{}); err != nil {
+	if err := db.AutoMigrate(&types.User{}, &types.Limite{}, &types.Despesa{}, &types.Importacao{}, &types.Receita{}, &types.RegraCategorizacao{}, &types.ConfiguracaoLimite{}, &types.ConfiguracaoAlerta{}, &types.Notificacao{}, &types.MensagemOutbox{}, &types.DispositivoPush{}, &types.Webhook{}, &types.EntregaWebhook{}, &types.ConfiguracaoPeriodo{}, &types.Envelope{}, &types.MovimentoEnvelope{}, &types.Meta{}, &types.AporteMeta{}, &types.Divida{}, &types.PagamentoDivida{}, &types.Familia{}, &types.MembroFamilia{}, &types.ConviteFamilia{}, &types.GrupoDivisao{}, &types.MembroGrupoDivisao{}, &types.Divisao{}, &types.ParticipanteDivisao{}, &types.AcertoDivisao{}, &types.FechamentoMes{}, &types.AuditoriaFechamento{}, &types.ConfiguracaoFechamento{}, &types.Preferencia{}); err != nil {
 		log.Fatalf("Falha ao migrar modelos: %v", err)
 	}
 
+	relogioServidor := newRelogio()
+	// A validade dos tokens é conferida com o mesmo relógio que os emite
+	jwt.TimeFunc = relogioServidor.Agora
+
 	authDAL := dal.NewAuthDAL(db)
-	authService := services.NewAuthService(authDAL)
-	authController := controllers.NewAuthController(authService)
+	authService := services.NewAuthService(authDAL, relogioServidor)
+	authController := controllers.NewAuthController(authService, relogioServidor)
+
+	despesaDAL := dal.NewDespesaDAL(db)
+
+	preferenciaDAL := dal.NewPreferenciaDAL(db)
+	preferenciaService := services.NewPreferenciaService(preferenciaDAL)
+	preferenciaController := controllers.NewPreferenciaController(preferenciaService)
+
+	periodoDAL := dal.NewPeriodoDAL(db)
+	periodoService := services.NewPeriodoService(periodoDAL, preferenciaService, relogioServidor)
+	periodoController := controllers.NewPeriodoController(periodoService)
+
+	notificacaoDAL := dal.NewNotificacaoDAL(db)
+
+	familiaDAL := dal.NewFamiliaDAL(db)
+	familiaService := services.NewFamiliaService(familiaDAL, authDAL, despesaDAL, notificacaoDAL, preferenciaService, relogioServidor)
+	familiaController := controllers.NewFamiliaController(familiaService)
 
 	limiteDAL := dal.NewLimiteDAL(db)
-	limiteService := services.NewLimiteService(limiteDAL)
+
+	fechamentoDAL := dal.NewFechamentoDAL(db)
+	fechamentoService := services.NewFechamentoService(fechamentoDAL, despesaDAL, limiteDAL, periodoService, familiaService)
+	fechamentoController := controllers.NewFechamentoController(fechamentoService)
+
+	limiteService := services.NewLimiteService(limiteDAL, despesaDAL, periodoService, familiaService, fechamentoService)
 	limiteController := controllers.NewLimiteController(limiteService)
 
-	despesaDAL := dal.NewDespesaDAL(db)
-	despesaService := services.NewDespesaService(despesaDAL)
+	regraDAL := dal.NewRegraDAL(db)
+	regraService := services.NewRegraService(regraDAL, despesaDAL, fechamentoService, preferenciaService)
+	regraController := controllers.NewRegraController(regraService)
+
+	relatorioDAL := dal.NewRelatorioDAL(db)
+
+	previsaoService := services.NewPrevisaoService(despesaDAL, relatorioDAL, limiteService)
+	previsaoController := controllers.NewPrevisaoController(previsaoService)
+
+	sugestaoService := services.NewSugestaoService(relatorioDAL, limiteDAL, limiteService)
+	sugestaoController := controllers.NewSugestaoController(sugestaoService)
+
+	webhookDAL := dal.NewWebhookDAL(db)
+	webhookService := services.NewWebhookService(webhookDAL, relogioServidor)
+	webhookController := controllers.NewWebhookController(webhookService)
+
+	notificacaoService := services.NewNotificacaoService(notificacaoDAL, relogioServidor)
+	notificacaoController := controllers.NewNotificacaoController(notificacaoService)
+
+	alertaDAL := dal.NewAlertaDAL(db)
+	alertaService := services.NewAlertaService(alertaDAL, notificacaoDAL, limiteService, webhookService, previsaoService, preferenciaService)
+	alertaController := controllers.NewAlertaController(alertaService)
+
+	despesaService := services.NewDespesaService(despesaDAL, regraService, alertaService, webhookService, periodoService, fechamentoService)
 	despesaController := controllers.NewDespesaController(despesaService)
 
+	dividaDAL := dal.NewDividaDAL(db)
+	dividaService := services.NewDividaService(dividaDAL, despesaService, periodoService)
+	dividaController := controllers.NewDividaController(dividaService)
+
+	receitaDAL := dal.NewReceitaDAL(db)
+	receitaService := services.NewReceitaService(receitaDAL)
+	receitaController := controllers.NewReceitaController(receitaService)
+
+	importacaoDAL := dal.NewImportacaoDAL(db)
+	importacaoService := services.NewImportacaoService(importacaoDAL, despesaDAL, despesaService, regraService, periodoService, fechamentoService)
+	importacaoController := controllers.NewImportacaoController(importacaoService)
+
+	envelopeDAL := dal.NewEnvelopeDAL(db)
+	envelopeService := services.NewEnvelopeService(envelopeDAL, despesaDAL, periodoService)
+	envelopeController := controllers.NewEnvelopeController(envelopeService)
+
+	metaDAL := dal.NewMetaDAL(db)
+	metaService := services.NewMetaService(metaDAL, periodoService)
+	metaController := controllers.NewMetaController(metaService)
+
+	divisaoDAL := dal.NewDivisaoDAL(db)
+	divisaoService := services.NewDivisaoService(divisaoDAL, authDAL, periodoService)
+	divisaoController := controllers.NewDivisaoController(divisaoService)
+
+	resumoService := services.NewResumoService(limiteService, despesaDAL, periodoService, envelopeService)
+	resumoController := controllers.NewResumoController(resumoService)
+
+	relatorioService := services.NewRelatorioService(relatorioDAL, limiteService)
+	relatorioController := controllers.NewRelatorioController(relatorioService)
+
+	dispositivoDAL := dal.NewDispositivoDAL(db)
+	dispositivoService := services.NewDispositivoService(dispositivoDAL)
+	dispositivoController := controllers.NewDispositivoController(dispositivoService)
+
+	outboxDAL := dal.NewOutboxDAL(db)
+	outboxService := services.NewOutboxService(outboxDAL, newPushProvider())
+
+	jobs.IniciarCriacaoAutomaticaLimites(limiteService, relogioServidor, time.Hour)
+	jobs.IniciarDespachoOutbox(outboxService, relogioServidor, 10*time.Second)
+	jobs.IniciarDespachoWebhooks(webhookService, relogioServidor, 10*time.Second)
+	jobs.IniciarAportesAutomaticosMetas(metaService, relogioServidor, time.Hour)
+	jobs.IniciarFechamentoAutomaticoMeses(fechamentoService, relogioServidor, time.Hour)
+
 	app := fiber.New()
 
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "*",
 		AllowMethods:     "GET,POST,HEAD,PUT,DELETE,PATCH",
-		AllowHeaders:     "Origin,Content-Type,Accept,Authorization",
+		AllowHeaders:     "Origin,Content-Type,Accept,Accept-Language,Authorization",
 		AllowCredentials: false,
 	}))
+	app.Use(middleware.IdiomaMiddleware(preferenciaService.IdiomaDoUsuario))
 
 	routes.SetupAuthRoutes(app, authController)
 	routes.SetupLimiteRoutes(app, limiteController)
 	routes.SetupDespesaRoutes(app, despesaController)
+	routes.SetupReceitaRoutes(app, receitaController)
+	routes.SetupRegraRoutes(app, regraController)
+	routes.SetupImportacaoRoutes(app, importacaoController)
+	routes.SetupResumoRoutes(app, resumoController)
+	routes.SetupRelatorioRoutes(app, relatorioController)
+	routes.SetupAlertaRoutes(app, alertaController)
+	routes.SetupNotificacaoRoutes(app, notificacaoController)
+	routes.SetupDispositivoRoutes(app, dispositivoController)
+	routes.SetupWebhookRoutes(app, webhookController)
+	routes.SetupPrevisaoRoutes(app, previsaoController)
+	routes.SetupSugestaoRoutes(app, sugestaoController)
+	routes.SetupPeriodoRoutes(app, periodoController)
+	routes.SetupEnvelopeRoutes(app, envelopeController)
+	routes.SetupMetaRoutes(app, metaController)
+	routes.SetupDividaRoutes(app, dividaController)
+	routes.SetupFamiliaRoutes(app, familiaController)
+	routes.SetupDivisaoRoutes(app, divisaoController)
+	routes.SetupFechamentoRoutes(app, fechamentoController)
+	routes.SetupPreferenciaRoutes(app, preferenciaController)
 
 	port := os.Getenv("PORT")
 	if port == "" {